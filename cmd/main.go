@@ -1,28 +1,49 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/xprasetio/go-spotify/internal/configs"
 	membershipsHandler "github.com/xprasetio/go-spotify/internal/handler/memberships"
+	playlistsHandler "github.com/xprasetio/go-spotify/internal/handler/playlists"
 	tracksHandler "github.com/xprasetio/go-spotify/internal/handler/tracks"
+	"github.com/xprasetio/go-spotify/internal/middleware"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
-	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
 	membershipsRepo "github.com/xprasetio/go-spotify/internal/repository/memberships"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
 	"github.com/xprasetio/go-spotify/internal/repository/spotify"
 	trackactivitiesRepo "github.com/xprasetio/go-spotify/internal/repository/trackactivities"
 	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	playlistsSvc "github.com/xprasetio/go-spotify/internal/service/playlists"
 	"github.com/xprasetio/go-spotify/internal/service/tracks"
+	"github.com/xprasetio/go-spotify/pkg/cache"
+	"github.com/xprasetio/go-spotify/pkg/encryption"
+	"github.com/xprasetio/go-spotify/pkg/fakespotify"
 	"github.com/xprasetio/go-spotify/pkg/httpclient"
 	"github.com/xprasetio/go-spotify/pkg/internalsql"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
+	"github.com/xprasetio/go-spotify/pkg/mail"
+	"github.com/xprasetio/go-spotify/pkg/revocation"
+	"gorm.io/gorm"
 )
 
 func main() {
 	var (
 		cfg *configs.Config
 	)
+	deleteOrphans := flag.Bool("delete-orphans", false, "delete the rows of deleted users and playlists that keep foreign keys from being added, then exit")
+	flag.Parse()
 
 	err := configs.Init(
 		configs.WithConfigFolder([]string{
@@ -36,25 +57,103 @@ func main() {
 		log.Fatalf("failed to initialize configs: %v", err)
 	}
 	cfg = configs.Get()
+	// local runs may start without keys, random ones are used that don't
+	// survive a restart
+	localRun := cfg.Service.AllowEphemeralKeys || cfg.SpotifyConfig.UseFakeServer
+	if cfg.Service.SecretKey == "" {
+		if !localRun {
+			log.Fatalf("service.secretKey must be set, it signs verification, password reset and mfa tokens")
+		}
+		secret, err := randomKey()
+		if err != nil {
+			log.Fatalf("failed to generate secret key: %v", err)
+		}
+		cfg.Service.SecretKey = base64.StdEncoding.EncodeToString(secret)
+		log.Printf("service.secretKey is not set, using a random key for this run")
+	}
+
+	if cfg.SpotifyConfig.UseFakeServer {
+		fakeSpotify := fakespotify.NewServer(
+			fakespotify.WithTracks(fakespotify.DefaultCatalog()...),
+			fakespotify.WithClientCredentials(cfg.SpotifyConfig.ClientID, cfg.SpotifyConfig.ClientSecret),
+		)
+		defer fakeSpotify.Close()
+
+		cfg.SpotifyConfig.APIBaseURL = fakeSpotify.APIBaseURL()
+		cfg.SpotifyConfig.AccountsBaseURL = fakeSpotify.AccountsBaseURL()
+		log.Printf("serving spotify from fake server at %s", fakeSpotify.URL)
+	}
 
 	db, err := internalsql.Connect(cfg.Database.DataSourceName)
 	if err != nil {
 		log.Fatalf("failed to connect to database, err: %+v", err)
 	}
-	db.AutoMigrate(&memberships.User{})
-	db.AutoMigrate(&trackactivities.TrackActivity{})
+	err = db.AutoMigrate(
+		&memberships.User{},
+		&memberships.Session{},
+		&memberships.PasswordReset{},
+		&memberships.UserTOTP{},
+		&memberships.RecoveryCode{},
+		&memberships.OAuthState{},
+		&memberships.SpotifyAccount{},
+	)
+	if err != nil {
+		log.Fatalf("failed to migrate database: %v", err)
+	}
+	trackAvtivitiesRepo := trackactivitiesRepo.NewRepository(db)
+	playlistRepo := playlistsRepo.NewRepository(db)
+	if *deleteOrphans {
+		if err := trackAvtivitiesRepo.DeleteOrphans(); err != nil {
+			log.Fatalf("failed to delete orphaned track activities: %v", err)
+		}
+		if err := playlistRepo.DeleteOrphans(); err != nil {
+			log.Fatalf("failed to delete orphaned playlists: %v", err)
+		}
+		return
+	}
+	if err := trackAvtivitiesRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate track activities: %v, rows of deleted users are removed with -delete-orphans", err)
+	}
+	if err := playlistRepo.Migrate(); err != nil {
+		log.Fatalf("failed to migrate playlists: %v, rows of deleted users and playlists are removed with -delete-orphans", err)
+	}
+
+	revocationStore := newRevocationStore(cfg.Service.RevocationBackend, db)
+	middleware.SetRevocationStore(revocationStore)
+
+	keySet, err := newKeySet(cfg.Service)
+	if err != nil {
+		log.Fatalf("failed to load signing keys: %v", err)
+	}
+	middleware.SetKeySet(keySet)
+
+	keyring, err := newKeyring(cfg.Service.EncryptionKeys, localRun)
+	if err != nil {
+		log.Fatalf("failed to load encryption keys: %v", err)
+	}
 
 	r := gin.Default()
+	if err := r.SetTrustedProxies(cfg.Service.TrustedProxies); err != nil {
+		log.Fatalf("failed to set trusted proxies: %v", err)
+	}
 
 	httpClient := httpclient.NewClient(&http.Client{})
 
-	spotifyOutbound := spotify.NewSpotifyOutbound(cfg, httpClient)
+	spotifyOutbound := spotify.NewCachedOutbound(
+		spotify.NewSpotifyOutbound(cfg, httpClient),
+		newCacheBackend(cfg.Cache),
+		cfg.Cache.TTL,
+	)
 
 	membershipRepo := membershipsRepo.NewRepository(db)
-	trackAvtivitiesRepo := trackactivitiesRepo.NewRepository(db)
 
-	membershipSvc := membershipsSvc.NewService(cfg, membershipRepo)
-	tracksSvc := tracks.NewService(spotifyOutbound, trackAvtivitiesRepo)
+	loginGuard := loginguard.New(newLoginGuardStore(cfg.Service.LoginGuard.Backend, db))
+
+	membershipSvc := membershipsSvc.NewService(cfg, membershipRepo, revocationStore, keySet, newMailer(cfg.Mail), loginGuard, spotifyOutbound, keyring)
+	tracksSvc := tracks.NewService(spotifyOutbound, trackAvtivitiesRepo, membershipSvc)
+	go tracksSvc.RunLibraryImports(context.Background(), 30*time.Second)
+	go tracksSvc.RunLikeSync(context.Background(), 30*time.Second)
+	playlistSvc := playlistsSvc.NewService(playlistRepo, tracksSvc, membershipRepo, spotifyOutbound)
 
 	membershipHandler := membershipsHandler.NewHandler(r, membershipSvc)
 	membershipHandler.RegisterRoute()
@@ -62,5 +161,153 @@ func main() {
 	tracksHandler := tracksHandler.NewHandler(r, tracksSvc)
 	tracksHandler.RegisterRoute()
 
+	playlistHandler := playlistsHandler.NewHandler(r, playlistSvc)
+	playlistHandler.RegisterRoute()
+
 	r.Run(cfg.Service.Port)
 }
+
+func newCacheBackend(cfg configs.CacheConfig) cache.Backend {
+	switch cfg.Backend {
+	case "memory":
+		return cache.NewMemory(cfg.MaxEntries)
+	case "redis":
+		return cache.NewRedis(cfg.Redis.Addr,
+			cache.WithRedisAuth(cfg.Redis.Password),
+			cache.WithRedisDB(cfg.Redis.DB),
+		)
+	case "":
+		return nil
+	default:
+		log.Fatalf("unknown cache backend %q", cfg.Backend)
+		return nil
+	}
+}
+
+func newMailer(cfg configs.MailConfig) mail.Mailer {
+	switch cfg.Backend {
+	case "smtp":
+		return mail.NewSMTP(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.From)
+	case "log", "":
+		return mail.NewLog()
+	default:
+		log.Fatalf("unknown mail backend %q", cfg.Backend)
+		return nil
+	}
+}
+
+func newRevocationStore(backend string, db *gorm.DB) revocation.Store {
+	switch backend {
+	case "memory":
+		return revocation.NewMemory()
+	case "postgres", "":
+		store := revocation.NewPostgres(db)
+		if err := store.Migrate(); err != nil {
+			log.Fatalf("failed to migrate revocation store: %v", err)
+		}
+		return store
+	default:
+		log.Fatalf("unknown revocation backend %q", backend)
+		return nil
+	}
+}
+
+func newLoginGuardStore(backend string, db *gorm.DB) loginguard.Store {
+	switch backend {
+	case "memory":
+		return loginguard.NewMemory()
+	case "postgres", "":
+		store := loginguard.NewPostgres(db)
+		if err := store.Migrate(); err != nil {
+			log.Fatalf("failed to migrate login guard store: %v", err)
+		}
+		return store
+	default:
+		log.Fatalf("unknown login guard backend %q", backend)
+		return nil
+	}
+}
+
+// newKeyring loads the configured encryption keys. Without a valid one it
+// fails, unless ephemeral keys are allowed: then invalid keys are skipped
+// and a random key is used, which loses every sealed value on restart.
+func newKeyring(cfg []configs.EncryptionKey, allowEphemeral bool) (*encryption.Keyring, error) {
+	keys := make([]encryption.Key, 0, len(cfg))
+	for _, keyCfg := range cfg {
+		key, err := encryption.ParseKey(keyCfg.ID, keyCfg.Key)
+		if err != nil {
+			if !allowEphemeral {
+				return nil, err
+			}
+			log.Printf("ignoring encryption key %q: %v", keyCfg.ID, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		return encryption.NewKeyring(keys...)
+	}
+
+	if !allowEphemeral {
+		return nil, errors.New("no encryption keys configured, set service.encryptionKeys or allowEphemeralKeys for a local run")
+	}
+	log.Printf("no encryption keys configured, using a random key for this run")
+	secret, err := randomKey()
+	if err != nil {
+		return nil, err
+	}
+	return encryption.NewKeyring(encryption.Key{ID: "ephemeral", Secret: secret})
+}
+
+func randomKey() ([]byte, error) {
+	secret := make([]byte, encryption.KeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+func newKeySet(cfg configs.Service) (*jwt.KeySet, error) {
+	if len(cfg.SigningKeys) == 0 {
+		return jwt.NewHMACKeySet(cfg.SecretKey, jwt.WithIssuer(cfg.TokenIssuer), jwt.WithAudience(cfg.TokenAudience)), nil
+	}
+
+	keys := make([]jwt.Key, 0, len(cfg.SigningKeys))
+	for _, keyCfg := range cfg.SigningKeys {
+		privateKeyPEM, err := readOptionalFile(keyCfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		publicKeyPEM, err := readOptionalFile(keyCfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := jwt.ParseKey(keyCfg.ID, keyCfg.Algorithm, privateKeyPEM, publicKeyPEM)
+		if err != nil {
+			return nil, err
+		}
+		if key.NotBefore, err = parseOptionalTime(keyCfg.NotBefore); err != nil {
+			return nil, fmt.Errorf("key %q: notBefore: %w", keyCfg.ID, err)
+		}
+		if key.NotAfter, err = parseOptionalTime(keyCfg.NotAfter); err != nil {
+			return nil, fmt.Errorf("key %q: notAfter: %w", keyCfg.ID, err)
+		}
+		keys = append(keys, key)
+	}
+	return jwt.NewKeySet(keys, jwt.WithIssuer(cfg.TokenIssuer), jwt.WithAudience(cfg.TokenAudience))
+}
+
+func readOptionalFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return os.ReadFile(path)
+}
+
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -1,15 +1,94 @@
 package configs
 
+import "time"
+
 type (
 	Config struct {
 		Service       Service
 		Database      DatabaseConfig
 		SpotifyConfig SpotifyConfig
+		Cache         CacheConfig
+		Mail          MailConfig
 	}
 
 	Service struct {
-		Port      string
+		Port string
+		// SecretKey signs email verification, password reset and mfa
+		// tokens, and access tokens when there are no SigningKeys. The
+		// service doesn't start without it, except for local runs.
 		SecretKey string
+		// RefreshTokenTTL is how long a refresh token stays usable.
+		RefreshTokenTTL time.Duration
+		// RevocationBackend is "postgres" or "memory", it stores revoked
+		// access tokens and per-user token versions.
+		RevocationBackend string
+		// SigningKeys sign and verify access tokens, SecretKey is used with
+		// HS256 when there are none.
+		SigningKeys []SigningKey
+		// TokenIssuer and TokenAudience are the iss and aud claims of access
+		// tokens, checked on every request when set.
+		TokenIssuer   string
+		TokenAudience string
+		// UnverifiedLogin is "block" to refuse login until the email is
+		// verified, or "limit" to let the user in with read only access.
+		UnverifiedLogin string
+		// VerificationURL is where the emailed link points, the token is
+		// appended as the token query parameter.
+		VerificationURL      string
+		VerificationTokenTTL time.Duration
+		// PasswordResetURL is the frontend page the emailed reset link opens,
+		// the token is appended as the token query parameter.
+		PasswordResetURL string
+		PasswordResetTTL time.Duration
+		LoginGuard       LoginGuardConfig
+		// EncryptionKeys seal secrets stored in the database, such as spotify
+		// refresh tokens. The first key seals, every key opens, so a new key
+		// is added in front and old ones are dropped once nothing uses them.
+		EncryptionKeys []EncryptionKey
+		// AllowEphemeralKeys starts the service without EncryptionKeys or
+		// SecretKey, using random keys that are lost on restart. It is meant
+		// for local runs only, as is serving spotify from the fake server.
+		AllowEphemeralKeys bool
+		// TrustedProxies are the addresses or CIDRs of the proxies in front
+		// of the service. Only they may set the client address through
+		// X-Forwarded-For, with none every request is keyed on its peer.
+		TrustedProxies []string
+	}
+
+	EncryptionKey struct {
+		ID string
+		// Key is 32 random bytes in base64, e.g. from openssl rand -base64 32.
+		Key string
+	}
+
+	LoginGuardConfig struct {
+		// Backend is "postgres" or "memory", it stores failed login attempts.
+		Backend string
+		// MaxFailures locks an account out after this many failed logins in
+		// a row, MaxFailuresPerIP does the same for a client address.
+		MaxFailures      int
+		MaxFailuresPerIP int
+		// Lockout is how long a lockout lasts, failures are forgotten after
+		// the same time without a new one.
+		Lockout time.Duration
+		// BaseDelay is the wait after the second failed login in a row,
+		// doubling with each further failure up to MaxDelay.
+		BaseDelay time.Duration
+		MaxDelay  time.Duration
+	}
+
+	SigningKey struct {
+		// ID is the kid header of the tokens the key signs.
+		ID string
+		// Algorithm is "RS256" or "EdDSA".
+		Algorithm string
+		// PrivateKeyFile is a PEM file, keys without one only verify.
+		PrivateKeyFile string
+		PublicKeyFile  string
+		// NotBefore and NotAfter are RFC 3339 times bounding when the key
+		// signs and verifies, see jwt.Key.
+		NotBefore string
+		NotAfter  string
 	}
 
 	DatabaseConfig struct {
@@ -17,7 +96,53 @@ type (
 	}
 
 	SpotifyConfig struct {
-		ClientID     string
-		ClientSecret string
+		ClientID        string
+		ClientSecret    string
+		APIBaseURL      string
+		AccountsBaseURL string
+		UseFakeServer   bool
+		// RedirectURL is the callback of the log in with spotify flow, it
+		// must be registered with the spotify app.
+		RedirectURL string
+		// Scopes are asked for when users connect their spotify account.
+		Scopes []string
+		Retry  RetryConfig
+		// TokenRefreshSkew refreshes the access token this long before it expires.
+		TokenRefreshSkew time.Duration
+	}
+
+	RetryConfig struct {
+		MaxRetries int
+		BaseDelay  time.Duration
+		MaxDelay   time.Duration
+		Budget     time.Duration
+	}
+
+	CacheConfig struct {
+		// Backend is "memory", "redis" or empty to disable caching.
+		Backend    string
+		TTL        time.Duration
+		MaxEntries int
+		Redis      RedisConfig
+	}
+
+	RedisConfig struct {
+		Addr     string
+		Password string
+		DB       int
+	}
+
+	MailConfig struct {
+		// Backend is "smtp", or "log" to print mails for local development.
+		Backend string
+		From    string
+		SMTP    SMTPConfig
+	}
+
+	SMTPConfig struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
 	}
 )
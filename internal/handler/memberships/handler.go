@@ -2,13 +2,32 @@ package memberships
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/middleware"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
 )
 
 //go:generate mockgen -source=handler.go -destination=handler_mock_test.go -package=memberships
 type service interface {
 	SignUp(request memberships.SignUpRequest) error
-	Login(request memberships.LoginRequest) (string, error)
+	Login(request memberships.LoginRequest) (*memberships.LoginResponse, error)
+	Refresh(request memberships.RefreshRequest) (*memberships.LoginResponse, error)
+	Logout(request memberships.LogoutRequest) error
+	LogoutAll(userID uint) error
+	JWKS() jwt.JWKS
+	VerifyEmail(request memberships.VerifyEmailRequest) error
+	ResendVerification(request memberships.ResendVerificationRequest) error
+	ForgotPassword(request memberships.ForgotPasswordRequest) error
+	ResetPassword(request memberships.ResetPasswordRequest) error
+	GetProfile(userID uint) (*memberships.ProfileResponse, error)
+	UpdateProfile(userID uint, request memberships.UpdateProfileRequest) (*memberships.ProfileResponse, error)
+	ChangePassword(userID uint, request memberships.ChangePasswordRequest) error
+	DeleteAccount(userID uint) error
+	EnrollTOTP(userID uint) (*memberships.EnrollTOTPResponse, error)
+	ConfirmTOTP(userID uint, request memberships.ConfirmTOTPRequest) error
+	LoginMFA(request memberships.LoginMFARequest) (*memberships.LoginResponse, error)
+	StartSpotifyOAuth(userID uint) (*memberships.StartSpotifyOAuthResponse, error)
+	SpotifyCallback(request memberships.SpotifyCallbackRequest) (*memberships.LoginResponse, error)
 }
 
 type Handler struct {
@@ -24,7 +43,28 @@ func NewHandler(api *gin.Engine, service service) *Handler {
 }
 
 func (h *Handler) RegisterRoute() {
+	h.GET("/.well-known/jwks.json", h.JWKS)
+
 	route := h.Group("/memberships")
 	route.POST("/sign_up", h.SignUp)
 	route.POST("/login", h.Login)
+	route.POST("/login/mfa", h.LoginMFA)
+	route.GET("/verify-email", h.VerifyEmail)
+	route.POST("/verify-email", h.VerifyEmail)
+	route.POST("/verify-email/resend", h.ResendVerification)
+	route.POST("/password/forgot", h.ForgotPassword)
+	route.POST("/password/reset", h.ResetPassword)
+	route.POST("/refresh", h.Refresh)
+	route.POST("/logout", middleware.OptionalAuthMiddleware(), h.Logout)
+	route.POST("/logout-all", middleware.AuthMiddleware(), h.LogoutAll)
+	route.GET("/oauth/spotify/start", middleware.OptionalAuthMiddleware(), h.StartSpotifyOAuth)
+	route.GET("/oauth/spotify/callback", h.SpotifyCallback)
+
+	me := route.Group("/me", middleware.AuthMiddleware())
+	me.GET("", h.GetProfile)
+	me.PATCH("", h.UpdateProfile)
+	me.DELETE("", h.DeleteAccount)
+	me.POST("/password", h.ChangePassword)
+	me.POST("/mfa/totp", h.EnrollTOTP)
+	me.POST("/mfa/totp/confirm", h.ConfirmTOTP)
 }
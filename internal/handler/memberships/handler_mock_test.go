@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	memberships "github.com/xprasetio/go-spotify/internal/models/memberships"
+	jwt "github.com/xprasetio/go-spotify/pkg/jwt"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,11 +40,111 @@ func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *Mockservice) ChangePassword(userID uint, request memberships.ChangePasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", userID, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockserviceMockRecorder) ChangePassword(userID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*Mockservice)(nil).ChangePassword), userID, request)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *Mockservice) ConfirmTOTP(userID uint, request memberships.ConfirmTOTPRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", userID, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockserviceMockRecorder) ConfirmTOTP(userID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*Mockservice)(nil).ConfirmTOTP), userID, request)
+}
+
+// DeleteAccount mocks base method.
+func (m *Mockservice) DeleteAccount(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockserviceMockRecorder) DeleteAccount(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*Mockservice)(nil).DeleteAccount), userID)
+}
+
+// EnrollTOTP mocks base method.
+func (m *Mockservice) EnrollTOTP(userID uint) (*memberships.EnrollTOTPResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", userID)
+	ret0, _ := ret[0].(*memberships.EnrollTOTPResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockserviceMockRecorder) EnrollTOTP(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*Mockservice)(nil).EnrollTOTP), userID)
+}
+
+// ForgotPassword mocks base method.
+func (m *Mockservice) ForgotPassword(request memberships.ForgotPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockserviceMockRecorder) ForgotPassword(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*Mockservice)(nil).ForgotPassword), request)
+}
+
+// GetProfile mocks base method.
+func (m *Mockservice) GetProfile(userID uint) (*memberships.ProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", userID)
+	ret0, _ := ret[0].(*memberships.ProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockserviceMockRecorder) GetProfile(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*Mockservice)(nil).GetProfile), userID)
+}
+
+// JWKS mocks base method.
+func (m *Mockservice) JWKS() jwt.JWKS {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWKS")
+	ret0, _ := ret[0].(jwt.JWKS)
+	return ret0
+}
+
+// JWKS indicates an expected call of JWKS.
+func (mr *MockserviceMockRecorder) JWKS() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWKS", reflect.TypeOf((*Mockservice)(nil).JWKS))
+}
+
 // Login mocks base method.
-func (m *Mockservice) Login(request memberships.LoginRequest) (string, error) {
+func (m *Mockservice) Login(request memberships.LoginRequest) (*memberships.LoginResponse, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Login", request)
-	ret0, _ := ret[0].(string)
+	ret0, _ := ret[0].(*memberships.LoginResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -54,6 +155,92 @@ func (mr *MockserviceMockRecorder) Login(request any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*Mockservice)(nil).Login), request)
 }
 
+// LoginMFA mocks base method.
+func (m *Mockservice) LoginMFA(request memberships.LoginMFARequest) (*memberships.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginMFA", request)
+	ret0, _ := ret[0].(*memberships.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginMFA indicates an expected call of LoginMFA.
+func (mr *MockserviceMockRecorder) LoginMFA(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginMFA", reflect.TypeOf((*Mockservice)(nil).LoginMFA), request)
+}
+
+// Logout mocks base method.
+func (m *Mockservice) Logout(request memberships.LogoutRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockserviceMockRecorder) Logout(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*Mockservice)(nil).Logout), request)
+}
+
+// LogoutAll mocks base method.
+func (m *Mockservice) LogoutAll(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutAll", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogoutAll indicates an expected call of LogoutAll.
+func (mr *MockserviceMockRecorder) LogoutAll(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutAll", reflect.TypeOf((*Mockservice)(nil).LogoutAll), userID)
+}
+
+// Refresh mocks base method.
+func (m *Mockservice) Refresh(request memberships.RefreshRequest) (*memberships.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", request)
+	ret0, _ := ret[0].(*memberships.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockserviceMockRecorder) Refresh(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*Mockservice)(nil).Refresh), request)
+}
+
+// ResendVerification mocks base method.
+func (m *Mockservice) ResendVerification(request memberships.ResendVerificationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockserviceMockRecorder) ResendVerification(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*Mockservice)(nil).ResendVerification), request)
+}
+
+// ResetPassword mocks base method.
+func (m *Mockservice) ResetPassword(request memberships.ResetPasswordRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockserviceMockRecorder) ResetPassword(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*Mockservice)(nil).ResetPassword), request)
+}
+
 // SignUp mocks base method.
 func (m *Mockservice) SignUp(request memberships.SignUpRequest) error {
 	m.ctrl.T.Helper()
@@ -67,3 +254,62 @@ func (mr *MockserviceMockRecorder) SignUp(request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignUp", reflect.TypeOf((*Mockservice)(nil).SignUp), request)
 }
+
+// SpotifyCallback mocks base method.
+func (m *Mockservice) SpotifyCallback(request memberships.SpotifyCallbackRequest) (*memberships.LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpotifyCallback", request)
+	ret0, _ := ret[0].(*memberships.LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpotifyCallback indicates an expected call of SpotifyCallback.
+func (mr *MockserviceMockRecorder) SpotifyCallback(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpotifyCallback", reflect.TypeOf((*Mockservice)(nil).SpotifyCallback), request)
+}
+
+// StartSpotifyOAuth mocks base method.
+func (m *Mockservice) StartSpotifyOAuth(userID uint) (*memberships.StartSpotifyOAuthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartSpotifyOAuth", userID)
+	ret0, _ := ret[0].(*memberships.StartSpotifyOAuthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartSpotifyOAuth indicates an expected call of StartSpotifyOAuth.
+func (mr *MockserviceMockRecorder) StartSpotifyOAuth(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartSpotifyOAuth", reflect.TypeOf((*Mockservice)(nil).StartSpotifyOAuth), userID)
+}
+
+// UpdateProfile mocks base method.
+func (m *Mockservice) UpdateProfile(userID uint, request memberships.UpdateProfileRequest) (*memberships.ProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userID, request)
+	ret0, _ := ret[0].(*memberships.ProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockserviceMockRecorder) UpdateProfile(userID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*Mockservice)(nil).UpdateProfile), userID, request)
+}
+
+// VerifyEmail mocks base method.
+func (m *Mockservice) VerifyEmail(request memberships.VerifyEmailRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockserviceMockRecorder) VerifyEmail(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*Mockservice)(nil).VerifyEmail), request)
+}
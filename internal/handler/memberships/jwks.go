@@ -0,0 +1,14 @@
+package memberships
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS publishes the public keys access tokens are signed with.
+func (h *Handler) JWKS(c *gin.Context) {
+	// publish a new key at least this long before it signs
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.service.JWKS())
+}
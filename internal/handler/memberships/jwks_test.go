@@ -0,0 +1,49 @@
+package memberships
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_JWKS(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	jwks := jwt.JWKS{Keys: []jwt.JWK{{
+		KeyType:   "OKP",
+		KeyID:     "kid",
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         "x",
+	}}}
+	mockSvc.EXPECT().JWKS().Return(jwks)
+
+	api := gin.New()
+	h := &Handler{
+		Engine:  api,
+		service: mockSvc,
+	}
+	h.RegisterRoute()
+	w := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodGet, `/.well-known/jwks.json`, nil)
+	assert.NoError(t, err)
+	h.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+
+	response := jwt.JWKS{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, jwks, response)
+}
@@ -1,10 +1,15 @@
 package memberships
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
 )
 
 func (h *Handler) Login(c *gin.Context) {
@@ -13,13 +18,33 @@ func (h *Handler) Login(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req.ClientIP = c.ClientIP()
 
-	accessToken, err := h.service.Login(req)
+	response, err := h.service.Login(req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeLoginError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, memberships.LoginResponse{
-		AccessToken: accessToken,
-	})
+	c.JSON(http.StatusOK, response)
+}
+
+func writeLoginError(c *gin.Context, err error) {
+	var locked *loginguard.LockedError
+	switch {
+	case errors.As(err, &locked):
+		writeLockedError(c, locked)
+	case errors.Is(err, membershipsSvc.ErrEmailNotVerified):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, membershipsSvc.ErrInvalidCredentials),
+		errors.Is(err, membershipsSvc.ErrInvalidMFAToken),
+		errors.Is(err, membershipsSvc.ErrInvalidMFACode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to log in"})
+	}
+}
+
+func writeLockedError(c *gin.Context, locked *loginguard.LockedError) {
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(locked.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{"error": locked.Error()})
 }
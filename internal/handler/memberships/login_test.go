@@ -6,10 +6,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
 	"go.uber.org/mock/gomock"
 )
 
@@ -23,6 +26,7 @@ func TestHandler_Login(t *testing.T) {
 		name               string
 		mockFn             func()
 		expectedStatusCode int
+		expectedRetryAfter string
 		expectedBody       memberships.LoginResponse
 		wantErr            bool
 	}{
@@ -32,11 +36,16 @@ func TestHandler_Login(t *testing.T) {
 				mockSvc.EXPECT().Login(memberships.LoginRequest{
 					Email:    "test@gmail.com",
 					Password: "password",
-				}).Return("accessToken", nil)
+					ClientIP: "10.0.0.1",
+				}).Return(&memberships.LoginResponse{
+					AccessToken:  "accessToken",
+					RefreshToken: "refreshToken",
+				}, nil)
 			},
 			expectedStatusCode: 200,
 			expectedBody: memberships.LoginResponse{
-				AccessToken: "accessToken",
+				AccessToken:  "accessToken",
+				RefreshToken: "refreshToken",
 			},
 			wantErr: false,
 		},
@@ -46,12 +55,53 @@ func TestHandler_Login(t *testing.T) {
 				mockSvc.EXPECT().Login(memberships.LoginRequest{
 					Email:    "test@gmail.com",
 					Password: "password",
-				}).Return("", assert.AnError)
+					ClientIP: "10.0.0.1",
+				}).Return(nil, assert.AnError)
+			},
+			expectedStatusCode: 500,
+			expectedBody:       memberships.LoginResponse{},
+			wantErr:            true,
+		},
+		{
+			name: "failed: invalid credentials",
+			mockFn: func() {
+				mockSvc.EXPECT().Login(memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				}).Return(nil, membershipsSvc.ErrInvalidCredentials)
 			},
 			expectedStatusCode: 400,
 			expectedBody:       memberships.LoginResponse{},
 			wantErr:            true,
 		},
+		{
+			name: "failed: too many attempts",
+			mockFn: func() {
+				mockSvc.EXPECT().Login(memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				}).Return(nil, &loginguard.LockedError{RetryAfter: 1500 * time.Millisecond})
+			},
+			expectedStatusCode: 429,
+			expectedRetryAfter: "2",
+			expectedBody:       memberships.LoginResponse{},
+			wantErr:            true,
+		},
+		{
+			name: "failed: email not verified",
+			mockFn: func() {
+				mockSvc.EXPECT().Login(memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				}).Return(nil, membershipsSvc.ErrEmailNotVerified)
+			},
+			expectedStatusCode: 403,
+			expectedBody:       memberships.LoginResponse{},
+			wantErr:            true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -77,9 +127,11 @@ func TestHandler_Login(t *testing.T) {
 			body := bytes.NewReader(val)
 			req, err := http.NewRequest(http.MethodPost, endpoint, body)
 			assert.NoError(t, err)
+			req.RemoteAddr = "10.0.0.1:51234"
 			h.ServeHTTP(w, req)
 
 			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
 
 			if !tt.wantErr {
 				res := w.Result()
@@ -94,3 +146,60 @@ func TestHandler_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_Login_forwardedFor(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name             string
+		trustedProxies   []string
+		expectedClientIP string
+	}{
+		{
+			name:             "spoofed header from an untrusted peer",
+			trustedProxies:   nil,
+			expectedClientIP: "10.0.0.1",
+		},
+		{
+			name:             "header set by a trusted proxy",
+			trustedProxies:   []string{"10.0.0.0/8"},
+			expectedClientIP: "203.0.113.7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockSvc.EXPECT().Login(memberships.LoginRequest{
+				Email:    "test@gmail.com",
+				Password: "password",
+				ClientIP: tt.expectedClientIP,
+			}).Return(nil, membershipsSvc.ErrInvalidCredentials)
+
+			api := gin.New()
+			assert.NoError(t, api.SetTrustedProxies(tt.trustedProxies))
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			val, err := json.Marshal(memberships.LoginRequest{
+				Email:    "test@gmail.com",
+				Password: "password",
+			})
+			assert.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/login`, bytes.NewReader(val))
+			assert.NoError(t, err)
+			req.RemoteAddr = "10.0.0.1:51234"
+			req.Header.Set("X-Forwarded-For", "203.0.113.7")
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, 400, w.Code)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package memberships
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+)
+
+func (h *Handler) EnrollTOTP(c *gin.Context) {
+	userID := c.GetUint("userID")
+	response, err := h.service.EnrollTOTP(userID)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) ConfirmTOTP(c *gin.Context) {
+	var req memberships.ConfirmTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	err := h.service.ConfirmTOTP(userID, req)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) LoginMFA(c *gin.Context) {
+	var req memberships.LoginMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ClientIP = c.ClientIP()
+
+	response, err := h.service.LoginMFA(req)
+	if err != nil {
+		writeLoginError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, membershipsSvc.ErrUserNotFound), errors.Is(err, membershipsSvc.ErrTOTPNotEnrolled):
+		return http.StatusNotFound
+	case errors.Is(err, membershipsSvc.ErrTOTPAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, membershipsSvc.ErrInvalidMFACode):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,215 @@
+package memberships
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_EnrollTOTP(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		withToken          bool
+		mockFn             func()
+		wantResponse       *memberships.EnrollTOTPResponse
+		expectedStatusCode int
+	}{
+		{
+			name:      "success",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().EnrollTOTP(uint(1)).Return(&memberships.EnrollTOTPResponse{
+					Secret:        "secret",
+					OTPAuthURI:    "otpauth://totp/go-spotify:test@gmail.com?secret=secret",
+					RecoveryCodes: []string{"abcde-fghij"},
+				}, nil)
+			},
+			wantResponse: &memberships.EnrollTOTPResponse{
+				Secret:        "secret",
+				OTPAuthURI:    "otpauth://totp/go-spotify:test@gmail.com?secret=secret",
+				RecoveryCodes: []string{"abcde-fghij"},
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "failed: missing token",
+			mockFn:             func() {},
+			expectedStatusCode: 401,
+		},
+		{
+			name:      "failed: already enabled",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().EnrollTOTP(uint(1)).Return(nil, membershipsSvc.ErrTOTPAlreadyEnabled)
+			},
+			expectedStatusCode: 409,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodPost, `/memberships/me/mfa/totp`, "", tt.withToken))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.wantResponse != nil {
+				var got memberships.EnrollTOTPResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, *tt.wantResponse, got)
+			}
+		})
+	}
+}
+
+func TestHandler_ConfirmTOTP(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"code":"123456"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ConfirmTOTP(uint(1), memberships.ConfirmTOTPRequest{Code: "123456"}).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: missing code",
+			body:               `{}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: wrong code",
+			body: `{"code":"123456"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ConfirmTOTP(uint(1), gomock.Any()).Return(membershipsSvc.ErrInvalidMFACode)
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: not enrolled",
+			body: `{"code":"123456"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ConfirmTOTP(uint(1), gomock.Any()).Return(membershipsSvc.ErrTOTPNotEnrolled)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodPost, `/memberships/me/mfa/totp/confirm`, tt.body, true))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_LoginMFA(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	request := memberships.LoginMFARequest{MFAToken: "mfaToken", Code: "123456", ClientIP: "10.0.0.1"}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"mfaToken":"mfaToken","code":"123456"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().LoginMFA(request).Return(&memberships.LoginResponse{
+					AccessToken:  "accessToken",
+					RefreshToken: "refreshToken",
+				}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "failed: missing code",
+			body:               `{"mfaToken":"mfaToken"}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: wrong code",
+			body: `{"mfaToken":"mfaToken","code":"123456"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().LoginMFA(request).Return(nil, membershipsSvc.ErrInvalidMFACode)
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: too many attempts",
+			body: `{"mfaToken":"mfaToken","code":"123456"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().LoginMFA(request).Return(nil, &loginguard.LockedError{RetryAfter: time.Minute})
+			},
+			expectedStatusCode: 429,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req := newRequest(t, http.MethodPost, `/memberships/login/mfa`, tt.body, false)
+			req.RemoteAddr = "10.0.0.1:51234"
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package memberships
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+)
+
+const (
+	spotifyStateCookie     = "spotify_oauth_state"
+	spotifyStateCookiePath = "/memberships/oauth/spotify"
+	spotifyStateCookieAge  = 10 * 60
+)
+
+// StartSpotifyOAuth returns the spotify authorize url to send the browser
+// to. Signed in users link their account, everyone else logs in with it.
+func (h *Handler) StartSpotifyOAuth(c *gin.Context) {
+	userID := c.GetUint("userID")
+	response, err := h.service.StartSpotifyOAuth(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// the state only counts when it comes back to the browser that asked
+	// for it, so nobody can log a victim into the attacker's account
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(spotifyStateCookie, response.State, spotifyStateCookieAge, spotifyStateCookiePath, "", c.Request.TLS != nil, true)
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) SpotifyCallback(c *gin.Context) {
+	var req memberships.SpotifyCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.CookieState, _ = c.Cookie(spotifyStateCookie)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(spotifyStateCookie, "", -1, spotifyStateCookiePath, "", c.Request.TLS != nil, true)
+
+	response, err := h.service.SpotifyCallback(req)
+	if err != nil {
+		c.JSON(oauthErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if response == nil {
+		c.Status(http.StatusNoContent)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func oauthErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, membershipsSvc.ErrInvalidOAuthState), errors.Is(err, membershipsSvc.ErrSpotifyEmailMissing):
+		return http.StatusBadRequest
+	case errors.Is(err, membershipsSvc.ErrSpotifyAuthDenied), errors.Is(err, membershipsSvc.ErrEmailNotVerified):
+		return http.StatusForbidden
+	case errors.Is(err, membershipsSvc.ErrSpotifyAccountLinked), errors.Is(err, membershipsSvc.ErrSpotifyEmailTaken):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,205 @@
+package memberships
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_StartSpotifyOAuth(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		withToken          bool
+		mockFn             func()
+		expectedStatusCode int
+		wantCookie         bool
+	}{
+		{
+			name: "success: log in",
+			mockFn: func() {
+				mockSvc.EXPECT().StartSpotifyOAuth(uint(0)).Return(&memberships.StartSpotifyOAuthResponse{
+					AuthorizeURL: "http://spotify/authorize",
+					State:        "state",
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			wantCookie:         true,
+		},
+		{
+			name:      "success: link",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().StartSpotifyOAuth(uint(1)).Return(&memberships.StartSpotifyOAuthResponse{
+					AuthorizeURL: "http://spotify/authorize",
+					State:        "state",
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			wantCookie:         true,
+		},
+		{
+			name: "failed",
+			mockFn: func() {
+				mockSvc.EXPECT().StartSpotifyOAuth(uint(0)).Return(nil, assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodGet, `/memberships/oauth/spotify/start`, "", tt.withToken))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if !tt.wantCookie {
+				assert.Empty(t, w.Result().Cookies())
+				return
+			}
+			var got map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+			assert.Equal(t, map[string]string{"authorizeURL": "http://spotify/authorize"}, got)
+
+			cookies := w.Result().Cookies()
+			assert.Len(t, cookies, 1)
+			assert.Equal(t, "spotify_oauth_state", cookies[0].Name)
+			assert.Equal(t, "state", cookies[0].Value)
+			assert.True(t, cookies[0].HttpOnly)
+			assert.Equal(t, http.SameSiteLaxMode, cookies[0].SameSite)
+		})
+	}
+}
+
+func TestHandler_SpotifyCallback(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		query              string
+		cookie             string
+		mockFn             func()
+		wantResponse       *memberships.LoginResponse
+		expectedStatusCode int
+	}{
+		{
+			name:   "success: log in",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			mockFn: func() {
+				mockSvc.EXPECT().SpotifyCallback(memberships.SpotifyCallbackRequest{
+					Code:        "code",
+					State:       "state",
+					CookieState: "state",
+				}).Return(&memberships.LoginResponse{AccessToken: "accessToken", RefreshToken: "refreshToken"}, nil)
+			},
+			wantResponse:       &memberships.LoginResponse{AccessToken: "accessToken", RefreshToken: "refreshToken"},
+			expectedStatusCode: 200,
+		},
+		{
+			name:   "success: link",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			mockFn: func() {
+				mockSvc.EXPECT().SpotifyCallback(gomock.Any()).Return(nil, nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: missing state",
+			query:              "?code=code",
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name:  "failed: invalid state",
+			query: "?code=code&state=state",
+			mockFn: func() {
+				mockSvc.EXPECT().SpotifyCallback(memberships.SpotifyCallbackRequest{
+					Code:  "code",
+					State: "state",
+				}).Return(nil, membershipsSvc.ErrInvalidOAuthState)
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name:   "failed: denied",
+			query:  "?error=access_denied&state=state",
+			cookie: "state",
+			mockFn: func() {
+				mockSvc.EXPECT().SpotifyCallback(memberships.SpotifyCallbackRequest{
+					Error:       "access_denied",
+					State:       "state",
+					CookieState: "state",
+				}).Return(nil, membershipsSvc.ErrSpotifyAuthDenied)
+			},
+			expectedStatusCode: 403,
+		},
+		{
+			name:   "failed: linked to another user",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			mockFn: func() {
+				mockSvc.EXPECT().SpotifyCallback(gomock.Any()).Return(nil, membershipsSvc.ErrSpotifyAccountLinked)
+			},
+			expectedStatusCode: 409,
+		},
+		{
+			name:   "failed",
+			query:  "?code=code&state=state",
+			cookie: "state",
+			mockFn: func() {
+				mockSvc.EXPECT().SpotifyCallback(gomock.Any()).Return(nil, assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req := newRequest(t, http.MethodGet, `/memberships/oauth/spotify/callback`+tt.query, "", false)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "spotify_oauth_state", Value: tt.cookie})
+			}
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.wantResponse != nil {
+				var got memberships.LoginResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, *tt.wantResponse, got)
+			}
+		})
+	}
+}
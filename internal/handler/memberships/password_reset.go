@@ -0,0 +1,42 @@
+package memberships
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+)
+
+// ForgotPassword always answers 202, the service logs its own failures, so
+// the response doesn't reveal whether the email has an account.
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	var req memberships.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	_ = h.service.ForgotPassword(req)
+	c.Status(http.StatusAccepted)
+}
+
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req memberships.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.service.ResetPassword(req)
+	if errors.Is(err, membershipsSvc.ErrInvalidResetToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,139 @@
+package memberships
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_ForgotPassword(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"email":"test@gmail.com"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ForgotPassword(memberships.ForgotPasswordRequest{Email: "test@gmail.com"}).Return(nil)
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name: "accepted even when the service fails",
+			body: `{"email":"test@gmail.com"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ForgotPassword(memberships.ForgotPasswordRequest{Email: "test@gmail.com"}).Return(assert.AnError)
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name:               "failed: invalid email",
+			body:               `{"email":"test"}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/password/forgot`, bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedStatusCode == 202 {
+				assert.Empty(t, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ResetPassword(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	request := memberships.ResetPasswordRequest{Token: "token", Password: "new password"}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"token":"token","password":"new password"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ResetPassword(request).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: password too short",
+			body:               `{"token":"token","password":"short"}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: invalid token",
+			body: `{"token":"token","password":"new password"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ResetPassword(request).Return(membershipsSvc.ErrInvalidResetToken)
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed",
+			body: `{"token":"token","password":"new password"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ResetPassword(request).Return(assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/password/reset`, bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
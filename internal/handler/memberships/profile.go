@@ -0,0 +1,83 @@
+package memberships
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
+)
+
+func (h *Handler) GetProfile(c *gin.Context) {
+	userID := c.GetUint("userID")
+	response, err := h.service.GetProfile(userID)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	var req memberships.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.UpdateProfile(userID, req)
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// ChangePassword signs the user out everywhere, including this session, so
+// the client has to log in again with the new password.
+func (h *Handler) ChangePassword(c *gin.Context) {
+	var req memberships.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	err := h.service.ChangePassword(userID, req)
+	var locked *loginguard.LockedError
+	if errors.As(err, &locked) {
+		writeLockedError(c, locked)
+		return
+	}
+	if err != nil {
+		c.JSON(profileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) DeleteAccount(c *gin.Context) {
+	userID := c.GetUint("userID")
+	err := h.service.DeleteAccount(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func profileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, membershipsSvc.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, membershipsSvc.ErrProfileTaken):
+		return http.StatusConflict
+	case errors.Is(err, membershipsSvc.ErrInvalidPassword):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
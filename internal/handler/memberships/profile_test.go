@@ -0,0 +1,278 @@
+package memberships
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
+	"go.uber.org/mock/gomock"
+)
+
+func newRequest(t *testing.T, method, path, body string, withToken bool) *http.Request {
+	req, err := http.NewRequest(method, path, bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	if withToken {
+		token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestHandler_GetProfile(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		withToken          bool
+		mockFn             func()
+		wantResponse       *memberships.ProfileResponse
+		expectedStatusCode int
+	}{
+		{
+			name:      "success",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetProfile(uint(1)).Return(&memberships.ProfileResponse{
+					ID:       1,
+					Email:    "test@gmail.com",
+					Username: "username",
+				}, nil)
+			},
+			wantResponse: &memberships.ProfileResponse{
+				ID:       1,
+				Email:    "test@gmail.com",
+				Username: "username",
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "failed: missing token",
+			mockFn:             func() {},
+			expectedStatusCode: 401,
+		},
+		{
+			name:      "failed: user not found",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetProfile(uint(1)).Return(nil, membershipsSvc.ErrUserNotFound)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodGet, `/memberships/me`, "", tt.withToken))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.wantResponse != nil {
+				var got memberships.ProfileResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				assert.Equal(t, *tt.wantResponse, got)
+			}
+		})
+	}
+}
+
+func TestHandler_UpdateProfile(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"username":"newname"}`,
+			mockFn: func() {
+				username := "newname"
+				mockSvc.EXPECT().UpdateProfile(uint(1), memberships.UpdateProfileRequest{Username: &username}).
+					Return(&memberships.ProfileResponse{ID: 1, Username: "newname"}, nil)
+			},
+			expectedStatusCode: 200,
+		},
+		{
+			name:               "failed: invalid email",
+			body:               `{"email":"test"}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: taken",
+			body: `{"email":"taken@gmail.com"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().UpdateProfile(uint(1), gomock.Any()).Return(nil, membershipsSvc.ErrProfileTaken)
+			},
+			expectedStatusCode: 409,
+		},
+		{
+			name: "failed",
+			body: `{"email":"new@gmail.com"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().UpdateProfile(uint(1), gomock.Any()).Return(nil, assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodPatch, `/memberships/me`, tt.body, true))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_ChangePassword(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	request := memberships.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "new password"}
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"currentPassword":"password","newPassword":"new password"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ChangePassword(uint(1), request).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: new password too short",
+			body:               `{"currentPassword":"password","newPassword":"short"}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: wrong current password",
+			body: `{"currentPassword":"password","newPassword":"new password"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ChangePassword(uint(1), request).Return(membershipsSvc.ErrInvalidPassword)
+			},
+			expectedStatusCode: 403,
+		},
+		{
+			name: "failed: too many wrong passwords",
+			body: `{"currentPassword":"password","newPassword":"new password"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ChangePassword(uint(1), request).Return(&loginguard.LockedError{RetryAfter: time.Minute})
+			},
+			expectedStatusCode: 429,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodPost, `/memberships/me/password`, tt.body, true))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_DeleteAccount(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		withToken          bool
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name:      "success",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().DeleteAccount(uint(1)).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: missing token",
+			mockFn:             func() {},
+			expectedStatusCode: 401,
+		},
+		{
+			name:      "failed",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().DeleteAccount(uint(1)).Return(assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, newRequest(t, http.MethodDelete, `/memberships/me`, "", tt.withToken))
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package memberships
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+)
+
+func (h *Handler) Refresh(c *gin.Context) {
+	var req memberships.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response, err := h.service.Refresh(req)
+	if err != nil {
+		c.JSON(sessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) Logout(c *gin.Context) {
+	var req memberships.LogoutRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.AccessTokenID = c.GetString("tokenID")
+	req.AccessTokenExpiresAt = c.GetTime("tokenExpiresAt")
+
+	err := h.service.Logout(req)
+	if err != nil {
+		c.JSON(sessionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) LogoutAll(c *gin.Context) {
+	userID := c.GetUint("userID")
+	err := h.service.LogoutAll(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func sessionErrorStatus(err error) int {
+	if errors.Is(err, membershipsSvc.ErrInvalidRefreshToken) || errors.Is(err, membershipsSvc.ErrRefreshTokenReused) {
+		return http.StatusUnauthorized
+	}
+	return http.StatusInternalServerError
+}
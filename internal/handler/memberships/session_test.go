@@ -0,0 +1,206 @@
+package memberships
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_Refresh(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+		expectedBody       memberships.LoginResponse
+		wantErr            bool
+	}{
+		{
+			name: "success",
+			body: `{"refreshToken":"refreshToken"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().Refresh(memberships.RefreshRequest{RefreshToken: "refreshToken"}).Return(&memberships.LoginResponse{
+					AccessToken:  "accessToken",
+					RefreshToken: "newRefreshToken",
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody: memberships.LoginResponse{
+				AccessToken:  "accessToken",
+				RefreshToken: "newRefreshToken",
+			},
+			wantErr: false,
+		},
+		{
+			name:               "failed: missing token",
+			body:               `{}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+			wantErr:            true,
+		},
+		{
+			name: "failed: reused token",
+			body: `{"refreshToken":"refreshToken"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().Refresh(memberships.RefreshRequest{RefreshToken: "refreshToken"}).Return(nil, membershipsSvc.ErrRefreshTokenReused)
+			},
+			expectedStatusCode: 401,
+			wantErr:            true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/refresh`, bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := memberships.LoginResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestHandler_Logout(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mockSvc.EXPECT().Logout(memberships.LogoutRequest{RefreshToken: "refreshToken"}).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name: "failed: unknown token",
+			mockFn: func() {
+				mockSvc.EXPECT().Logout(memberships.LogoutRequest{RefreshToken: "refreshToken"}).Return(membershipsSvc.ErrInvalidRefreshToken)
+			},
+			expectedStatusCode: 401,
+		},
+		{
+			name: "failed",
+			mockFn: func() {
+				mockSvc.EXPECT().Logout(memberships.LogoutRequest{RefreshToken: "refreshToken"}).Return(assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/logout`, bytes.NewBufferString(`{"refreshToken":"refreshToken"}`))
+			assert.NoError(t, err)
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_LogoutAll(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		withToken          bool
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name:      "success",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().LogoutAll(uint(1)).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: missing token",
+			mockFn:             func() {},
+			expectedStatusCode: 401,
+		},
+		{
+			name:      "failed",
+			withToken: true,
+			mockFn: func() {
+				mockSvc.EXPECT().LogoutAll(uint(1)).Return(assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/logout-all`, nil)
+			assert.NoError(t, err)
+			if tt.withToken {
+				token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+				assert.NoError(t, err)
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
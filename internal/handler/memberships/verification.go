@@ -0,0 +1,52 @@
+package memberships
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+)
+
+// VerifyEmail takes the token as JSON, or in the query when the emailed
+// link is opened.
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	var req memberships.VerifyEmailRequest
+	bind := c.ShouldBindJSON
+	if c.Request.Method == http.MethodGet {
+		bind = c.ShouldBindQuery
+	}
+	if err := bind(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.service.VerifyEmail(req)
+	if errors.Is(err, membershipsSvc.ErrInvalidVerificationToken) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerification answers 202 whether or not the email belongs to an
+// unverified account.
+func (h *Handler) ResendVerification(c *gin.Context) {
+	var req memberships.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err := h.service.ResendVerification(req)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
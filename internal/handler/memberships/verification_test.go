@@ -0,0 +1,148 @@
+package memberships
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	membershipsSvc "github.com/xprasetio/go-spotify/internal/service/memberships"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		method             string
+		endpoint           string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"token":"token"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().VerifyEmail(memberships.VerifyEmailRequest{Token: "token"}).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:     "success: emailed link",
+			method:   http.MethodGet,
+			endpoint: `/memberships/verify-email?token=token`,
+			mockFn: func() {
+				mockSvc.EXPECT().VerifyEmail(memberships.VerifyEmailRequest{Token: "token"}).Return(nil)
+			},
+			expectedStatusCode: 204,
+		},
+		{
+			name:               "failed: link without token",
+			method:             http.MethodGet,
+			endpoint:           `/memberships/verify-email`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name:               "failed: missing token",
+			body:               `{}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed: invalid token",
+			body: `{"token":"token"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().VerifyEmail(memberships.VerifyEmailRequest{Token: "token"}).Return(membershipsSvc.ErrInvalidVerificationToken)
+			},
+			expectedStatusCode: 400,
+		},
+		{
+			name: "failed",
+			body: `{"token":"token"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().VerifyEmail(memberships.VerifyEmailRequest{Token: "token"}).Return(assert.AnError)
+			},
+			expectedStatusCode: 500,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			method, endpoint := tt.method, tt.endpoint
+			if method == "" {
+				method, endpoint = http.MethodPost, `/memberships/verify-email`
+			}
+			req, err := http.NewRequest(method, endpoint, bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_ResendVerification(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockSvc := NewMockservice(ctrlMock)
+
+	tests := []struct {
+		name               string
+		body               string
+		mockFn             func()
+		expectedStatusCode int
+	}{
+		{
+			name: "success",
+			body: `{"email":"test@gmail.com"}`,
+			mockFn: func() {
+				mockSvc.EXPECT().ResendVerification(memberships.ResendVerificationRequest{Email: "test@gmail.com"}).Return(nil)
+			},
+			expectedStatusCode: 202,
+		},
+		{
+			name:               "failed: invalid email",
+			body:               `{"email":"test"}`,
+			mockFn:             func() {},
+			expectedStatusCode: 400,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/memberships/verify-email/resend`, bytes.NewBufferString(tt.body))
+			assert.NoError(t, err)
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package playlists
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/handler/spotifyerr"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
+	playlistsSvc "github.com/xprasetio/go-spotify/internal/service/playlists"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+)
+
+// writeError maps spotify failures with spotifyerr, invalid input to bad
+// request, missing playlists and users to not found, missing roles to
+// forbidden and edits on an older version to conflict, anything else is an
+// internal error.
+func writeError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	status, ok := spotifyerr.Status(c, err)
+	if !ok {
+		switch {
+		case errors.As(err, &tooLarge):
+			status = http.StatusRequestEntityTooLarge
+		case errors.Is(err, playlistsSvc.ErrEmptyName), errors.Is(err, playlistsSvc.ErrOwnerMember),
+			errors.Is(err, playlistsSvc.ErrTooManyMembers), errors.Is(err, playlistsSvc.ErrEmptyImport),
+			errors.Is(err, playlistsRepo.ErrInvalidPosition), errors.Is(err, playlistsRepo.ErrPlaylistFull),
+			errors.Is(err, playlistfile.ErrUnknownFormat), errors.Is(err, playlistfile.ErrInvalidFile):
+			status = http.StatusBadRequest
+		case errors.Is(err, playlistsSvc.ErrPlaylistNotFound), errors.Is(err, playlistsSvc.ErrUserNotFound),
+			errors.Is(err, playlistsSvc.ErrMemberNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, playlistsSvc.ErrForbidden):
+			status = http.StatusForbidden
+		case errors.Is(err, playlistsRepo.ErrVersionConflict):
+			status = http.StatusConflict
+		default:
+			status = http.StatusInternalServerError
+		}
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
+
+// playlistID reads the playlist id from the path, writing a bad request
+// when it isn't one.
+func playlistID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid playlist id"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,69 @@
+package playlists
+
+import (
+	"context"
+	"io"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/middleware"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+)
+
+//go:generate mockgen -source=handler.go -destination=handler_mock_test.go -package=playlists
+type service interface {
+	CreatePlaylist(ctx context.Context, userID uint, request playlists.PlaylistRequest) (*playlists.PlaylistResponse, error)
+	ListPlaylists(ctx context.Context, userID uint) (*playlists.ListResponse, error)
+	GetPlaylist(ctx context.Context, userID, playlistID uint) (*playlists.PlaylistDetailResponse, error)
+	RenamePlaylist(ctx context.Context, userID, playlistID uint, request playlists.PlaylistRequest) (*playlists.PlaylistResponse, error)
+	DeletePlaylist(ctx context.Context, userID, playlistID uint) error
+	AddTracks(ctx context.Context, userID, playlistID uint, request playlists.AddTracksRequest) (*playlists.PlaylistResponse, error)
+	RemoveTracks(ctx context.Context, userID, playlistID uint, request playlists.RemoveTracksRequest) (*playlists.PlaylistResponse, error)
+	ReorderTracks(ctx context.Context, userID, playlistID uint, request playlists.ReorderTracksRequest) (*playlists.PlaylistResponse, error)
+
+	ListMembers(ctx context.Context, userID, playlistID uint) (*playlists.MembersResponse, error)
+	InviteMember(ctx context.Context, userID, playlistID uint, request playlists.InviteMemberRequest) (*playlists.MemberResponse, error)
+	RemoveMember(ctx context.Context, userID, playlistID, memberUserID uint) error
+	ShareLink(ctx context.Context, userID, playlistID uint) (*playlists.ShareLinkResponse, error)
+	RevokeShareLink(ctx context.Context, userID, playlistID uint) error
+	GetSharedPlaylist(ctx context.Context, slug string) (*playlists.PlaylistDetailResponse, error)
+
+	ExportPlaylist(ctx context.Context, userID, playlistID uint) (*playlistfile.Playlist, error)
+	ImportPlaylist(ctx context.Context, userID uint, format string, r io.Reader) (*playlists.ImportResponse, error)
+}
+
+type Handler struct {
+	*gin.Engine
+	service service
+}
+
+func NewHandler(api *gin.Engine, service service) *Handler {
+	return &Handler{
+		api,
+		service,
+	}
+}
+
+func (h *Handler) RegisterRoute() {
+	route := h.Group("/playlists")
+	route.Use(middleware.AuthMiddleware())
+	// reading needs a login, every change also needs a verified email
+	route.GET("", h.ListPlaylists)
+	route.POST("", middleware.RequireVerifiedEmail(), h.CreatePlaylist)
+	route.POST("/import", middleware.RequireVerifiedEmail(), h.ImportPlaylist)
+	route.GET("/:id", h.GetPlaylist)
+	route.PATCH("/:id", middleware.RequireVerifiedEmail(), h.RenamePlaylist)
+	route.DELETE("/:id", middleware.RequireVerifiedEmail(), h.DeletePlaylist)
+	route.GET("/:id/export", h.ExportPlaylist)
+	route.POST("/:id/tracks", middleware.RequireVerifiedEmail(), h.AddTracks)
+	route.DELETE("/:id/tracks", middleware.RequireVerifiedEmail(), h.RemoveTracks)
+	route.POST("/:id/tracks/reorder", middleware.RequireVerifiedEmail(), h.ReorderTracks)
+	route.GET("/:id/members", h.ListMembers)
+	route.POST("/:id/members", middleware.RequireVerifiedEmail(), h.InviteMember)
+	route.DELETE("/:id/members/:userID", middleware.RequireVerifiedEmail(), h.RemoveMember)
+	route.POST("/:id/share", middleware.RequireVerifiedEmail(), h.ShareLink)
+	route.DELETE("/:id/share", middleware.RequireVerifiedEmail(), h.RevokeShareLink)
+
+	// share links are read-only and need no account
+	h.GET("/shared/playlists/:slug", h.GetSharedPlaylist)
+}
@@ -0,0 +1,280 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=handler.go -destination=handler_mock_test.go -package=playlists
+//
+
+// Package playlists is a generated GoMock package.
+package playlists
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	playlists "github.com/xprasetio/go-spotify/internal/models/playlists"
+	playlistfile "github.com/xprasetio/go-spotify/pkg/playlistfile"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockservice is a mock of service interface.
+type Mockservice struct {
+	ctrl     *gomock.Controller
+	recorder *MockserviceMockRecorder
+}
+
+// MockserviceMockRecorder is the mock recorder for Mockservice.
+type MockserviceMockRecorder struct {
+	mock *Mockservice
+}
+
+// NewMockservice creates a new mock instance.
+func NewMockservice(ctrl *gomock.Controller) *Mockservice {
+	mock := &Mockservice{ctrl: ctrl}
+	mock.recorder = &MockserviceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
+	return m.recorder
+}
+
+// AddTracks mocks base method.
+func (m *Mockservice) AddTracks(ctx context.Context, userID, playlistID uint, request playlists.AddTracksRequest) (*playlists.PlaylistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTracks", ctx, userID, playlistID, request)
+	ret0, _ := ret[0].(*playlists.PlaylistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTracks indicates an expected call of AddTracks.
+func (mr *MockserviceMockRecorder) AddTracks(ctx, userID, playlistID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTracks", reflect.TypeOf((*Mockservice)(nil).AddTracks), ctx, userID, playlistID, request)
+}
+
+// CreatePlaylist mocks base method.
+func (m *Mockservice) CreatePlaylist(ctx context.Context, userID uint, request playlists.PlaylistRequest) (*playlists.PlaylistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, userID, request)
+	ret0, _ := ret[0].(*playlists.PlaylistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockserviceMockRecorder) CreatePlaylist(ctx, userID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*Mockservice)(nil).CreatePlaylist), ctx, userID, request)
+}
+
+// DeletePlaylist mocks base method.
+func (m *Mockservice) DeletePlaylist(ctx context.Context, userID, playlistID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, userID, playlistID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockserviceMockRecorder) DeletePlaylist(ctx, userID, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*Mockservice)(nil).DeletePlaylist), ctx, userID, playlistID)
+}
+
+// ExportPlaylist mocks base method.
+func (m *Mockservice) ExportPlaylist(ctx context.Context, userID, playlistID uint) (*playlistfile.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportPlaylist", ctx, userID, playlistID)
+	ret0, _ := ret[0].(*playlistfile.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportPlaylist indicates an expected call of ExportPlaylist.
+func (mr *MockserviceMockRecorder) ExportPlaylist(ctx, userID, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportPlaylist", reflect.TypeOf((*Mockservice)(nil).ExportPlaylist), ctx, userID, playlistID)
+}
+
+// GetPlaylist mocks base method.
+func (m *Mockservice) GetPlaylist(ctx context.Context, userID, playlistID uint) (*playlists.PlaylistDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, userID, playlistID)
+	ret0, _ := ret[0].(*playlists.PlaylistDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockserviceMockRecorder) GetPlaylist(ctx, userID, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*Mockservice)(nil).GetPlaylist), ctx, userID, playlistID)
+}
+
+// GetSharedPlaylist mocks base method.
+func (m *Mockservice) GetSharedPlaylist(ctx context.Context, slug string) (*playlists.PlaylistDetailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSharedPlaylist", ctx, slug)
+	ret0, _ := ret[0].(*playlists.PlaylistDetailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSharedPlaylist indicates an expected call of GetSharedPlaylist.
+func (mr *MockserviceMockRecorder) GetSharedPlaylist(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedPlaylist", reflect.TypeOf((*Mockservice)(nil).GetSharedPlaylist), ctx, slug)
+}
+
+// ImportPlaylist mocks base method.
+func (m *Mockservice) ImportPlaylist(ctx context.Context, userID uint, format string, r io.Reader) (*playlists.ImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportPlaylist", ctx, userID, format, r)
+	ret0, _ := ret[0].(*playlists.ImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportPlaylist indicates an expected call of ImportPlaylist.
+func (mr *MockserviceMockRecorder) ImportPlaylist(ctx, userID, format, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportPlaylist", reflect.TypeOf((*Mockservice)(nil).ImportPlaylist), ctx, userID, format, r)
+}
+
+// InviteMember mocks base method.
+func (m *Mockservice) InviteMember(ctx context.Context, userID, playlistID uint, request playlists.InviteMemberRequest) (*playlists.MemberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteMember", ctx, userID, playlistID, request)
+	ret0, _ := ret[0].(*playlists.MemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InviteMember indicates an expected call of InviteMember.
+func (mr *MockserviceMockRecorder) InviteMember(ctx, userID, playlistID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteMember", reflect.TypeOf((*Mockservice)(nil).InviteMember), ctx, userID, playlistID, request)
+}
+
+// ListMembers mocks base method.
+func (m *Mockservice) ListMembers(ctx context.Context, userID, playlistID uint) (*playlists.MembersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMembers", ctx, userID, playlistID)
+	ret0, _ := ret[0].(*playlists.MembersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMembers indicates an expected call of ListMembers.
+func (mr *MockserviceMockRecorder) ListMembers(ctx, userID, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMembers", reflect.TypeOf((*Mockservice)(nil).ListMembers), ctx, userID, playlistID)
+}
+
+// ListPlaylists mocks base method.
+func (m *Mockservice) ListPlaylists(ctx context.Context, userID uint) (*playlists.ListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, userID)
+	ret0, _ := ret[0].(*playlists.ListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockserviceMockRecorder) ListPlaylists(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*Mockservice)(nil).ListPlaylists), ctx, userID)
+}
+
+// RemoveMember mocks base method.
+func (m *Mockservice) RemoveMember(ctx context.Context, userID, playlistID, memberUserID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, userID, playlistID, memberUserID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockserviceMockRecorder) RemoveMember(ctx, userID, playlistID, memberUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*Mockservice)(nil).RemoveMember), ctx, userID, playlistID, memberUserID)
+}
+
+// RemoveTracks mocks base method.
+func (m *Mockservice) RemoveTracks(ctx context.Context, userID, playlistID uint, request playlists.RemoveTracksRequest) (*playlists.PlaylistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTracks", ctx, userID, playlistID, request)
+	ret0, _ := ret[0].(*playlists.PlaylistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTracks indicates an expected call of RemoveTracks.
+func (mr *MockserviceMockRecorder) RemoveTracks(ctx, userID, playlistID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTracks", reflect.TypeOf((*Mockservice)(nil).RemoveTracks), ctx, userID, playlistID, request)
+}
+
+// RenamePlaylist mocks base method.
+func (m *Mockservice) RenamePlaylist(ctx context.Context, userID, playlistID uint, request playlists.PlaylistRequest) (*playlists.PlaylistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenamePlaylist", ctx, userID, playlistID, request)
+	ret0, _ := ret[0].(*playlists.PlaylistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenamePlaylist indicates an expected call of RenamePlaylist.
+func (mr *MockserviceMockRecorder) RenamePlaylist(ctx, userID, playlistID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenamePlaylist", reflect.TypeOf((*Mockservice)(nil).RenamePlaylist), ctx, userID, playlistID, request)
+}
+
+// ReorderTracks mocks base method.
+func (m *Mockservice) ReorderTracks(ctx context.Context, userID, playlistID uint, request playlists.ReorderTracksRequest) (*playlists.PlaylistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderTracks", ctx, userID, playlistID, request)
+	ret0, _ := ret[0].(*playlists.PlaylistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderTracks indicates an expected call of ReorderTracks.
+func (mr *MockserviceMockRecorder) ReorderTracks(ctx, userID, playlistID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderTracks", reflect.TypeOf((*Mockservice)(nil).ReorderTracks), ctx, userID, playlistID, request)
+}
+
+// RevokeShareLink mocks base method.
+func (m *Mockservice) RevokeShareLink(ctx context.Context, userID, playlistID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeShareLink", ctx, userID, playlistID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeShareLink indicates an expected call of RevokeShareLink.
+func (mr *MockserviceMockRecorder) RevokeShareLink(ctx, userID, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeShareLink", reflect.TypeOf((*Mockservice)(nil).RevokeShareLink), ctx, userID, playlistID)
+}
+
+// ShareLink mocks base method.
+func (m *Mockservice) ShareLink(ctx context.Context, userID, playlistID uint) (*playlists.ShareLinkResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShareLink", ctx, userID, playlistID)
+	ret0, _ := ret[0].(*playlists.ShareLinkResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShareLink indicates an expected call of ShareLink.
+func (mr *MockserviceMockRecorder) ShareLink(ctx, userID, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShareLink", reflect.TypeOf((*Mockservice)(nil).ShareLink), ctx, userID, playlistID)
+}
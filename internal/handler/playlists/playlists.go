@@ -0,0 +1,160 @@
+package playlists
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+)
+
+func (h *Handler) CreatePlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req playlists.PlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.CreatePlaylist(ctx, userID, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *Handler) ListPlaylists(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetUint("userID")
+	response, err := h.service.ListPlaylists(ctx, userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetPlaylist(ctx, userID, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) RenamePlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+	var req playlists.PlaylistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.RenamePlaylist(ctx, userID, id, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) DeletePlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetUint("userID")
+	err := h.service.DeletePlaylist(ctx, userID, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) AddTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+	var req playlists.AddTracksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.AddTracks(ctx, userID, id, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) RemoveTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+	var req playlists.RemoveTracksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.RemoveTracks(ctx, userID, id, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) ReorderTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+	var req playlists.ReorderTracksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.ReorderTracks(ctx, userID, id, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
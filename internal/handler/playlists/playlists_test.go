@@ -0,0 +1,328 @@
+package playlists
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
+	playlistsSvc "github.com/xprasetio/go-spotify/internal/service/playlists"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_CreatePlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	playlist := playlists.PlaylistResponse{
+		ID:        3,
+		Name:      "road trip",
+		CreatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		expectedStatusCode int
+		expectedBody       playlists.PlaylistResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			body:               `{"name":"road trip"}`,
+			expectedStatusCode: 201,
+			expectedBody:       playlist,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().CreatePlaylist(gomock.Any(), uint(1), playlists.PlaylistRequest{Name: "road trip"}).Return(&playlist, nil)
+			},
+		},
+		{
+			name:               "failed: missing name",
+			body:               `{}`,
+			expectedStatusCode: 400,
+			wantErr:            true,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: blank name",
+			body:               `{"name":" "}`,
+			expectedStatusCode: 400,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().CreatePlaylist(gomock.Any(), uint(1), playlists.PlaylistRequest{Name: " "}).Return(nil, playlistsSvc.ErrEmptyName)
+			},
+		},
+		{
+			name:               "failed",
+			body:               `{"name":"road trip"}`,
+			expectedStatusCode: 500,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().CreatePlaylist(gomock.Any(), uint(1), playlists.PlaylistRequest{Name: "road trip"}).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/playlists`, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := playlists.PlaylistResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestHandler_GetPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	isLikedTrue := true
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	playlist := playlists.PlaylistDetailResponse{
+		PlaylistResponse: playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 1, CreatedAt: now, UpdatedAt: now},
+		Tracks: []playlists.PlaylistTrackItem{
+			{
+				SpotifyTrackObject: spotify.SpotifyTrackObject{
+					ID:          "3z8h0TU7ReDPLIbEnYhWZb",
+					Name:        "Bohemian Rhapsody",
+					ArtistsName: []string{"Queen"},
+					IsLiked:     &isLikedTrue,
+				},
+				Position: 0,
+				AddedAt:  now,
+			},
+		},
+	}
+
+	tests := []struct {
+		name               string
+		playlistID         string
+		expectedStatusCode int
+		expectedBody       playlists.PlaylistDetailResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			playlistID:         "3",
+			expectedStatusCode: 200,
+			expectedBody:       playlist,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().GetPlaylist(gomock.Any(), uint(1), uint(3)).Return(&playlist, nil)
+			},
+		},
+		{
+			name:               "failed: not found",
+			playlistID:         "4",
+			expectedStatusCode: 404,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetPlaylist(gomock.Any(), uint(1), uint(4)).Return(nil, playlistsSvc.ErrPlaylistNotFound)
+			},
+		},
+		{
+			name:               "failed: invalid id",
+			playlistID:         "abc",
+			expectedStatusCode: 400,
+			wantErr:            true,
+			mockFn:             func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, `/playlists/`+tt.playlistID, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := playlists.PlaylistDetailResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestHandler_EditTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	playlist := playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 2, CreatedAt: now, UpdatedAt: now}
+
+	tests := []struct {
+		name               string
+		method             string
+		endpoint           string
+		body               string
+		unverified         bool
+		expectedStatusCode int
+		mockFn             func()
+	}{
+		{
+			name:               "success: add tracks",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/tracks`,
+			body:               `{"ids":["track1","track2"],"version":4}`,
+			expectedStatusCode: 200,
+			mockFn: func() {
+				mockSvc.EXPECT().AddTracks(gomock.Any(), uint(1), uint(3), playlists.AddTracksRequest{IDs: []string{"track1", "track2"}, Version: 4}).Return(&playlist, nil)
+			},
+		},
+		{
+			name:               "failed: add without ids",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/tracks`,
+			body:               `{"ids":[],"version":4}`,
+			expectedStatusCode: 400,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: add without version",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/tracks`,
+			body:               `{"ids":["track1"]}`,
+			expectedStatusCode: 400,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: add on an older version",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/tracks`,
+			body:               `{"ids":["track1"],"version":3}`,
+			expectedStatusCode: 409,
+			mockFn: func() {
+				mockSvc.EXPECT().AddTracks(gomock.Any(), uint(1), uint(3), playlists.AddTracksRequest{IDs: []string{"track1"}, Version: 3}).Return(nil, playlistsRepo.ErrVersionConflict)
+			},
+		},
+		{
+			name:               "success: remove tracks",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3/tracks`,
+			body:               `{"positions":[0,2],"version":4}`,
+			expectedStatusCode: 200,
+			mockFn: func() {
+				mockSvc.EXPECT().RemoveTracks(gomock.Any(), uint(1), uint(3), playlists.RemoveTracksRequest{Positions: []int{0, 2}, Version: 4}).Return(&playlist, nil)
+			},
+		},
+		{
+			name:               "failed: remove as a viewer",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3/tracks`,
+			body:               `{"positions":[0],"version":4}`,
+			expectedStatusCode: 403,
+			mockFn: func() {
+				mockSvc.EXPECT().RemoveTracks(gomock.Any(), uint(1), uint(3), playlists.RemoveTracksRequest{Positions: []int{0}, Version: 4}).Return(nil, playlistsSvc.ErrForbidden)
+			},
+		},
+		{
+			name:               "failed: reorder out of range",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/tracks/reorder`,
+			body:               `{"rangeStart":5,"insertBefore":0,"version":4}`,
+			expectedStatusCode: 400,
+			mockFn: func() {
+				mockSvc.EXPECT().ReorderTracks(gomock.Any(), uint(1), uint(3), playlists.ReorderTracksRequest{RangeStart: 5, Version: 4}).Return(nil, playlistsRepo.ErrInvalidPosition)
+			},
+		},
+		{
+			name:               "failed: delete with an unverified email",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3`,
+			unverified:         true,
+			expectedStatusCode: 403,
+			mockFn:             func() {},
+		},
+		{
+			name:               "success: delete playlist",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3`,
+			expectedStatusCode: 204,
+			mockFn: func() {
+				mockSvc.EXPECT().DeletePlaylist(gomock.Any(), uint(1), uint(3)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tt.method, tt.endpoint, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: !tt.unverified}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
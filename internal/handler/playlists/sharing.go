@@ -0,0 +1,116 @@
+package playlists
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+)
+
+func (h *Handler) ListMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.ListMembers(ctx, userID, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) InviteMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+
+	var req playlists.InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.InviteMember(ctx, userID, id, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) RemoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+	memberUserID, err := strconv.ParseUint(c.Param("userID"), 10, 64)
+	if err != nil || memberUserID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	err = h.service.RemoveMember(ctx, userID, id, uint(memberUserID))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ShareLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.ShareLink(ctx, userID, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) RevokeShareLink(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+
+	userID := c.GetUint("userID")
+	err := h.service.RevokeShareLink(ctx, userID, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) GetSharedPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	response, err := h.service.GetSharedPlaylist(ctx, c.Param("slug"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
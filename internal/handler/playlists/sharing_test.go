@@ -0,0 +1,199 @@
+package playlists
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	playlistsSvc "github.com/xprasetio/go-spotify/internal/service/playlists"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_Members(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	tests := []struct {
+		name               string
+		method             string
+		endpoint           string
+		body               string
+		expectedStatusCode int
+		mockFn             func()
+	}{
+		{
+			name:               "success: invite",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/members`,
+			body:               `{"username":"friend","role":"editor"}`,
+			expectedStatusCode: 200,
+			mockFn: func() {
+				mockSvc.EXPECT().InviteMember(gomock.Any(), uint(1), uint(3), playlists.InviteMemberRequest{Username: "friend", Role: "editor"}).
+					Return(&playlists.MemberResponse{UserID: 2, Username: "friend", Role: "editor"}, nil)
+			},
+		},
+		{
+			name:               "failed: invite as owner",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/members`,
+			body:               `{"username":"friend","role":"owner"}`,
+			expectedStatusCode: 400,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: invite unknown user",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/members`,
+			body:               `{"username":"nobody","role":"viewer"}`,
+			expectedStatusCode: 404,
+			mockFn: func() {
+				mockSvc.EXPECT().InviteMember(gomock.Any(), uint(1), uint(3), playlists.InviteMemberRequest{Username: "nobody", Role: "viewer"}).
+					Return(nil, playlistsSvc.ErrUserNotFound)
+			},
+		},
+		{
+			name:               "success: revoke",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3/members/2`,
+			expectedStatusCode: 204,
+			mockFn: func() {
+				mockSvc.EXPECT().RemoveMember(gomock.Any(), uint(1), uint(3), uint(2)).Return(nil)
+			},
+		},
+		{
+			name:               "failed: revoke without the role",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3/members/2`,
+			expectedStatusCode: 403,
+			mockFn: func() {
+				mockSvc.EXPECT().RemoveMember(gomock.Any(), uint(1), uint(3), uint(2)).Return(playlistsSvc.ErrForbidden)
+			},
+		},
+		{
+			name:               "failed: invalid user id",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3/members/abc`,
+			expectedStatusCode: 400,
+			mockFn:             func() {},
+		},
+		{
+			name:               "success: share link",
+			method:             http.MethodPost,
+			endpoint:           `/playlists/3/share`,
+			expectedStatusCode: 200,
+			mockFn: func() {
+				mockSvc.EXPECT().ShareLink(gomock.Any(), uint(1), uint(3)).Return(&playlists.ShareLinkResponse{Slug: "c2hhcmVk"}, nil)
+			},
+		},
+		{
+			name:               "success: revoke share link",
+			method:             http.MethodDelete,
+			endpoint:           `/playlists/3/share`,
+			expectedStatusCode: 204,
+			mockFn: func() {
+				mockSvc.EXPECT().RevokeShareLink(gomock.Any(), uint(1), uint(3)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tt.method, tt.endpoint, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestHandler_GetSharedPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	playlist := playlists.PlaylistDetailResponse{
+		PlaylistResponse: playlists.PlaylistResponse{ID: 3, Name: "road trip", Version: 2, CreatedAt: now, UpdatedAt: now},
+		Tracks:           []playlists.PlaylistTrackItem{},
+	}
+
+	tests := []struct {
+		name               string
+		slug               string
+		expectedStatusCode int
+		expectedBody       playlists.PlaylistDetailResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success: without a token",
+			slug:               "c2hhcmVk",
+			expectedStatusCode: 200,
+			expectedBody:       playlist,
+			mockFn: func() {
+				mockSvc.EXPECT().GetSharedPlaylist(gomock.Any(), "c2hhcmVk").Return(&playlist, nil)
+			},
+		},
+		{
+			name:               "failed: revoked",
+			slug:               "revoked",
+			expectedStatusCode: 404,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetSharedPlaylist(gomock.Any(), "revoked").Return(nil, playlistsSvc.ErrPlaylistNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, `/shared/playlists/`+tt.slug, nil)
+			assert.NoError(t, err)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := playlists.PlaylistDetailResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
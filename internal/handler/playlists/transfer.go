@@ -0,0 +1,88 @@
+package playlists
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+)
+
+// maxImportSize bounds an uploaded playlist file, a full playlist in any
+// of the formats stays well below it.
+const maxImportSize = 1 << 20
+
+// ExportPlaylist sends the playlist as a file download, json unless the
+// format asks for m3u or xspf.
+func (h *Handler) ExportPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, ok := playlistID(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", playlistfile.FormatJSON)
+	contentType, err := playlistfile.ContentType(format)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	file, err := h.service.ExportPlaylist(ctx, userID, id)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", attachment(file.Name, format))
+	c.Status(http.StatusOK)
+	if err := playlistfile.Write(c.Writer, format, *file); err != nil {
+		// the status is out already, all that's left is to log it
+		_ = c.Error(err)
+	}
+}
+
+// ImportPlaylist creates a playlist from the file in the body, in the
+// format of the format query.
+func (h *Handler) ImportPlaylist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	format := c.Query("format")
+	if _, err := playlistfile.ContentType(format); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	response, err := h.service.ImportPlaylist(ctx, userID, format, body)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response)
+}
+
+// attachment names the download after the playlist, without what would
+// make it a path.
+func attachment(name, format string) string {
+	name = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(name))
+	if name == "" {
+		name = "playlist"
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": name + "." + format})
+	if disposition == "" {
+		return "attachment; filename=playlist." + format
+	}
+	return disposition
+}
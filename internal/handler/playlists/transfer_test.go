@@ -0,0 +1,223 @@
+package playlists
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	playlistsSvc "github.com/xprasetio/go-spotify/internal/service/playlists"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_ExportPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	file := &playlistfile.Playlist{
+		Name: "road/trip",
+		Tracks: []playlistfile.Track{
+			{Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354320, Location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"},
+		},
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		expectedStatusCode  int
+		expectedContentType string
+		expectedDisposition string
+		expectedBody        string
+		mockFn              func()
+	}{
+		{
+			name:                "success: m3u",
+			query:               "?format=m3u",
+			expectedStatusCode:  200,
+			expectedContentType: "audio/x-mpegurl",
+			expectedDisposition: "attachment; filename=road_trip.m3u",
+			expectedBody: "#EXTM3U\n" +
+				"#PLAYLIST:road/trip\n" +
+				"#EXTINF:354,Queen - Bohemian Rhapsody\n" +
+				"spotify:track:3z8h0TU7ReDPLIbEnYhWZb\n",
+			mockFn: func() {
+				mockSvc.EXPECT().ExportPlaylist(gomock.Any(), uint(1), uint(3)).Return(file, nil)
+			},
+		},
+		{
+			name:                "success: json by default",
+			expectedStatusCode:  200,
+			expectedContentType: "application/json",
+			expectedDisposition: "attachment; filename=road_trip.json",
+			expectedBody: `{
+  "name": "road/trip",
+  "tracks": [
+    {
+      "name": "Bohemian Rhapsody",
+      "artists": [
+        "Queen"
+      ],
+      "durationMs": 354320,
+      "uri": "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"
+    }
+  ]
+}
+`,
+			mockFn: func() {
+				mockSvc.EXPECT().ExportPlaylist(gomock.Any(), uint(1), uint(3)).Return(file, nil)
+			},
+		},
+		{
+			name:               "failed: unknown format",
+			query:              "?format=pls",
+			expectedStatusCode: 400,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: not found",
+			query:              "?format=xspf",
+			expectedStatusCode: 404,
+			mockFn: func() {
+				mockSvc.EXPECT().ExportPlaylist(gomock.Any(), uint(1), uint(3)).Return(nil, playlistsSvc.ErrPlaylistNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, `/playlists/3/export`+tt.query, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+				assert.Equal(t, tt.expectedDisposition, w.Header().Get("Content-Disposition"))
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandler_ImportPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	report := playlists.ImportResponse{
+		Playlist: playlists.PlaylistResponse{ID: 9, Name: "road trip", TrackCount: 1, Version: 1, Role: "owner"},
+		Matched:  1,
+		Unmatched: []playlists.UnmatchedEntry{
+			{Line: 4, Entry: "Nobody - Unknown Song", Reason: "no close enough match on spotify"},
+		},
+	}
+	m3u := "#EXTM3U\nspotify:track:3z8h0TU7ReDPLIbEnYhWZb\n#EXTINF:200,Nobody - Unknown Song\nMusic/unknown.mp3\n"
+
+	tests := []struct {
+		name               string
+		query              string
+		body               string
+		expectedStatusCode int
+		expectedBody       *playlists.ImportResponse
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			query:              "?format=m3u",
+			body:               m3u,
+			expectedStatusCode: 201,
+			expectedBody:       &report,
+			mockFn: func() {
+				mockSvc.EXPECT().ImportPlaylist(gomock.Any(), uint(1), "m3u", gomock.Any()).
+					DoAndReturn(func(_ any, _ uint, _ string, r io.Reader) (*playlists.ImportResponse, error) {
+						file, err := io.ReadAll(r)
+						assert.NoError(t, err)
+						assert.Equal(t, m3u, string(file))
+						return &report, nil
+					})
+			},
+		},
+		{
+			name:               "failed: missing format",
+			body:               m3u,
+			expectedStatusCode: 400,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: empty file",
+			query:              "?format=m3u",
+			body:               "#EXTM3U\n",
+			expectedStatusCode: 400,
+			mockFn: func() {
+				mockSvc.EXPECT().ImportPlaylist(gomock.Any(), uint(1), "m3u", gomock.Any()).Return(nil, playlistsSvc.ErrEmptyImport)
+			},
+		},
+		{
+			name:               "failed: file too large",
+			query:              "?format=m3u",
+			body:               "#EXTM3U\n" + strings.Repeat("spotify:track:3z8h0TU7ReDPLIbEnYhWZb\n", maxImportSize/37+1),
+			expectedStatusCode: 413,
+			mockFn: func() {
+				mockSvc.EXPECT().ImportPlaylist(gomock.Any(), uint(1), "m3u", gomock.Any()).
+					DoAndReturn(func(_ any, _ uint, format string, r io.Reader) (*playlists.ImportResponse, error) {
+						_, err := playlistfile.Read(r, format)
+						return nil, err
+					})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/playlists/import`+tt.query, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if tt.expectedBody != nil {
+				response := playlists.ImportResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, *tt.expectedBody, response)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package spotifyerr maps spotify failures to the status codes the
+// handlers answer with.
+package spotifyerr
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+)
+
+// Status returns the status code for a spotify failure, setting Retry-After
+// when spotify asked to wait. It reports false for any other error. A user
+// without a linked spotify account gets a conflict.
+func Status(c *gin.Context, err error) (int, bool) {
+	switch {
+	case errors.Is(err, spotifyRepo.ErrRateLimited):
+		var apiErr *spotifyRepo.APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(apiErr.RetryAfter.Seconds()))))
+		}
+		return http.StatusTooManyRequests, true
+	case errors.Is(err, spotifyRepo.ErrBadRequest):
+		return http.StatusBadRequest, true
+	case errors.Is(err, spotifyRepo.ErrUnauthorized), errors.Is(err, spotifyRepo.ErrUpstream):
+		return http.StatusBadGateway, true
+	case errors.Is(err, spotifyRepo.ErrUnavailable):
+		return http.StatusServiceUnavailable, true
+	case errors.Is(err, spotifyRepo.ErrNotFound):
+		return http.StatusNotFound, true
+	case errors.Is(err, spotifyRepo.ErrNotLinked):
+		return http.StatusConflict, true
+	}
+	return 0, false
+}
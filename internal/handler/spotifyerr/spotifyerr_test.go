@@ -0,0 +1,63 @@
+package spotifyerr
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantOK     bool
+		retryAfter string
+	}{
+		{
+			name:       "rate limited",
+			err:        &spotifyRepo.APIError{StatusCode: 429, RetryAfter: 1500 * time.Millisecond},
+			wantStatus: http.StatusTooManyRequests,
+			wantOK:     true,
+			retryAfter: "2",
+		},
+		{
+			name:       "rejected request",
+			err:        &spotifyRepo.APIError{StatusCode: 400},
+			wantStatus: http.StatusBadRequest,
+			wantOK:     true,
+		},
+		{
+			name:       "upstream error",
+			err:        &spotifyRepo.APIError{StatusCode: 502},
+			wantStatus: http.StatusBadGateway,
+			wantOK:     true,
+		},
+		{
+			name:       "not linked",
+			err:        fmt.Errorf("like sync: %w", spotifyRepo.ErrNotLinked),
+			wantStatus: http.StatusConflict,
+			wantOK:     true,
+		},
+		{
+			name: "not from spotify",
+			err:  assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			status, ok := Status(c, tt.err)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.retryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package tracks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetAlbum(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	albumID := c.Param("id")
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetAlbum(ctx, userID, albumID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetAlbumTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	albumID := c.Param("id")
+	pageSizeStr := c.Query("pageSize")
+	pageIndexStr := c.Query("pageIndex")
+
+	pageSize, err := strconv.Atoi(pageSizeStr)
+	if err != nil {
+		pageSize = 10
+	}
+
+	pageIndex, err := strconv.Atoi(pageIndexStr)
+	if err != nil {
+		pageIndex = 1
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetAlbumTracks(ctx, userID, albumID, pageSize, pageIndex)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
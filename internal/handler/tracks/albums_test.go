@@ -0,0 +1,94 @@
+package tracks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_GetAlbumTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	albumTracks := spotify.AlbumTracksResponse{
+		Limit:  10,
+		Offset: 0,
+		Items: []spotify.SpotifyTrackObject{
+			{
+				AlbumImagesURL: []string{},
+				ArtistsName:    []string{"Daft Punk"},
+				ID:             "0DiWol3AO6WpXZgp0goxAV",
+				Name:           "One More Time",
+			},
+		},
+		Total: 14,
+	}
+
+	tests := []struct {
+		name               string
+		expectedStatusCode int
+		expectedBody       spotify.AlbumTracksResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: 200,
+			expectedBody:       albumTracks,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().GetAlbumTracks(gomock.Any(), uint(1), "2noRn2Aes5aoNVsU6iWThc", 10, 1).Return(&albumTracks, nil)
+			},
+		},
+		{
+			name:               "failed",
+			expectedStatusCode: 500,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetAlbumTracks(gomock.Any(), uint(1), "2noRn2Aes5aoNVsU6iWThc", 10, 1).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			endpoint := `/albums/2noRn2Aes5aoNVsU6iWThc/tracks?pageSize=10&pageIndex=1`
+
+			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := spotify.AlbumTracksResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
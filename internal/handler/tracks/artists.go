@@ -0,0 +1,35 @@
+package tracks
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) GetArtist(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	artistID := c.Param("id")
+
+	response, err := h.service.GetArtist(ctx, artistID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetArtistTopTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	artistID := c.Param("id")
+	market := c.Query("market")
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetArtistTopTracks(ctx, userID, artistID, market)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
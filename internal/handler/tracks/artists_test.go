@@ -0,0 +1,90 @@
+package tracks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_GetArtist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	artist := spotify.ArtistResponse{
+		ID:         "1dfeR4HaWDbWqFHLkxsg1d",
+		Name:       "Queen",
+		Genres:     []string{"classic rock", "glam rock"},
+		Followers:  51000000,
+		Popularity: 86,
+		ImagesURL:  []string{"https://i.scdn.co/image/queen"},
+	}
+
+	tests := []struct {
+		name               string
+		expectedStatusCode int
+		expectedBody       spotify.ArtistResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: 200,
+			expectedBody:       artist,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().GetArtist(gomock.Any(), "1dfeR4HaWDbWqFHLkxsg1d").Return(&artist, nil)
+			},
+		},
+		{
+			name:               "failed: not found",
+			expectedStatusCode: 404,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetArtist(gomock.Any(), "1dfeR4HaWDbWqFHLkxsg1d").Return(nil, spotifyRepo.ErrNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			endpoint := `/artists/1dfeR4HaWDbWqFHLkxsg1d`
+
+			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := spotify.ArtistResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
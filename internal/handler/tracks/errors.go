@@ -0,0 +1,31 @@
+package tracks
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/handler/spotifyerr"
+	tracksSvc "github.com/xprasetio/go-spotify/internal/service/tracks"
+)
+
+// writeError maps spotify failures with spotifyerr, invalid input to bad
+// request and missing import jobs to not found, anything else is an
+// internal error.
+func writeError(c *gin.Context, err error) {
+	status, ok := spotifyerr.Status(c, err)
+	if !ok {
+		switch {
+		case errors.Is(err, tracksSvc.ErrInvalidCursor), errors.Is(err, tracksSvc.ErrNoRecommendationSeeds):
+			status = http.StatusBadRequest
+		case errors.Is(err, tracksSvc.ErrImportJobNotFound):
+			status = http.StatusNotFound
+		default:
+			status = http.StatusInternalServerError
+		}
+	}
+
+	c.JSON(status, gin.H{
+		"error": err.Error(),
+	})
+}
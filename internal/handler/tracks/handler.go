@@ -11,9 +11,18 @@ import (
 
 //go:generate mockgen -source=handler.go -destination=handler_mock_test.go -package=tracks
 type service interface {
-	Search(ctx context.Context, query string, pageSize, pageIndex int, userID uint) (*spotify.SearchResponse, error)
+	Search(ctx context.Context, query string, pageSize, pageIndex int, market string, userID uint) (*spotify.SearchResponse, error)
 	UpsertTrackActivities(ctx context.Context, userID uint, request trackactivities.TrackActivityRequest) error
-	GetRecommendation(ctx context.Context, userID uint, limit int, trackID string) (*spotify.RecommendationResponse, error)
+	GetRecommendation(ctx context.Context, userID uint, request spotify.RecommendationRequest) (*spotify.RecommendationResponse, error)
+	GetTrack(ctx context.Context, userID uint, trackID string) (*spotify.SpotifyTrackObject, error)
+	GetTracks(ctx context.Context, userID uint, trackIDs []string) (*spotify.TracksResponse, error)
+	ListTrackActivities(ctx context.Context, userID uint, request trackactivities.ListRequest) (*spotify.TrackActivitiesResponse, error)
+	GetArtist(ctx context.Context, artistID string) (*spotify.ArtistResponse, error)
+	GetArtistTopTracks(ctx context.Context, userID uint, artistID, market string) (*spotify.ArtistTopTracksResponse, error)
+	GetAlbum(ctx context.Context, userID uint, albumID string) (*spotify.AlbumResponse, error)
+	GetAlbumTracks(ctx context.Context, userID uint, albumID string, pageSize, pageIndex int) (*spotify.AlbumTracksResponse, error)
+	StartLibraryImport(ctx context.Context, userID uint) (*trackactivities.ImportJobResponse, error)
+	GetLibraryImport(ctx context.Context, userID, jobID uint) (*trackactivities.ImportJobResponse, error)
 }
 
 type Handler struct {
@@ -32,6 +41,22 @@ func (h *Handler) RegisterRoute() {
 	route := h.Group("/tracks")
 	route.Use(middleware.AuthMiddleware())
 	route.GET("/search", h.Search)
-	route.POST("/track-activity", h.UpsertTrackActivities)
+	route.POST("/track-activity", middleware.RequireVerifiedEmail(), h.UpsertTrackActivities)
+	route.GET("/liked", h.GetLikedTracks)
+	route.GET("/disliked", h.GetDislikedTracks)
 	route.GET("/recommendations", h.GetRecommendation)
+	route.POST("/batch", h.GetTracks)
+	route.POST("/import", middleware.RequireVerifiedEmail(), h.StartLibraryImport)
+	route.GET("/import/:jobID", h.GetLibraryImport)
+	route.GET("/:id", h.GetTrack)
+
+	artistRoute := h.Group("/artists")
+	artistRoute.Use(middleware.AuthMiddleware())
+	artistRoute.GET("/:id", h.GetArtist)
+	artistRoute.GET("/:id/top-tracks", h.GetArtistTopTracks)
+
+	albumRoute := h.Group("/albums")
+	albumRoute.Use(middleware.AuthMiddleware())
+	albumRoute.GET("/:id", h.GetAlbum)
+	albumRoute.GET("/:id/tracks", h.GetAlbumTracks)
 }
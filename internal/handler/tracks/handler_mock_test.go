@@ -41,34 +41,169 @@ func (m *Mockservice) EXPECT() *MockserviceMockRecorder {
 	return m.recorder
 }
 
+// GetAlbum mocks base method.
+func (m *Mockservice) GetAlbum(ctx context.Context, userID uint, albumID string) (*spotify.AlbumResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, userID, albumID)
+	ret0, _ := ret[0].(*spotify.AlbumResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockserviceMockRecorder) GetAlbum(ctx, userID, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*Mockservice)(nil).GetAlbum), ctx, userID, albumID)
+}
+
+// GetAlbumTracks mocks base method.
+func (m *Mockservice) GetAlbumTracks(ctx context.Context, userID uint, albumID string, pageSize, pageIndex int) (*spotify.AlbumTracksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbumTracks", ctx, userID, albumID, pageSize, pageIndex)
+	ret0, _ := ret[0].(*spotify.AlbumTracksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbumTracks indicates an expected call of GetAlbumTracks.
+func (mr *MockserviceMockRecorder) GetAlbumTracks(ctx, userID, albumID, pageSize, pageIndex any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbumTracks", reflect.TypeOf((*Mockservice)(nil).GetAlbumTracks), ctx, userID, albumID, pageSize, pageIndex)
+}
+
+// GetArtist mocks base method.
+func (m *Mockservice) GetArtist(ctx context.Context, artistID string) (*spotify.ArtistResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, artistID)
+	ret0, _ := ret[0].(*spotify.ArtistResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockserviceMockRecorder) GetArtist(ctx, artistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*Mockservice)(nil).GetArtist), ctx, artistID)
+}
+
+// GetArtistTopTracks mocks base method.
+func (m *Mockservice) GetArtistTopTracks(ctx context.Context, userID uint, artistID, market string) (*spotify.ArtistTopTracksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtistTopTracks", ctx, userID, artistID, market)
+	ret0, _ := ret[0].(*spotify.ArtistTopTracksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtistTopTracks indicates an expected call of GetArtistTopTracks.
+func (mr *MockserviceMockRecorder) GetArtistTopTracks(ctx, userID, artistID, market any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtistTopTracks", reflect.TypeOf((*Mockservice)(nil).GetArtistTopTracks), ctx, userID, artistID, market)
+}
+
+// GetLibraryImport mocks base method.
+func (m *Mockservice) GetLibraryImport(ctx context.Context, userID, jobID uint) (*trackactivities.ImportJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLibraryImport", ctx, userID, jobID)
+	ret0, _ := ret[0].(*trackactivities.ImportJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLibraryImport indicates an expected call of GetLibraryImport.
+func (mr *MockserviceMockRecorder) GetLibraryImport(ctx, userID, jobID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLibraryImport", reflect.TypeOf((*Mockservice)(nil).GetLibraryImport), ctx, userID, jobID)
+}
+
 // GetRecommendation mocks base method.
-func (m *Mockservice) GetRecommendation(ctx context.Context, userID uint, limit int, trackID string) (*spotify.RecommendationResponse, error) {
+func (m *Mockservice) GetRecommendation(ctx context.Context, userID uint, request spotify.RecommendationRequest) (*spotify.RecommendationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecommendation", ctx, userID, limit, trackID)
+	ret := m.ctrl.Call(m, "GetRecommendation", ctx, userID, request)
 	ret0, _ := ret[0].(*spotify.RecommendationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRecommendation indicates an expected call of GetRecommendation.
-func (mr *MockserviceMockRecorder) GetRecommendation(ctx, userID, limit, trackID any) *gomock.Call {
+func (mr *MockserviceMockRecorder) GetRecommendation(ctx, userID, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendation", reflect.TypeOf((*Mockservice)(nil).GetRecommendation), ctx, userID, request)
+}
+
+// GetTrack mocks base method.
+func (m *Mockservice) GetTrack(ctx context.Context, userID uint, trackID string) (*spotify.SpotifyTrackObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrack", ctx, userID, trackID)
+	ret0, _ := ret[0].(*spotify.SpotifyTrackObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrack indicates an expected call of GetTrack.
+func (mr *MockserviceMockRecorder) GetTrack(ctx, userID, trackID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrack", reflect.TypeOf((*Mockservice)(nil).GetTrack), ctx, userID, trackID)
+}
+
+// GetTracks mocks base method.
+func (m *Mockservice) GetTracks(ctx context.Context, userID uint, trackIDs []string) (*spotify.TracksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, userID, trackIDs)
+	ret0, _ := ret[0].(*spotify.TracksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockserviceMockRecorder) GetTracks(ctx, userID, trackIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*Mockservice)(nil).GetTracks), ctx, userID, trackIDs)
+}
+
+// ListTrackActivities mocks base method.
+func (m *Mockservice) ListTrackActivities(ctx context.Context, userID uint, request trackactivities.ListRequest) (*spotify.TrackActivitiesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrackActivities", ctx, userID, request)
+	ret0, _ := ret[0].(*spotify.TrackActivitiesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrackActivities indicates an expected call of ListTrackActivities.
+func (mr *MockserviceMockRecorder) ListTrackActivities(ctx, userID, request any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendation", reflect.TypeOf((*Mockservice)(nil).GetRecommendation), ctx, userID, limit, trackID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrackActivities", reflect.TypeOf((*Mockservice)(nil).ListTrackActivities), ctx, userID, request)
 }
 
 // Search mocks base method.
-func (m *Mockservice) Search(ctx context.Context, query string, pageSize, pageIndex int, userID uint) (*spotify.SearchResponse, error) {
+func (m *Mockservice) Search(ctx context.Context, query string, pageSize, pageIndex int, market string, userID uint) (*spotify.SearchResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, query, pageSize, pageIndex, userID)
+	ret := m.ctrl.Call(m, "Search", ctx, query, pageSize, pageIndex, market, userID)
 	ret0, _ := ret[0].(*spotify.SearchResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockserviceMockRecorder) Search(ctx, query, pageSize, pageIndex, userID any) *gomock.Call {
+func (mr *MockserviceMockRecorder) Search(ctx, query, pageSize, pageIndex, market, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mockservice)(nil).Search), ctx, query, pageSize, pageIndex, market, userID)
+}
+
+// StartLibraryImport mocks base method.
+func (m *Mockservice) StartLibraryImport(ctx context.Context, userID uint) (*trackactivities.ImportJobResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLibraryImport", ctx, userID)
+	ret0, _ := ret[0].(*trackactivities.ImportJobResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartLibraryImport indicates an expected call of StartLibraryImport.
+func (mr *MockserviceMockRecorder) StartLibraryImport(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*Mockservice)(nil).Search), ctx, query, pageSize, pageIndex, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLibraryImport", reflect.TypeOf((*Mockservice)(nil).StartLibraryImport), ctx, userID)
 }
 
 // UpsertTrackActivities mocks base method.
@@ -0,0 +1,38 @@
+package tracks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) StartLibraryImport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	userID := c.GetUint("userID")
+	response, err := h.service.StartLibraryImport(ctx, userID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, response)
+}
+
+func (h *Handler) GetLibraryImport(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jobID, err := strconv.ParseUint(c.Param("jobID"), 10, 64)
+	if err != nil || jobID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid job id"})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetLibraryImport(ctx, userID, uint(jobID))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,180 @@
+package tracks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	tracksSvc "github.com/xprasetio/go-spotify/internal/service/tracks"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_StartLibraryImport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	job := trackactivities.ImportJobResponse{
+		ID:        1,
+		Status:    trackactivities.ImportStatusPending,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name               string
+		emailVerified      bool
+		expectedStatusCode int
+		expectedBody       trackactivities.ImportJobResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			emailVerified:      true,
+			expectedStatusCode: 202,
+			expectedBody:       job,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().StartLibraryImport(gomock.Any(), uint(1)).Return(&job, nil)
+			},
+		},
+		{
+			name:               "failed: spotify account not linked",
+			emailVerified:      true,
+			expectedStatusCode: 409,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().StartLibraryImport(gomock.Any(), uint(1)).Return(nil, spotifyRepo.ErrNotLinked)
+			},
+		},
+		{
+			name:               "failed: email not verified",
+			emailVerified:      false,
+			expectedStatusCode: 403,
+			wantErr:            true,
+			mockFn:             func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodPost, `/tracks/import`, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: tt.emailVerified}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := trackactivities.ImportJobResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestHandler_GetLibraryImport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	job := trackactivities.ImportJobResponse{
+		ID:        1,
+		Status:    trackactivities.ImportStatusRunning,
+		Processed: 50,
+		Total:     120,
+		Imported:  48,
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name               string
+		jobID              string
+		expectedStatusCode int
+		expectedBody       trackactivities.ImportJobResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			jobID:              "1",
+			expectedStatusCode: 200,
+			expectedBody:       job,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().GetLibraryImport(gomock.Any(), uint(1), uint(1)).Return(&job, nil)
+			},
+		},
+		{
+			name:               "failed: not found",
+			jobID:              "2",
+			expectedStatusCode: 404,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetLibraryImport(gomock.Any(), uint(1), uint(2)).Return(nil, tracksSvc.ErrImportJobNotFound)
+			},
+		},
+		{
+			name:               "failed: invalid job id",
+			jobID:              "abc",
+			expectedStatusCode: 400,
+			wantErr:            true,
+			mockFn:             func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, `/tracks/import/`+tt.jobID, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := trackactivities.ImportJobResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
@@ -1,29 +1,137 @@
 package tracks
 
 import (
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
 )
 
+const (
+	maxRecommendationSeeds     = 5
+	defaultRecommendationLimit = 10
+	// maxRecommendationLimit is spotify's cap on recommendations per request.
+	maxRecommendationLimit = 100
+)
+
+// tunableAttribute is a spotify tunable track attribute. It is read from the
+// query as target<Name>, min<Name> and max<Name>, e.g. targetEnergy.
+type tunableAttribute struct {
+	name     string
+	spotify  string
+	minValue float64
+	maxValue float64
+}
+
+var tunableAttributes = []tunableAttribute{
+	{name: "Acousticness", spotify: "acousticness", minValue: 0, maxValue: 1},
+	{name: "Danceability", spotify: "danceability", minValue: 0, maxValue: 1},
+	{name: "Energy", spotify: "energy", minValue: 0, maxValue: 1},
+	{name: "Instrumentalness", spotify: "instrumentalness", minValue: 0, maxValue: 1},
+	{name: "Liveness", spotify: "liveness", minValue: 0, maxValue: 1},
+	{name: "Loudness", spotify: "loudness", minValue: math.Inf(-1), maxValue: math.Inf(1)},
+	{name: "Popularity", spotify: "popularity", minValue: 0, maxValue: 100},
+	{name: "Speechiness", spotify: "speechiness", minValue: 0, maxValue: 1},
+	{name: "Tempo", spotify: "tempo", minValue: 0, maxValue: math.Inf(1)},
+	{name: "Valence", spotify: "valence", minValue: 0, maxValue: 1},
+}
+
 func (h *Handler) GetRecommendation(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	trackID := c.Query("trackID")
-	limitStr := c.Query("limit")
-	limit, err := strconv.Atoi(limitStr)
+	request, err := parseRecommendationRequest(c)
 	if err != nil {
-		limit = 10
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
 	userID := c.GetUint("userID")
-	response, err := h.service.GetRecommendation(ctx, userID, limit, trackID)
+	response, err := h.service.GetRecommendation(ctx, userID, request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, response)
 }
+
+func parseRecommendationRequest(c *gin.Context) (spotify.RecommendationRequest, error) {
+	limit := defaultRecommendationLimit
+	if raw := c.Query("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 || value > maxRecommendationLimit {
+			return spotify.RecommendationRequest{}, fmt.Errorf("invalid limit: must be a number between 1 and %d", maxRecommendationLimit)
+		}
+		limit = value
+	}
+
+	request := spotify.RecommendationRequest{
+		Limit:  limit,
+		Market: c.Query("market"),
+		// trackID is the single seed the endpoint used to take
+		SeedTracks:  append(splitQuery(c.Query("trackID")), splitQuery(c.Query("seedTracks"))...),
+		SeedArtists: splitQuery(c.Query("seedArtists")),
+		SeedGenres:  splitQuery(c.Query("seedGenres")),
+	}
+
+	// no seed at all asks for personalized recommendations
+	seeds := len(request.SeedTracks) + len(request.SeedArtists) + len(request.SeedGenres)
+	if seeds > maxRecommendationSeeds {
+		return request, fmt.Errorf("recommendations take at most %d seeds across seedTracks, seedArtists and seedGenres, got %d", maxRecommendationSeeds, seeds)
+	}
+
+	for _, attribute := range tunableAttributes {
+		values := make(map[string]float64)
+		for _, prefix := range []string{"target", "min", "max"} {
+			key := prefix + attribute.name
+			raw := c.Query(key)
+			if raw == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(raw, 64)
+			if err != nil || math.IsNaN(value) || value < attribute.minValue || value > attribute.maxValue {
+				return request, fmt.Errorf("invalid %s: %s", key, attribute.describe())
+			}
+			values[prefix] = value
+		}
+
+		minValue, hasMin := values["min"]
+		maxValue, hasMax := values["max"]
+		if hasMin && hasMax && minValue > maxValue {
+			return request, fmt.Errorf("invalid %s: min%s is greater than max%s", attribute.spotify, attribute.name, attribute.name)
+		}
+
+		for prefix, value := range values {
+			if request.Tunables == nil {
+				request.Tunables = make(map[string]float64)
+			}
+			request.Tunables[prefix+"_"+attribute.spotify] = value
+		}
+	}
+	return request, nil
+}
+
+func (a tunableAttribute) describe() string {
+	switch {
+	case math.IsInf(a.minValue, -1):
+		return "must be a number"
+	case math.IsInf(a.maxValue, 1):
+		return fmt.Sprintf("must be a number of at least %g", a.minValue)
+	default:
+		return fmt.Sprintf("must be a number between %g and %g", a.minValue, a.maxValue)
+	}
+}
+
+func splitQuery(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -55,7 +55,7 @@ func TestHandler_GetRecommendation(t *testing.T) {
 			},
 			wantErr: false,
 			mockFn: func() {
-				mockSvc.EXPECT().GetRecommendation(gomock.Any(), uint(1), 10, "trackID").Return(&spotify.RecommendationResponse{
+				mockSvc.EXPECT().GetRecommendation(gomock.Any(), uint(1), spotify.RecommendationRequest{Limit: 10, SeedTracks: []string{"trackID"}}).Return(&spotify.RecommendationResponse{
 					Items: []spotify.SpotifyTrackObject{
 						{
 							AlbumType:        "album",
@@ -84,11 +84,11 @@ func TestHandler_GetRecommendation(t *testing.T) {
 
 		{
 			name:               "failed",
-			expectedStatusCode: http.StatusBadRequest,
+			expectedStatusCode: http.StatusInternalServerError,
 			expectedBody:       nil,
 			wantErr:            true,
 			mockFn: func() {
-				mockSvc.EXPECT().GetRecommendation(gomock.Any(), uint(1), 10, "trackID").Return(nil, assert.AnError)
+				mockSvc.EXPECT().GetRecommendation(gomock.Any(), uint(1), spotify.RecommendationRequest{Limit: 10, SeedTracks: []string{"trackID"}}).Return(nil, assert.AnError)
 			},
 		},
 	}
@@ -108,9 +108,9 @@ func TestHandler_GetRecommendation(t *testing.T) {
 
 			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
 			assert.NoError(t, err)
-			token, err := jwt.CreateToken(1, "username", "")
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
 			assert.NoError(t, err)
-			req.Header.Set("Authorization", token)
+			req.Header.Set("Authorization", "Bearer "+token)
 
 			h.ServeHTTP(w, req)
 
@@ -129,3 +129,113 @@ func TestHandler_GetRecommendation(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetRecommendation_params(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	tests := []struct {
+		name               string
+		endpoint           string
+		expectedStatusCode int
+		expectedError      string
+		mockFn             func()
+	}{
+		{
+			name:               "success: combined seeds and tunables",
+			endpoint:           `/tracks/recommendations?limit=5&market=US&seedTracks=trackA,trackB&seedArtists=artistA&seedGenres=rock&targetEnergy=0.8&minTempo=100&maxTempo=140`,
+			expectedStatusCode: http.StatusOK,
+			mockFn: func() {
+				mockSvc.EXPECT().GetRecommendation(gomock.Any(), uint(1), spotify.RecommendationRequest{
+					Limit:       5,
+					Market:      "US",
+					SeedTracks:  []string{"trackA", "trackB"},
+					SeedArtists: []string{"artistA"},
+					SeedGenres:  []string{"rock"},
+					Tunables: map[string]float64{
+						"target_energy": 0.8,
+						"min_tempo":     100,
+						"max_tempo":     140,
+					},
+				}).Return(&spotify.RecommendationResponse{}, nil)
+			},
+		},
+		{
+			name:               "success: personalized without seed",
+			endpoint:           `/tracks/recommendations?limit=10`,
+			expectedStatusCode: http.StatusOK,
+			mockFn: func() {
+				mockSvc.EXPECT().GetRecommendation(gomock.Any(), uint(1), spotify.RecommendationRequest{Limit: 10}).
+					Return(&spotify.RecommendationResponse{}, nil)
+			},
+		},
+		{
+			name:               "failed: limit below one",
+			endpoint:           `/tracks/recommendations?trackID=trackA&limit=-1`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid limit: must be a number between 1 and 100",
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: limit above spotify's cap",
+			endpoint:           `/tracks/recommendations?limit=101`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid limit: must be a number between 1 and 100",
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: too many seeds",
+			endpoint:           `/tracks/recommendations?seedTracks=a,b,c&seedArtists=d,e&seedGenres=rock`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "recommendations take at most 5 seeds across seedTracks, seedArtists and seedGenres, got 6",
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: tunable out of range",
+			endpoint:           `/tracks/recommendations?trackID=trackA&targetValence=1.5`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid targetValence: must be a number between 0 and 1",
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: min greater than max",
+			endpoint:           `/tracks/recommendations?trackID=trackA&minTempo=150&maxTempo=90`,
+			expectedStatusCode: http.StatusBadRequest,
+			expectedError:      "invalid tempo: minTempo is greater than maxTempo",
+			mockFn:             func() {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, tt.endpoint, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if tt.expectedError != "" {
+				response := map[string]string{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedError, response["error"])
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ func (h *Handler) Search(c *gin.Context) {
 	query := c.Query("query")
 	pageSizeStr := c.Query("pageSize")
 	pageIndexStr := c.Query("pageIndex")
+	market := c.Query("market")
 
 	pageSize, err := strconv.Atoi(pageSizeStr)
 	if err != nil {
@@ -25,11 +26,9 @@ func (h *Handler) Search(c *gin.Context) {
 	}
 
 	userID := c.GetUint("userID")
-	response, err := h.service.Search(ctx, query, pageSize, pageIndex, userID)
+	response, err := h.service.Search(ctx, query, pageSize, pageIndex, market, userID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error": err.Error(),
-		})
+		writeError(c, err)
 		return
 	}
 	c.JSON(http.StatusOK, response)
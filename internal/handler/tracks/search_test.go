@@ -2,6 +2,7 @@ package tracks
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +10,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
 	"github.com/xprasetio/go-spotify/pkg/jwt"
 	"go.uber.org/mock/gomock"
 )
@@ -58,7 +60,7 @@ func TestHandler_Search(t *testing.T) {
 			},
 			wantErr: false,
 			mockFn: func() {
-				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, uint(1)).Return(&spotify.SearchResponse{
+				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, "ID", uint(1)).Return(&spotify.SearchResponse{
 					Limit:  10,
 					Offset: 0,
 					Items: []spotify.SpotifyTrackObject{
@@ -90,11 +92,38 @@ func TestHandler_Search(t *testing.T) {
 
 		{
 			name:               "failed",
-			expectedStatusCode: 400,
+			expectedStatusCode: 500,
 			expectedBody:       spotify.SearchResponse{},
 			wantErr:            true,
 			mockFn: func() {
-				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, uint(1)).Return(nil, assert.AnError)
+				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, "ID", uint(1)).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:               "failed: rate limited",
+			expectedStatusCode: 429,
+			expectedBody:       spotify.SearchResponse{},
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, "ID", uint(1)).Return(nil, &spotifyRepo.APIError{StatusCode: 429})
+			},
+		},
+		{
+			name:               "failed: upstream error",
+			expectedStatusCode: 502,
+			expectedBody:       spotify.SearchResponse{},
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, "ID", uint(1)).Return(nil, fmt.Errorf("search: %w", spotifyRepo.ErrUpstream))
+			},
+		},
+		{
+			name:               "failed: spotify unavailable",
+			expectedStatusCode: 503,
+			expectedBody:       spotify.SearchResponse{},
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().Search(gomock.Any(), "bohemian rhapsody", 10, 1, "ID", uint(1)).Return(nil, spotifyRepo.ErrUnavailable)
 			},
 		},
 	}
@@ -110,13 +139,13 @@ func TestHandler_Search(t *testing.T) {
 			h.RegisterRoute()
 			w := httptest.NewRecorder()
 
-			endpoint := `/tracks/search?query=bohemian+rhapsody&pageSize=10&pageIndex=1`
+			endpoint := `/tracks/search?query=bohemian+rhapsody&pageSize=10&pageIndex=1&market=ID`
 
 			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
 			assert.NoError(t, err)
-			token, err := jwt.CreateToken(1, "username", "")
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
 			assert.NoError(t, err)
-			req.Header.Set("Authorization", token)
+			req.Header.Set("Authorization", "Bearer "+token)
 
 			h.ServeHTTP(w, req)
 
@@ -2,6 +2,7 @@ package tracks
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
@@ -24,3 +25,49 @@ func (h *Handler) UpsertTrackActivities(c *gin.Context) {
 	}
 	c.Status(http.StatusOK)
 }
+
+const (
+	defaultTrackActivitiesPageSize = 20
+	maxTrackActivitiesPageSize     = 50
+)
+
+func (h *Handler) GetLikedTracks(c *gin.Context) {
+	h.listTrackActivities(c, true)
+}
+
+func (h *Handler) GetDislikedTracks(c *gin.Context) {
+	h.listTrackActivities(c, false)
+}
+
+func (h *Handler) listTrackActivities(c *gin.Context, isLiked bool) {
+	ctx := c.Request.Context()
+
+	pageSize, err := strconv.Atoi(c.Query("pageSize"))
+	if err != nil || pageSize <= 0 {
+		pageSize = defaultTrackActivitiesPageSize
+	}
+	pageSize = min(pageSize, maxTrackActivitiesPageSize)
+
+	var ascending bool
+	switch c.DefaultQuery("sort", "desc") {
+	case "desc":
+	case "asc":
+		ascending = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be asc or desc"})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.ListTrackActivities(ctx, userID, trackactivities.ListRequest{
+		IsLiked:   isLiked,
+		PageSize:  pageSize,
+		Cursor:    c.Query("cursor"),
+		Ascending: ascending,
+	})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	tracksSvc "github.com/xprasetio/go-spotify/internal/service/tracks"
 	"github.com/xprasetio/go-spotify/pkg/jwt"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -72,9 +74,9 @@ func TestHandler_UpsertTrackActivities(t *testing.T) {
 
 			req, err := http.NewRequest(http.MethodPost, endpoint, io.NopCloser(bytes.NewBuffer(payloadBytes)))
 			assert.NoError(t, err)
-			token, err := jwt.CreateToken(1, "username", "")
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
 			assert.NoError(t, err)
-			req.Header.Set("Authorization", token)
+			req.Header.Set("Authorization", "Bearer "+token)
 
 			h.ServeHTTP(w, req)
 
@@ -82,3 +84,124 @@ func TestHandler_UpsertTrackActivities(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_GetLikedTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	isLikedFalse := false
+	tests := []struct {
+		name               string
+		endpoint           string
+		expectedStatusCode int
+		expectedBody       spotify.TrackActivitiesResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success: liked",
+			endpoint:           `/tracks/liked?pageSize=2&cursor=abc`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: spotify.TrackActivitiesResponse{
+				Items:      []spotify.TrackActivityItem{},
+				NextCursor: "def",
+			},
+			mockFn: func() {
+				mockSvc.EXPECT().ListTrackActivities(gomock.Any(), uint(1), trackactivities.ListRequest{
+					IsLiked:  true,
+					PageSize: 2,
+					Cursor:   "abc",
+				}).Return(&spotify.TrackActivitiesResponse{
+					Items:      []spotify.TrackActivityItem{},
+					NextCursor: "def",
+				}, nil)
+			},
+		},
+		{
+			name:               "success: disliked oldest first, page size capped",
+			endpoint:           `/tracks/disliked?pageSize=500&sort=asc`,
+			expectedStatusCode: http.StatusOK,
+			expectedBody: spotify.TrackActivitiesResponse{
+				Items: []spotify.TrackActivityItem{
+					{SpotifyTrackObject: spotify.SpotifyTrackObject{ID: "trackA", IsLiked: &isLikedFalse, NotFound: true}},
+				},
+			},
+			mockFn: func() {
+				mockSvc.EXPECT().ListTrackActivities(gomock.Any(), uint(1), trackactivities.ListRequest{
+					IsLiked:   false,
+					PageSize:  50,
+					Ascending: true,
+				}).Return(&spotify.TrackActivitiesResponse{
+					Items: []spotify.TrackActivityItem{
+						{SpotifyTrackObject: spotify.SpotifyTrackObject{ID: "trackA", IsLiked: &isLikedFalse, NotFound: true}},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:               "failed: invalid sort",
+			endpoint:           `/tracks/liked?sort=random`,
+			expectedStatusCode: http.StatusBadRequest,
+			wantErr:            true,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: invalid cursor",
+			endpoint:           `/tracks/liked?cursor=abc`,
+			expectedStatusCode: http.StatusBadRequest,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().ListTrackActivities(gomock.Any(), uint(1), trackactivities.ListRequest{
+					IsLiked:  true,
+					PageSize: 20,
+					Cursor:   "abc",
+				}).Return(nil, tracksSvc.ErrInvalidCursor)
+			},
+		},
+		{
+			name:               "failed: service error",
+			endpoint:           `/tracks/liked`,
+			expectedStatusCode: http.StatusInternalServerError,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().ListTrackActivities(gomock.Any(), uint(1), trackactivities.ListRequest{
+					IsLiked:  true,
+					PageSize: 20,
+				}).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, tt.endpoint, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := spotify.TrackActivitiesResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
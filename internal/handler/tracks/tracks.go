@@ -0,0 +1,40 @@
+package tracks
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+)
+
+func (h *Handler) GetTrack(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	trackID := c.Param("id")
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetTrack(ctx, userID, trackID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) GetTracks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req spotify.TracksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetUint("userID")
+	response, err := h.service.GetTracks(ctx, userID, req.IDs)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,195 @@
+package tracks
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHandler_GetTrack(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	track := spotify.SpotifyTrackObject{
+		AlbumType:        "album",
+		AlbumTotalTracks: 22,
+		AlbumImagesURL:   []string{"https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b"},
+		AlbumName:        "Bohemian Rhapsody (The Original Soundtrack)",
+		ArtistsName:      []string{"Queen"},
+		Explicit:         false,
+		ID:               "3z8h0TU7ReDPLIbEnYhWZb",
+		Name:             "Bohemian Rhapsody",
+	}
+
+	tests := []struct {
+		name               string
+		expectedStatusCode int
+		expectedBody       spotify.SpotifyTrackObject
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			expectedStatusCode: 200,
+			expectedBody:       track,
+			wantErr:            false,
+			mockFn: func() {
+				mockSvc.EXPECT().GetTrack(gomock.Any(), uint(1), "3z8h0TU7ReDPLIbEnYhWZb").Return(&track, nil)
+			},
+		},
+		{
+			name:               "failed: not found",
+			expectedStatusCode: 404,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetTrack(gomock.Any(), uint(1), "3z8h0TU7ReDPLIbEnYhWZb").Return(nil, &spotifyRepo.APIError{StatusCode: 404})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			endpoint := `/tracks/3z8h0TU7ReDPLIbEnYhWZb`
+
+			req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := spotify.SpotifyTrackObject{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
+
+func TestHandler_GetTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSvc := NewMockservice(mockCtrl)
+
+	tests := []struct {
+		name               string
+		body               string
+		expectedStatusCode int
+		expectedBody       spotify.TracksResponse
+		wantErr            bool
+		mockFn             func()
+	}{
+		{
+			name:               "success",
+			body:               `{"ids":["3z8h0TU7ReDPLIbEnYhWZb","unknown"]}`,
+			expectedStatusCode: 200,
+			expectedBody: spotify.TracksResponse{
+				Items: []spotify.SpotifyTrackObject{
+					{
+						AlbumImagesURL: []string{},
+						ArtistsName:    []string{"Queen"},
+						ID:             "3z8h0TU7ReDPLIbEnYhWZb",
+						Name:           "Bohemian Rhapsody",
+					},
+					{
+						ID:       "unknown",
+						NotFound: true,
+					},
+				},
+			},
+			wantErr: false,
+			mockFn: func() {
+				mockSvc.EXPECT().GetTracks(gomock.Any(), uint(1), []string{"3z8h0TU7ReDPLIbEnYhWZb", "unknown"}).Return(&spotify.TracksResponse{
+					Items: []spotify.SpotifyTrackObject{
+						{
+							AlbumImagesURL: []string{},
+							ArtistsName:    []string{"Queen"},
+							ID:             "3z8h0TU7ReDPLIbEnYhWZb",
+							Name:           "Bohemian Rhapsody",
+						},
+						{
+							ID:       "unknown",
+							NotFound: true,
+						},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:               "failed: empty ids",
+			body:               `{"ids":[]}`,
+			expectedStatusCode: 400,
+			wantErr:            true,
+			mockFn:             func() {},
+		},
+		{
+			name:               "failed: upstream error",
+			body:               `{"ids":["3z8h0TU7ReDPLIbEnYhWZb"]}`,
+			expectedStatusCode: 502,
+			wantErr:            true,
+			mockFn: func() {
+				mockSvc.EXPECT().GetTracks(gomock.Any(), uint(1), []string{"3z8h0TU7ReDPLIbEnYhWZb"}).Return(nil, spotifyRepo.ErrUpstream)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			api := gin.New()
+
+			h := &Handler{
+				Engine:  api,
+				service: mockSvc,
+			}
+			h.RegisterRoute()
+			w := httptest.NewRecorder()
+
+			endpoint := `/tracks/batch`
+
+			req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			h.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+
+			if !tt.wantErr {
+				response := spotify.TracksResponse{}
+				err = json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+
+				assert.Equal(t, tt.expectedBody, response)
+			}
+		})
+	}
+}
@@ -8,26 +8,170 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/xprasetio/go-spotify/internal/configs"
 	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/revocation"
 )
 
+var (
+	revocationStore revocation.Store
+	keySet          *jwt.KeySet
+
+	errMissingToken  = errors.New("missing token")
+	errInvalidScheme = errors.New("authorization header must use the Bearer scheme")
+	errTokenRevoked  = errors.New("token is revoked")
+)
+
+// SetRevocationStore makes the auth middlewares reject revoked tokens and
+// tokens issued before the user's last logout-all. Without a store only the
+// signature and expiry are checked.
+func SetRevocationStore(store revocation.Store) {
+	revocationStore = store
+}
+
+// SetKeySet makes the auth middlewares verify tokens against keys.
+func SetKeySet(keys *jwt.KeySet) {
+	keySet = keys
+}
+
+func verificationKeys() *jwt.KeySet {
+	if keySet != nil {
+		return keySet
+	}
+	return jwt.NewHMACKeySet(configs.Get().Service.SecretKey)
+}
+
 func AuthMiddleware() gin.HandlerFunc {
-	secretKey := configs.Get().Service.SecretKey
+	keys := verificationKeys()
 	return func(c *gin.Context) {
-		header := c.Request.Header.Get("Authorization")
+		header := strings.TrimSpace(c.Request.Header.Get("Authorization"))
+		if header == "" {
+			abortUnauthorized(c, errMissingToken)
+			return
+		}
+
+		if err := authenticate(c, header, keys); err != nil {
+			abort(c, err)
+			return
+		}
+		c.Next()
+	}
+}
 
-		header = strings.TrimSpace(header)
+// OptionalAuthMiddleware authenticates the request when it carries a token
+// and lets it through anonymously otherwise.
+func OptionalAuthMiddleware() gin.HandlerFunc {
+	keys := verificationKeys()
+	return func(c *gin.Context) {
+		header := strings.TrimSpace(c.Request.Header.Get("Authorization"))
 		if header == "" {
-			c.AbortWithError(http.StatusUnauthorized, errors.New("missing token"))
+			c.Next()
+			return
+		}
+
+		if err := authenticate(c, header, keys); err != nil {
+			abort(c, err)
 			return
 		}
+		c.Next()
+	}
+}
+
+func authenticate(c *gin.Context, header string, keys *jwt.KeySet) error {
+	tokenStr, err := bearerToken(header)
+	if err != nil {
+		return err
+	}
 
-		userID, username, err := jwt.ValidateToken(header, secretKey)
+	claims, err := keys.ValidateToken(tokenStr)
+	if err != nil {
+		return err
+	}
+
+	if revocationStore != nil {
+		ctx := c.Request.Context()
+		revoked, err := revocationStore.IsRevoked(ctx, claims.ID)
 		if err != nil {
-			c.AbortWithError(http.StatusUnauthorized, err)
+			return err
+		}
+		if revoked {
+			return errTokenRevoked
+		}
+
+		version, err := revocationStore.TokenVersion(ctx, claims.UserID)
+		if err != nil {
+			return err
+		}
+		if claims.TokenVersion < version {
+			return errTokenRevoked
+		}
+	}
+
+	c.Set("userID", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("tokenID", claims.ID)
+	c.Set("tokenExpiresAt", claims.ExpiresAt)
+	c.Set("emailVerified", claims.EmailVerified)
+	return nil
+}
+
+// RequireVerifiedEmail refuses users who logged in before verifying their
+// email, it goes after AuthMiddleware on routes that change data.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool("emailVerified") {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "email is not verified"})
 			return
 		}
-		c.Set("userID", userID)
-		c.Set("username", username)
 		c.Next()
 	}
 }
+
+// bearerToken extracts the token of an "Authorization: Bearer <token>"
+// header, the scheme is case insensitive.
+func bearerToken(header string) (string, error) {
+	scheme, token, _ := strings.Cut(header, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return "", errInvalidScheme
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", errMissingToken
+	}
+	return token, nil
+}
+
+var unauthorizedErrors = []error{
+	errMissingToken,
+	errInvalidScheme,
+	errTokenRevoked,
+	jwt.ErrTokenMalformed,
+	jwt.ErrTokenUnverifiable,
+	jwt.ErrTokenAlgorithm,
+	jwt.ErrTokenSignatureInvalid,
+	jwt.ErrTokenExpired,
+	jwt.ErrTokenNotValidYet,
+	jwt.ErrTokenInvalidIssuer,
+	jwt.ErrTokenInvalidAudience,
+	jwt.ErrTokenInvalidClaims,
+}
+
+// abort answers 401 with the kind of the token error, anything else, e.g.
+// the revocation store being down, is a 500.
+func abort(c *gin.Context, err error) {
+	for _, unauthorizedErr := range unauthorizedErrors {
+		if errors.Is(err, unauthorizedErr) {
+			abortUnauthorized(c, unauthorizedErr)
+			return
+		}
+	}
+	c.Error(err)
+	c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "failed to authenticate"})
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	if err == errMissingToken {
+		c.Header("WWW-Authenticate", "Bearer")
+	} else {
+		c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+}
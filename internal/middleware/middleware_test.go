@@ -0,0 +1,218 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/revocation"
+)
+
+func TestAuthMiddleware_revocation(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name               string
+		tokenVersion       uint
+		prepare            func(store *revocation.Memory, claims *jwt.Claims)
+		expectedStatusCode int
+	}{
+		{
+			name:               "valid token",
+			prepare:            func(store *revocation.Memory, claims *jwt.Claims) {},
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name: "revoked token",
+			prepare: func(store *revocation.Memory, claims *jwt.Claims) {
+				assert.NoError(t, store.Revoke(ctx, claims.ID, claims.ExpiresAt))
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name: "token issued before logout-all",
+			prepare: func(store *revocation.Memory, claims *jwt.Claims) {
+				_, err := store.BumpTokenVersion(ctx, claims.UserID)
+				assert.NoError(t, err)
+			},
+			expectedStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "token issued after logout-all",
+			tokenVersion: 1,
+			prepare: func(store *revocation.Memory, claims *jwt.Claims) {
+				_, err := store.BumpTokenVersion(ctx, claims.UserID)
+				assert.NoError(t, err)
+			},
+			expectedStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := revocation.NewMemory()
+			SetRevocationStore(store)
+			t.Cleanup(func() { SetRevocationStore(nil) })
+
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", TokenVersion: tt.tokenVersion}, "")
+			assert.NoError(t, err)
+			claims, err := jwt.ValidateToken(token, "")
+			assert.NoError(t, err)
+			tt.prepare(store, claims)
+
+			api := gin.New()
+			api.GET("/", AuthMiddleware(), func(c *gin.Context) {
+				assert.Equal(t, claims.ID, c.GetString("tokenID"))
+				assert.Equal(t, claims.ExpiresAt, c.GetTime("tokenExpiresAt"))
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			api.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_errors(t *testing.T) {
+	token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: true}, "")
+	assert.NoError(t, err)
+	expiredToken, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, gojwt.MapClaims{
+		"id":  1,
+		"jti": "jti",
+		"exp": time.Now().Add(-time.Minute).Unix(),
+	}).SignedString([]byte(""))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		header             string
+		expectedStatusCode int
+		expectedError      string
+	}{
+		{
+			name:               "bearer token",
+			header:             "Bearer " + token,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "scheme is case insensitive",
+			header:             "bearer " + token,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			name:               "missing token",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "missing token",
+		},
+		{
+			name:               "raw token",
+			header:             token,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "authorization header must use the Bearer scheme",
+		},
+		{
+			name:               "bearer without token",
+			header:             "Bearer  ",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "missing token",
+		},
+		{
+			name:               "malformed",
+			header:             "Bearer not-a-token",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "token is malformed",
+		},
+		{
+			name:               "expired",
+			header:             "Bearer " + expiredToken,
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "token is expired",
+		},
+		{
+			name:               "bad signature",
+			header:             "Bearer " + token[:len(token)-4] + "AAAA",
+			expectedStatusCode: http.StatusUnauthorized,
+			expectedError:      "token signature is invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := gin.New()
+			api.GET("/", AuthMiddleware(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/", nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", tt.header)
+			api.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedError != "" {
+				assert.JSONEq(t, `{"error":"`+tt.expectedError+`"}`, w.Body.String())
+				assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	tests := []struct {
+		name               string
+		emailVerified      bool
+		expectedStatusCode int
+	}{
+		{name: "verified", emailVerified: true, expectedStatusCode: http.StatusOK},
+		{name: "unverified", emailVerified: false, expectedStatusCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := jwt.CreateToken(jwt.Claims{UserID: 1, Username: "username", EmailVerified: tt.emailVerified}, "")
+			assert.NoError(t, err)
+
+			api := gin.New()
+			api.POST("/", AuthMiddleware(), RequireVerifiedEmail(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, "/", nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+			api.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+		})
+	}
+}
+
+func TestOptionalAuthMiddleware(t *testing.T) {
+	api := gin.New()
+	api.GET("/", OptionalAuthMiddleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetString("tokenID"))
+	})
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	assert.NoError(t, err)
+	api.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = httptest.NewRecorder()
+	req.Header.Set("Authorization", "Bearer not-a-token")
+	api.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+}
@@ -0,0 +1,51 @@
+package memberships
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type (
+	// UserTOTP is the authenticator app of a user. Two-factor login is on
+	// once it is confirmed with a first code.
+	UserTOTP struct {
+		gorm.Model
+		UserID uint `gorm:"unique;not null"`
+		// Secret is sealed with the configured encryption keys.
+		Secret      string `gorm:"not null"`
+		ConfirmedAt *time.Time
+		// LastStep is the time step of the last accepted code, codes of it
+		// and earlier steps are rejected so they can't be replayed.
+		LastStep int64 `gorm:"not null;default:0"`
+	}
+
+	// RecoveryCode stands in for a TOTP code once, only its hash is stored.
+	RecoveryCode struct {
+		gorm.Model
+		UserID   uint   `gorm:"not null;index"`
+		CodeHash string `gorm:"not null"`
+		UsedAt   *time.Time
+	}
+)
+
+type (
+	ConfirmTOTPRequest struct {
+		Code string `json:"code" binding:"required"`
+	}
+
+	LoginMFARequest struct {
+		MFAToken string `json:"mfaToken" binding:"required"`
+		// Code is a TOTP code or a recovery code.
+		Code     string `json:"code" binding:"required"`
+		ClientIP string `json:"-"`
+	}
+)
+
+type (
+	EnrollTOTPResponse struct {
+		Secret        string   `json:"secret"`
+		OTPAuthURI    string   `json:"otpauthURI"`
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+)
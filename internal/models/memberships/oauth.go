@@ -0,0 +1,56 @@
+package memberships
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type (
+	// OAuthState is a pending log in with spotify, created on start and used
+	// up by the callback. Only the hash of the state is stored.
+	OAuthState struct {
+		gorm.Model
+		StateHash    string `gorm:"unique;not null"`
+		CodeVerifier string `gorm:"not null"`
+		// UserID is the user linking their spotify account, nil for a log in.
+		UserID    *uint
+		ExpiresAt time.Time `gorm:"not null"`
+		UsedAt    *time.Time
+	}
+
+	// SpotifyAccount links a user to their spotify account.
+	SpotifyAccount struct {
+		gorm.Model
+		UserID        uint   `gorm:"unique;not null"`
+		SpotifyUserID string `gorm:"unique;not null"`
+		// RefreshToken is sealed with the configured encryption keys.
+		RefreshToken string `gorm:"not null"`
+		Scope        string
+	}
+)
+
+type (
+	SpotifyCallbackRequest struct {
+		Code  string `form:"code"`
+		State string `form:"state" binding:"required"`
+		// Error is set instead of Code when the user declined.
+		Error string `form:"error"`
+		// CookieState is the state remembered by the browser on start, it
+		// has to match State.
+		CookieState string `form:"-"`
+	}
+)
+
+type (
+	StartSpotifyOAuthResponse struct {
+		AuthorizeURL string `json:"authorizeURL"`
+		// State is also set as a cookie, the callback only accepts it from
+		// the browser that started the flow.
+		State string `json:"-"`
+	}
+)
+
+func (OAuthState) TableName() string {
+	return "oauth_states"
+}
@@ -0,0 +1,29 @@
+package memberships
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type (
+	// PasswordReset is one emailed reset token, only its hash is stored.
+	PasswordReset struct {
+		gorm.Model
+		UserID    uint      `gorm:"not null;index"`
+		TokenHash string    `gorm:"unique;not null"`
+		ExpiresAt time.Time `gorm:"not null"`
+		UsedAt    *time.Time
+	}
+)
+
+type (
+	ForgotPasswordRequest struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	ResetPasswordRequest struct {
+		Token    string `json:"token" binding:"required"`
+		Password string `json:"password" binding:"required,min=8"`
+	}
+)
@@ -0,0 +1,35 @@
+package memberships
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type (
+	// Session is one refresh token. Every refresh rotates the token into a
+	// new session of the same family, so a family traces one login.
+	Session struct {
+		gorm.Model
+		UserID    uint      `gorm:"not null;index"`
+		FamilyID  string    `gorm:"not null;index"`
+		TokenHash string    `gorm:"unique;not null"`
+		ExpiresAt time.Time `gorm:"not null"`
+		RotatedAt *time.Time
+		RevokedAt *time.Time
+	}
+)
+
+type (
+	RefreshRequest struct {
+		RefreshToken string `json:"refreshToken" binding:"required"`
+	}
+
+	LogoutRequest struct {
+		RefreshToken string `json:"refreshToken" binding:"required"`
+		// AccessTokenID and AccessTokenExpiresAt identify the access token the
+		// request was authenticated with, if any, so it is revoked as well.
+		AccessTokenID        string    `json:"-"`
+		AccessTokenExpiresAt time.Time `json:"-"`
+	}
+)
@@ -1,6 +1,10 @@
 package memberships
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type (
 	User struct {
@@ -10,6 +14,8 @@ type (
 		Password  string `gorm:"not null"`
 		CreatedBy string `gorm:"not null"`
 		UpdatedBy string `gorm:"not null"`
+		// EmailVerifiedAt is nil until the user confirms their email.
+		EmailVerifiedAt *time.Time
 	}
 )
 
@@ -23,11 +29,46 @@ type (
 	LoginRequest struct {
 		Email    string `json:"email"`
 		Password string `json:"password"`
+		// ClientIP is the address the request came from, failed logins are
+		// throttled per address as well as per account.
+		ClientIP string `json:"-"`
+	}
+
+	VerifyEmailRequest struct {
+		Token string `json:"token" form:"token" binding:"required"`
+	}
+
+	ResendVerificationRequest struct {
+		Email string `json:"email" binding:"required,email"`
+	}
+
+	// UpdateProfileRequest changes only the fields that are set.
+	UpdateProfileRequest struct {
+		Email    *string `json:"email" binding:"omitempty,email"`
+		Username *string `json:"username" binding:"omitempty,min=1"`
+	}
+
+	ChangePasswordRequest struct {
+		CurrentPassword string `json:"currentPassword" binding:"required"`
+		NewPassword     string `json:"newPassword" binding:"required,min=8"`
 	}
 )
 
 type (
+	// LoginResponse carries either the tokens, or an MFAToken to exchange
+	// together with a second factor at /memberships/login/mfa.
 	LoginResponse struct {
-		AccessToken string `json:"accessToken"`
+		AccessToken  string `json:"accessToken,omitempty"`
+		RefreshToken string `json:"refreshToken,omitempty"`
+		MFARequired  bool   `json:"mfaRequired,omitempty"`
+		MFAToken     string `json:"mfaToken,omitempty"`
+	}
+
+	ProfileResponse struct {
+		ID            uint      `json:"id"`
+		Email         string    `json:"email"`
+		Username      string    `json:"username"`
+		EmailVerified bool      `json:"emailVerified"`
+		CreatedAt     time.Time `json:"createdAt"`
 	}
 )
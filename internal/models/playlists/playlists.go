@@ -0,0 +1,174 @@
+package playlists
+
+import (
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"gorm.io/gorm"
+)
+
+// MaxTracks is the most tracks a playlist holds, a playlist is always
+// returned whole.
+const MaxTracks = 500
+
+// MaxMembers is the most users a playlist is shared with, the owner aside.
+const MaxMembers = 50
+
+// Roles a user has on a playlist. The owner is the user who created it,
+// editors change the tracks and viewers only read.
+const (
+	RoleOwner  = "owner"
+	RoleEditor = "editor"
+	RoleViewer = "viewer"
+)
+
+var roleRanks = map[string]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+// RoleAllows tells whether role may do what needs the given role.
+func RoleAllows(role, needs string) bool {
+	return roleRanks[role] > 0 && roleRanks[role] >= roleRanks[needs]
+}
+
+type (
+	Playlist struct {
+		gorm.Model
+		UserID uint   `gorm:"not null;index"`
+		Name   string `gorm:"not null"`
+		// TrackCount is kept with every change of the tracks, positions of
+		// the tracks run from 0 to TrackCount-1 without gaps.
+		TrackCount int `gorm:"not null;default:0"`
+		// Version goes up with every change of the tracks. Track edits carry
+		// the version they were made on, an edit on an older version is
+		// refused instead of moving the wrong tracks.
+		Version int `gorm:"not null;default:1"`
+		// ShareSlug opens the playlist read-only to anyone with the link,
+		// nil while the playlist isn't shared.
+		ShareSlug *string `gorm:"uniqueIndex"`
+		CreatedBy string  `gorm:"not null"`
+		UpdatedBy string  `gorm:"not null"`
+	}
+
+	// PlaylistMember gives a user other than the owner a role on a playlist.
+	PlaylistMember struct {
+		ID         uint      `gorm:"primarykey"`
+		PlaylistID uint      `gorm:"not null;uniqueIndex:idx_playlist_members_playlist_user"`
+		UserID     uint      `gorm:"not null;uniqueIndex:idx_playlist_members_playlist_user;index"`
+		Role       string    `gorm:"not null"`
+		CreatedBy  string    `gorm:"not null"`
+		CreatedAt  time.Time `gorm:"not null"`
+		UpdatedAt  time.Time `gorm:"not null"`
+	}
+
+	// PlaylistWithRole is a playlist together with the role of the user it
+	// was listed for.
+	PlaylistWithRole struct {
+		Playlist `gorm:"embedded"`
+		Role     string
+	}
+
+	// PlaylistTrack is a track at a position of a playlist, the same track
+	// can be in a playlist more than once.
+	PlaylistTrack struct {
+		ID         uint      `gorm:"primarykey"`
+		PlaylistID uint      `gorm:"not null;index:idx_playlist_tracks_position,priority:1"`
+		Position   int       `gorm:"not null;index:idx_playlist_tracks_position,priority:2"`
+		SpotifyID  string    `gorm:"not null"`
+		AddedBy    string    `gorm:"not null"`
+		CreatedAt  time.Time `gorm:"not null"`
+	}
+)
+
+type (
+	PlaylistRequest struct {
+		Name string `json:"name" binding:"required,max=100"`
+	}
+
+	// AddTracksRequest inserts the tracks in order before Position, or at
+	// the end without one.
+	AddTracksRequest struct {
+		IDs      []string `json:"ids" binding:"required,min=1,max=100,dive,required"`
+		Position *int     `json:"position" binding:"omitempty,min=0"`
+		Version  int      `json:"version" binding:"required,min=1"`
+	}
+
+	RemoveTracksRequest struct {
+		Positions []int `json:"positions" binding:"required,min=1,max=100,dive,min=0"`
+		Version   int   `json:"version" binding:"required,min=1"`
+	}
+
+	// ReorderTracksRequest moves RangeLength tracks starting at RangeStart
+	// to before the track at InsertBefore, positions as before the move.
+	ReorderTracksRequest struct {
+		RangeStart   int `json:"rangeStart" binding:"min=0"`
+		RangeLength  int `json:"rangeLength" binding:"omitempty,min=1"`
+		InsertBefore int `json:"insertBefore" binding:"min=0"`
+		Version      int `json:"version" binding:"required,min=1"`
+	}
+
+	InviteMemberRequest struct {
+		Username string `json:"username" binding:"required"`
+		Role     string `json:"role" binding:"required,oneof=editor viewer"`
+	}
+)
+
+type (
+	PlaylistResponse struct {
+		ID         uint   `json:"id"`
+		Name       string `json:"name"`
+		TrackCount int    `json:"trackCount"`
+		Version    int    `json:"version"`
+		// Role is the role of the user asking, empty on a share link.
+		Role string `json:"role,omitempty"`
+		// ShareSlug is only shown to the owner.
+		ShareSlug string    `json:"shareSlug,omitempty"`
+		CreatedAt time.Time `json:"createdAt"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+
+	ListResponse struct {
+		Items []PlaylistResponse `json:"items"`
+	}
+
+	PlaylistDetailResponse struct {
+		PlaylistResponse
+		Tracks []PlaylistTrackItem `json:"tracks"`
+	}
+
+	PlaylistTrackItem struct {
+		spotify.SpotifyTrackObject
+		Position int       `json:"position"`
+		AddedAt  time.Time `json:"addedAt"`
+	}
+
+	MemberResponse struct {
+		UserID   uint   `json:"userID"`
+		Username string `json:"username"`
+		Role     string `json:"role"`
+	}
+
+	MembersResponse struct {
+		Items []MemberResponse `json:"items"`
+	}
+
+	ShareLinkResponse struct {
+		Slug string `json:"slug"`
+	}
+
+	// ImportResponse is the playlist an import created and the entries of
+	// the file it left out.
+	ImportResponse struct {
+		Playlist  PlaylistResponse `json:"playlist"`
+		Matched   int              `json:"matched"`
+		Unmatched []UnmatchedEntry `json:"unmatched"`
+	}
+
+	UnmatchedEntry struct {
+		Line   int    `json:"line"`
+		Entry  string `json:"entry"`
+		Reason string `json:"reason"`
+	}
+)
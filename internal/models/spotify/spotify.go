@@ -1,5 +1,7 @@
 package spotify
 
+import "time"
+
 type SearchResponse struct {
 	Limit  int                  `json:"limit"`
 	Offset int                  `json:"offset"`
@@ -13,17 +15,89 @@ type SpotifyTrackObject struct {
 	AlbumTotalTracks int      `json:"totalTracks"`
 	AlbumImagesURL   []string `json:"albumImagesURL"`
 	AlbumName        string   `json:"albumName"`
+	AlbumID          string   `json:"albumID"`
+	AlbumReleaseDate string   `json:"albumReleaseDate"`
 
 	// artist related fields
 	ArtistsName []string `json:"artistsName"`
+	ArtistsID   []string `json:"artistsID"`
 
 	// track related fields
-	Explicit bool   `json:"explicit"`
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	IsLiked  *bool  `json:"isLiked"`
+	Explicit    bool    `json:"explicit"`
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	DurationMs  int     `json:"durationMs"`
+	Popularity  int     `json:"popularity"`
+	ISRC        string  `json:"isrc"`
+	PreviewURL  *string `json:"previewURL"`
+	TrackNumber int     `json:"trackNumber"`
+	DiscNumber  int     `json:"discNumber"`
+	IsLiked     *bool   `json:"isLiked"`
+
+	// NotFound marks a requested id spotify has no track for
+	NotFound bool `json:"notFound,omitempty"`
+}
+
+type TracksRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1,max=500,dive,required"`
+}
+
+type TracksResponse struct {
+	Items []SpotifyTrackObject `json:"items"`
+}
+
+type RecommendationRequest struct {
+	Limit       int
+	Market      string
+	SeedTracks  []string
+	SeedArtists []string
+	SeedGenres  []string
+	// Tunables is keyed by spotify's query name, e.g. target_energy
+	Tunables map[string]float64
 }
 
 type RecommendationResponse struct {
 	Items []SpotifyTrackObject `json:"items"`
 }
+
+type ArtistResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Genres     []string `json:"genres"`
+	Followers  int      `json:"followers"`
+	Popularity int      `json:"popularity"`
+	ImagesURL  []string `json:"imagesURL"`
+}
+
+type ArtistTopTracksResponse struct {
+	Items []SpotifyTrackObject `json:"items"`
+}
+
+type AlbumResponse struct {
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	AlbumType   string               `json:"albumType"`
+	Label       string               `json:"label"`
+	ReleaseDate string               `json:"releaseDate"`
+	TotalTracks int                  `json:"totalTracks"`
+	ImagesURL   []string             `json:"imagesURL"`
+	ArtistsName []string             `json:"artistsName"`
+	Items       []SpotifyTrackObject `json:"items"`
+}
+
+type AlbumTracksResponse struct {
+	Limit  int                  `json:"limit"`
+	Offset int                  `json:"offset"`
+	Items  []SpotifyTrackObject `json:"items"`
+	Total  int                  `json:"total"`
+}
+
+type TrackActivityItem struct {
+	SpotifyTrackObject
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type TrackActivitiesResponse struct {
+	Items      []TrackActivityItem `json:"items"`
+	NextCursor string              `json:"nextCursor,omitempty"`
+}
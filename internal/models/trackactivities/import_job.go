@@ -0,0 +1,42 @@
+package trackactivities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+type (
+	ImportJob struct {
+		gorm.Model
+		UserID     uint   `gorm:"not null;index"`
+		Status     string `gorm:"not null"`
+		Offset     int    `gorm:"not null;default:0"` // saved tracks done so far
+		Total      int    `gorm:"not null;default:0"`
+		Imported   int    `gorm:"not null;default:0"` // tracks that became likes
+		Attempt    int    `gorm:"not null;default:0"` // bumped on every claim
+		LeaseUntil *time.Time
+		Error      string
+		FinishedAt *time.Time
+	}
+)
+
+type (
+	ImportJobResponse struct {
+		ID         uint       `json:"id"`
+		Status     string     `json:"status"`
+		Processed  int        `json:"processed"`
+		Total      int        `json:"total"`
+		Imported   int        `json:"imported"`
+		Error      string     `json:"error,omitempty"`
+		CreatedAt  time.Time  `json:"createdAt"`
+		FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	}
+)
@@ -0,0 +1,29 @@
+package trackactivities
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	LikeSyncStatusPending = "pending"
+	LikeSyncStatusSynced  = "synced"
+	LikeSyncStatusSkipped = "skipped" // replaced by a later change or not linked
+	LikeSyncStatusDead    = "dead"    // kept failing, not tried again
+)
+
+type (
+	// LikeSync is an outbox row to like or unlike a track on spotify.
+	LikeSync struct {
+		gorm.Model
+		UserID        uint      `gorm:"not null;index"`
+		SpotifyID     string    `gorm:"not null"`
+		Liked         bool      `gorm:"not null"`
+		ChangedAt     time.Time `gorm:"not null"`
+		Status        string    `gorm:"not null;index:idx_like_syncs_due,priority:1"`
+		Attempts      int       `gorm:"not null;default:0"` // bumped on every claim
+		NextAttemptAt time.Time `gorm:"not null;index:idx_like_syncs_due,priority:2"`
+		Error         string
+	}
+)
@@ -1,12 +1,16 @@
 package trackactivities
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type (
 	TrackActivity struct {
 		gorm.Model
-		UserID    uint   `gorm:"not null"`
-		SpotifyID string `gorm:"not null"`
+		UserID    uint   `gorm:"not null;uniqueIndex:idx_track_activities_user_spotify"`
+		SpotifyID string `gorm:"not null;uniqueIndex:idx_track_activities_user_spotify"`
 		IsLiked   *bool
 		CreatedBy string `gorm:"not null"`
 		UpdatedBy string `gorm:"not null"`
@@ -18,4 +22,27 @@ type (
 		SpotifyID string `json:"spotifyID"`
 		IsLiked   *bool  `json:"isLiked"` // true = liked, false = dislike, null = neutral
 	}
+
+	ListRequest struct {
+		IsLiked   bool
+		PageSize  int
+		Cursor    string
+		Ascending bool
+	}
+)
+
+type (
+	// ListParams selects a page of liked or disliked activities ordered by
+	// updated time. After is the last row of the previous page.
+	ListParams struct {
+		IsLiked   bool
+		Limit     int
+		Ascending bool
+		After     *Cursor
+	}
+
+	Cursor struct {
+		UpdatedAt time.Time
+		ID        uint
+	}
 )
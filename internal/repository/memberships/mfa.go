@@ -0,0 +1,79 @@
+package memberships
+
+import (
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/gorm"
+)
+
+func (r *repository) GetUserTOTP(userID uint) (*memberships.UserTOTP, error) {
+	userTOTP := memberships.UserTOTP{}
+	res := r.db.Where("user_id = ?", userID).First(&userTOTP)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &userTOTP, nil
+}
+
+// EnrollTOTP replaces an unconfirmed enrollment and the recovery codes of
+// the user in one transaction.
+func (r *repository) EnrollTOTP(model memberships.UserTOTP, codes []memberships.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Where("user_id = ?", model.UserID).Where("confirmed_at IS NULL").
+			Delete(&memberships.UserTOTP{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Create(&model).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().Where("user_id = ?", model.UserID).Delete(&memberships.RecoveryCode{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// ConfirmTOTP turns two-factor login on. It reports false when the
+// enrollment was already confirmed.
+func (r *repository) ConfirmTOTP(userID uint, step int64, confirmedAt time.Time) (bool, error) {
+	res := r.db.Model(&memberships.UserTOTP{}).
+		Where("user_id = ?", userID).Where("confirmed_at IS NULL").
+		Updates(map[string]interface{}{
+			"confirmed_at": confirmedAt,
+			"last_step":    step,
+		})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// UseTOTPStep records an accepted code. It reports false when a code of
+// the same or a later step was accepted already.
+func (r *repository) UseTOTPStep(userID uint, step int64) (bool, error) {
+	res := r.db.Model(&memberships.UserTOTP{}).
+		Where("user_id = ?", userID).Where("last_step < ?", step).
+		Update("last_step", step)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+// UseRecoveryCode uses up a recovery code. It reports false when the code
+// is unknown or was used already.
+func (r *repository) UseRecoveryCode(userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	res := r.db.Model(&memberships.RecoveryCode{}).
+		Where("user_id = ?", userID).Where("code_hash = ?", codeHash).Where("used_at IS NULL").
+		Update("used_at", usedAt)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,203 @@
+package memberships
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_EnrollTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	model := memberships.UserTOTP{UserID: 1, Secret: "secret"}
+	codes := []memberships.RecoveryCode{
+		{UserID: 1, CodeHash: "hash1"},
+		{UserID: 1, CodeHash: "hash2"},
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "user_totps" WHERE user_id = \$1 AND confirmed_at IS NULL`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(`INSERT INTO "user_totps" (.+) VALUES (.+)`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "secret", nil, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"last_step", "id"}).AddRow(0, 1))
+				mock.ExpectExec(`DELETE FROM "recovery_codes" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				mock.ExpectQuery(`INSERT INTO "recovery_codes" (.+) VALUES (.+),(.+)`).
+					WithArgs(
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "hash1", nil,
+						sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "hash2", nil,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: already confirmed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "user_totps" WHERE user_id = \$1 AND confirmed_at IS NULL`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(`INSERT INTO "user_totps" (.+) VALUES (.+)`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			err := r.EnrollTOTP(model, codes)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_ConfirmTOTP(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "user_totps" SET "confirmed_at"=\$1,"last_step"=\$2,"updated_at"=\$3 WHERE user_id = \$4 AND confirmed_at IS NULL .+`).
+		WithArgs(now, int64(42), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	confirmed, err := r.ConfirmTOTP(1, 42, now)
+	assert.NoError(t, err)
+	assert.True(t, confirmed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_UseTOTPStep(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "user_totps" SET "last_step"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND last_step < \$4 .+`).
+					WithArgs(int64(42), sqlmock.AnyArg(), 1, int64(42)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "replayed",
+			want: false,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "user_totps" SET "last_step"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND last_step < \$4 .+`).
+					WithArgs(int64(42), sqlmock.AnyArg(), 1, int64(42)).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "user_totps" .+`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.UseTOTPStep(1, 42)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_UseRecoveryCode(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		want bool
+		rows int64
+	}{
+		{name: "success", want: true, rows: 1},
+		{name: "unknown or used", want: false, rows: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			mock.ExpectExec(`UPDATE "recovery_codes" SET "used_at"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND code_hash = \$4 AND used_at IS NULL .+`).
+				WithArgs(now, sqlmock.AnyArg(), 1, "hash").
+				WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			mock.ExpectCommit()
+
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.UseRecoveryCode(1, "hash", now)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
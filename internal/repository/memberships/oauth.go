@@ -0,0 +1,81 @@
+package memberships
+
+import (
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) CreateOAuthState(model memberships.OAuthState) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *repository) GetOAuthState(stateHash string) (*memberships.OAuthState, error) {
+	state := memberships.OAuthState{}
+	res := r.db.Where("state_hash = ?", stateHash).First(&state)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &state, nil
+}
+
+// UseOAuthState uses up the state. It reports false when it was used
+// already, i.e. the callback was replayed.
+func (r *repository) UseOAuthState(id uint, usedAt time.Time) (bool, error) {
+	res := r.db.Model(&memberships.OAuthState{}).
+		Where("id = ?", id).Where("used_at IS NULL").
+		Update("used_at", usedAt)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *repository) GetSpotifyAccount(spotifyUserID string) (*memberships.SpotifyAccount, error) {
+	account := memberships.SpotifyAccount{}
+	res := r.db.Where("spotify_user_id = ?", spotifyUserID).First(&account)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &account, nil
+}
+
+func (r *repository) GetSpotifyAccountByUser(userID uint) (*memberships.SpotifyAccount, error) {
+	account := memberships.SpotifyAccount{}
+	res := r.db.Where("user_id = ?", userID).First(&account)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &account, nil
+}
+
+// SaveSpotifyAccount links the user to the spotify account, replacing the
+// account linked before.
+func (r *repository) SaveSpotifyAccount(model memberships.SpotifyAccount) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "spotify_user_id", "refresh_token", "scope"}),
+	}).Create(&model).Error
+}
+
+// CreateSpotifyUser creates a user signing up with spotify together with
+// their linked account, and sets the id of the new user on user.
+func (r *repository) CreateSpotifyUser(user *memberships.User, account memberships.SpotifyAccount) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(user).Error
+		if err != nil {
+			return err
+		}
+		account.UserID = user.ID
+		return tx.Create(&account).Error
+	})
+}
+
+// UpdateSpotifyRefreshToken stores the refresh token spotify rotated to.
+func (r *repository) UpdateSpotifyRefreshToken(userID uint, refreshToken string) error {
+	return r.db.Model(&memberships.SpotifyAccount{}).
+		Where("user_id = ?", userID).
+		Update("refresh_token", refreshToken).Error
+}
@@ -0,0 +1,186 @@
+package memberships
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_UseOAuthState(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "oauth_states" SET "used_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND used_at IS NULL AND "oauth_states"."deleted_at" IS NULL`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "success: used already",
+			want: false,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "oauth_states" SET (.+) WHERE (.+)`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "oauth_states" SET (.+) WHERE (.+)`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.UseOAuthState(1, now)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_SaveSpotifyAccount(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	model := memberships.SpotifyAccount{
+		UserID:        1,
+		SpotifyUserID: "spotifyuser",
+		RefreshToken:  "sealed",
+		Scope:         "user-read-email",
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "spotify_accounts" (.+) VALUES (.+) ON CONFLICT \("user_id"\) DO UPDATE SET "updated_at"="excluded"."updated_at","spotify_user_id"="excluded"."spotify_user_id","refresh_token"="excluded"."refresh_token","scope"="excluded"."scope" RETURNING "id"`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 1, "spotifyuser", "sealed", "user-read-email").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "spotify_accounts" (.+) VALUES (.+)`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			err := r.SaveSpotifyAccount(model)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_CreateSpotifyUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		wantUserID uint
+		wantErr    bool
+		mockFn     func()
+	}{
+		{
+			name:       "success",
+			wantUserID: 7,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "users" (.+) VALUES (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectQuery(`INSERT INTO "spotify_accounts" (.+) VALUES (.+)`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), 7, "spotifyuser", "sealed", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: rolls back the user",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "users" (.+) VALUES (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectQuery(`INSERT INTO "spotify_accounts" (.+) VALUES (.+)`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			user := &memberships.User{Email: "spotify@example.com", Username: "spotifyuser"}
+			err := r.CreateSpotifyUser(user, memberships.SpotifyAccount{SpotifyUserID: "spotifyuser", RefreshToken: "sealed"})
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantUserID, user.ID)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
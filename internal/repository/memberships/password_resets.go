@@ -0,0 +1,57 @@
+package memberships
+
+import (
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/gorm"
+)
+
+func (r *repository) CreatePasswordReset(model memberships.PasswordReset) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *repository) GetPasswordReset(tokenHash string) (*memberships.PasswordReset, error) {
+	reset := memberships.PasswordReset{}
+	res := r.db.Where("token_hash = ?", tokenHash).First(&reset)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &reset, nil
+}
+
+// ResetPassword uses up the reset token and sets the new password in one
+// transaction. It reports false when the token was already used, i.e. a
+// concurrent reset won. The other pending tokens of the user are used up as
+// well, and the email counts as verified since the user read the mail.
+func (r *repository) ResetPassword(resetID, userID uint, password string, usedAt time.Time) (bool, error) {
+	used := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&memberships.PasswordReset{}).
+			Where("id = ?", resetID).Where("used_at IS NULL").
+			Update("used_at", usedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return nil
+		}
+		used = true
+
+		err := tx.Model(&memberships.PasswordReset{}).
+			Where("user_id = ?", userID).Where("used_at IS NULL").
+			Update("used_at", usedAt).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&memberships.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"password":          password,
+			"email_verified_at": gorm.Expr("COALESCE(email_verified_at, ?)", usedAt),
+		}).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return used, nil
+}
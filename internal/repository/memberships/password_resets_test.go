@@ -0,0 +1,144 @@
+package memberships
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_CreatePasswordReset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	model := memberships.PasswordReset{
+		UserID:    1,
+		TokenHash: "tokenHash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "password_resets" (.+) VALUES (.+)`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			model.UserID,
+			model.TokenHash,
+			model.ExpiresAt,
+			nil,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	assert.NoError(t, r.CreatePasswordReset(model))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_GetPasswordReset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	mock.ExpectQuery(`SELECT \* FROM "password_resets" .+`).
+		WithArgs("tokenHash", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at"}).
+			AddRow(1, 2, "tokenHash", expiresAt))
+
+	r := &repository{
+		db: gormDB,
+	}
+	got, err := r.GetPasswordReset("tokenHash")
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), got.UserID)
+	assert.Equal(t, expiresAt, got.ExpiresAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_ResetPassword(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "password_resets" SET "used_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND used_at IS NULL .+`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "password_resets" SET "used_at"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND used_at IS NULL .+`).
+					WithArgs(now, sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`UPDATE "users" SET "email_verified_at"=COALESCE\(email_verified_at, \$1\),"password"=\$2,"updated_at"=\$3 WHERE id = \$4 .+`).
+					WithArgs(now, "hashed", sqlmock.AnyArg(), 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "already used",
+			want: false,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "password_resets" SET "used_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND used_at IS NULL .+`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "password_resets" SET "used_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND used_at IS NULL .+`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "password_resets" .+`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.ResetPassword(1, 2, "hashed", now)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
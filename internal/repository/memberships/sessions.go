@@ -0,0 +1,44 @@
+package memberships
+
+import (
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+)
+
+func (r *repository) CreateSession(model memberships.Session) error {
+	return r.db.Create(&model).Error
+}
+
+func (r *repository) GetSession(tokenHash string) (*memberships.Session, error) {
+	session := memberships.Session{}
+	res := r.db.Where("token_hash = ?", tokenHash).First(&session)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &session, nil
+}
+
+// RotateSession marks the session as exchanged. It reports false when the
+// session was already rotated or revoked, i.e. another request won the race.
+func (r *repository) RotateSession(id uint, rotatedAt time.Time) (bool, error) {
+	res := r.db.Model(&memberships.Session{}).
+		Where("id = ?", id).Where("rotated_at IS NULL").Where("revoked_at IS NULL").
+		Update("rotated_at", rotatedAt)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
+
+func (r *repository) RevokeSessionFamily(familyID string, revokedAt time.Time) error {
+	return r.db.Model(&memberships.Session{}).
+		Where("family_id = ?", familyID).Where("revoked_at IS NULL").
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *repository) RevokeUserSessions(userID uint, revokedAt time.Time) error {
+	return r.db.Model(&memberships.Session{}).
+		Where("user_id = ?", userID).Where("revoked_at IS NULL").
+		Update("revoked_at", revokedAt).Error
+}
@@ -0,0 +1,230 @@
+package memberships
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_CreateSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour)
+	model := memberships.Session{
+		UserID:    1,
+		FamilyID:  "familyID",
+		TokenHash: "tokenHash",
+		ExpiresAt: expiresAt,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "sessions" (.+) VALUES (.+)`).
+		WithArgs(
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			model.UserID,
+			model.FamilyID,
+			model.TokenHash,
+			model.ExpiresAt,
+			nil,
+			nil,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	assert.NoError(t, r.CreateSession(model))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_GetSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		want    *memberships.Session
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: &memberships.Session{
+				Model: gorm.Model{
+					ID:        1,
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				UserID:    1,
+				FamilyID:  "familyID",
+				TokenHash: "tokenHash",
+				ExpiresAt: now,
+			},
+			wantErr: false,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT \* FROM "sessions" WHERE token_hash = \$1 .+`).WithArgs("tokenHash", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "user_id", "family_id", "token_hash", "expires_at"}).
+						AddRow(1, now, now, 1, "familyID", "tokenHash", now))
+			},
+		},
+		{
+			name:    "failed",
+			want:    nil,
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT \* FROM "sessions" .+`).WithArgs("tokenHash", 1).
+					WillReturnError(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.GetSession("tokenHash")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repository.GetSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repository.GetSession() = %v, want %v", got, tt.want)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_RotateSession(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "sessions" SET "rotated_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND rotated_at IS NULL AND revoked_at IS NULL AND "sessions"."deleted_at" IS NULL`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "success: already rotated",
+			want: false,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "sessions" .+`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "sessions" .+`).
+					WithArgs(now, sqlmock.AnyArg(), 1).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.RotateSession(1, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repository.RotateSession() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_RevokeSessionFamily(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "sessions" SET "revoked_at"=\$1,"updated_at"=\$2 WHERE family_id = \$3 AND revoked_at IS NULL AND "sessions"."deleted_at" IS NULL`).
+		WithArgs(now, sqlmock.AnyArg(), "familyID").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	assert.NoError(t, r.RevokeSessionFamily("familyID", now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_RevokeUserSessions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "sessions" SET "revoked_at"=\$1,"updated_at"=\$2 WHERE user_id = \$3 AND revoked_at IS NULL AND "sessions"."deleted_at" IS NULL`).
+		WithArgs(now, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	assert.NoError(t, r.RevokeUserSessions(1, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
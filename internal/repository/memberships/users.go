@@ -1,7 +1,10 @@
 package memberships
 
 import (
+	"time"
+
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"gorm.io/gorm"
 )
 
 func (r *repository) CreateUser(model memberships.User) error {
@@ -16,3 +19,38 @@ func (r *repository) GetUser(email, username string, id uint) (*memberships.User
 	}
 	return &user, nil
 }
+
+func (r *repository) VerifyEmail(userID uint, verifiedAt time.Time) error {
+	return r.db.Model(&memberships.User{}).
+		Where("id = ?", userID).Where("email_verified_at IS NULL").
+		Update("email_verified_at", verifiedAt).Error
+}
+
+// UpdateUser sets the given columns of the user.
+func (r *repository) UpdateUser(userID uint, fields map[string]interface{}) error {
+	return r.db.Model(&memberships.User{}).Where("id = ?", userID).Updates(fields).Error
+}
+
+// DeleteUser removes the user together with its sessions, reset tokens,
+// two-factor secrets and spotify link in one transaction. Rows of other
+// modules reference the user with ON DELETE CASCADE and go with it. Rows
+// are deleted for good, so the email and username can be signed up again.
+func (r *repository) DeleteUser(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		owned := []interface{}{
+			&memberships.Session{},
+			&memberships.PasswordReset{},
+			&memberships.UserTOTP{},
+			&memberships.RecoveryCode{},
+			&memberships.OAuthState{},
+			&memberships.SpotifyAccount{},
+		}
+		for _, model := range owned {
+			err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id = ?", userID).Delete(&memberships.User{}).Error
+	})
+}
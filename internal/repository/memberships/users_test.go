@@ -54,6 +54,7 @@ func Test_repository_CreateUser(t *testing.T) {
 						args.model.Password,
 						args.model.CreatedBy,
 						args.model.UpdatedBy,
+						nil,
 					).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 
@@ -85,6 +86,7 @@ func Test_repository_CreateUser(t *testing.T) {
 						args.model.Password,
 						args.model.CreatedBy,
 						args.model.UpdatedBy,
+						nil,
 					).
 					WillReturnError(assert.AnError)
 
@@ -185,3 +187,143 @@ func Test_repository_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func Test_repository_VerifyEmail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "users" SET "email_verified_at"=\$1,"updated_at"=\$2 WHERE id = \$3 AND email_verified_at IS NULL AND "users"."deleted_at" IS NULL`).
+		WithArgs(now, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	assert.NoError(t, r.VerifyEmail(1, now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_UpdateUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "users" SET "email"=\$1,"email_verified_at"=\$2,"updated_by"=\$3,"updated_at"=\$4 WHERE id = \$5 .+`).
+					WithArgs("new@gmail.com", nil, "test@gmail.com", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "users" .+`).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			err := r.UpdateUser(1, map[string]interface{}{
+				"email":             "new@gmail.com",
+				"email_verified_at": nil,
+				"updated_by":        "test@gmail.com",
+			})
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_DeleteUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "sessions" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`DELETE FROM "password_resets" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(`DELETE FROM "user_totps" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM "recovery_codes" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				mock.ExpectExec(`DELETE FROM "oauth_states" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM "spotify_accounts" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`DELETE FROM "users" WHERE id = \$1`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: rolls back",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "sessions" WHERE user_id = \$1`).
+					WithArgs(1).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			err := r.DeleteUser(1)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package playlists
+
+import (
+	"context"
+
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *repository) GetPlaylistMember(ctx context.Context, playlistID, userID uint) (*playlists.PlaylistMember, error) {
+	member := playlists.PlaylistMember{}
+	res := r.db.Where("playlist_id = ?", playlistID).Where("user_id = ?", userID).First(&member)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &member, nil
+}
+
+// ListPlaylistMembers returns the members of the playlist in the order they
+// joined, the owner isn't one of them.
+func (r *repository) ListPlaylistMembers(ctx context.Context, playlistID uint) ([]playlists.PlaylistMember, error) {
+	members := make([]playlists.PlaylistMember, 0)
+	res := r.db.Where("playlist_id = ?", playlistID).Order("id").Find(&members)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return members, nil
+}
+
+// SavePlaylistMember adds the member, or changes the role of a user who is
+// a member already.
+func (r *repository) SavePlaylistMember(ctx context.Context, model *playlists.PlaylistMember) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "playlist_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "updated_at"}),
+	}).Create(model).Error
+}
+
+func (r *repository) DeletePlaylistMember(ctx context.Context, playlistID, userID uint) error {
+	res := r.db.Where("playlist_id = ?", playlistID).Where("user_id = ?", userID).Delete(&playlists.PlaylistMember{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
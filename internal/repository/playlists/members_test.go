@@ -0,0 +1,84 @@
+package playlists
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_SavePlaylistMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "playlist_members" \("playlist_id","user_id","role","created_by","created_at","updated_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6\) ON CONFLICT \("playlist_id","user_id"\) DO UPDATE SET "role"="excluded"."role","updated_at"="excluded"."updated_at" RETURNING "id"`).
+		WithArgs(1, 3, "editor", "2", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	member := &playlists.PlaylistMember{PlaylistID: 1, UserID: 3, Role: playlists.RoleEditor, CreatedBy: "2"}
+	err = r.SavePlaylistMember(context.Background(), member)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), member.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_DeletePlaylistMember(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "playlist_members" WHERE playlist_id = \$1 AND user_id = \$2`).
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: not a member",
+			wantErr: gorm.ErrRecordNotFound,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "playlist_members" WHERE playlist_id = \$1 AND user_id = \$2`).
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			err := r.DeletePlaylistMember(context.Background(), 1, 3)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
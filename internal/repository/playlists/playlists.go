@@ -0,0 +1,256 @@
+package playlists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidPosition = errors.New("invalid track position")
+	ErrPlaylistFull    = fmt.Errorf("a playlist holds at most %d tracks", playlists.MaxTracks)
+	ErrVersionConflict = errors.New("playlist was changed in the meantime")
+)
+
+func (r *repository) CreatePlaylist(ctx context.Context, model *playlists.Playlist) error {
+	return r.db.Create(model).Error
+}
+
+// CreatePlaylistWithTracks creates the playlist holding the tracks in
+// order, all or nothing.
+func (r *repository) CreatePlaylistWithTracks(ctx context.Context, model *playlists.Playlist, spotifyIDs []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		model.TrackCount = len(spotifyIDs)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		if len(spotifyIDs) == 0 {
+			return nil
+		}
+
+		tracks := make([]playlists.PlaylistTrack, len(spotifyIDs))
+		for idx, spotifyID := range spotifyIDs {
+			tracks[idx] = playlists.PlaylistTrack{
+				PlaylistID: model.ID,
+				Position:   idx,
+				SpotifyID:  spotifyID,
+				AddedBy:    model.CreatedBy,
+			}
+		}
+		return tx.Create(&tracks).Error
+	})
+}
+
+func (r *repository) GetPlaylist(ctx context.Context, id uint) (*playlists.Playlist, error) {
+	playlist := playlists.Playlist{}
+	res := r.db.Where("id = ?", id).First(&playlist)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &playlist, nil
+}
+
+func (r *repository) GetPlaylistBySlug(ctx context.Context, slug string) (*playlists.Playlist, error) {
+	playlist := playlists.Playlist{}
+	res := r.db.Where("share_slug = ?", slug).First(&playlist)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &playlist, nil
+}
+
+// ListPlaylists returns the playlists the user owns or is a member of, each
+// with the role of the user, last changed first.
+func (r *repository) ListPlaylists(ctx context.Context, userID uint) ([]playlists.PlaylistWithRole, error) {
+	result := make([]playlists.PlaylistWithRole, 0)
+	res := r.db.Model(&playlists.Playlist{}).
+		Select("playlists.*, COALESCE(playlist_members.role, ?) AS role", playlists.RoleOwner).
+		Joins("LEFT JOIN playlist_members ON playlist_members.playlist_id = playlists.id AND playlist_members.user_id = ?", userID).
+		Where("playlists.user_id = ? OR playlist_members.id IS NOT NULL", userID).
+		Order("playlists.updated_at DESC, playlists.id DESC").
+		Find(&result)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return result, nil
+}
+
+func (r *repository) RenamePlaylist(ctx context.Context, id uint, name, updatedBy string) (*playlists.Playlist, error) {
+	return r.editPlaylist(id, 0, updatedBy, func(tx *gorm.DB, playlist *playlists.Playlist) error {
+		playlist.Name = name
+		return nil
+	})
+}
+
+// SetShareSlug shares the playlist under the slug, a nil slug stops sharing.
+func (r *repository) SetShareSlug(ctx context.Context, id uint, slug *string, updatedBy string) (*playlists.Playlist, error) {
+	return r.editPlaylist(id, 0, updatedBy, func(tx *gorm.DB, playlist *playlists.Playlist) error {
+		playlist.ShareSlug = slug
+		return nil
+	})
+}
+
+// DeletePlaylist removes the playlist with its tracks and members for good.
+func (r *repository) DeletePlaylist(ctx context.Context, id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []interface{}{&playlists.PlaylistTrack{}, &playlists.PlaylistMember{}} {
+			err := tx.Where("playlist_id = ?", id).Delete(model).Error
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Unscoped().Where("id = ?", id).Delete(&playlists.Playlist{}).Error
+	})
+}
+
+// ListPlaylistTracks returns the tracks of the playlist in order.
+func (r *repository) ListPlaylistTracks(ctx context.Context, playlistID uint) ([]playlists.PlaylistTrack, error) {
+	tracks := make([]playlists.PlaylistTrack, 0)
+	res := r.db.Where("playlist_id = ?", playlistID).Order("position").Find(&tracks)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return tracks, nil
+}
+
+// AddPlaylistTracks inserts the tracks in order before position, or at the
+// end when position is nil.
+func (r *repository) AddPlaylistTracks(ctx context.Context, playlistID uint, version int, position *int, spotifyIDs []string, updatedBy string) (*playlists.Playlist, error) {
+	return r.editPlaylist(playlistID, version, updatedBy, func(tx *gorm.DB, playlist *playlists.Playlist) error {
+		at := playlist.TrackCount
+		if position != nil {
+			if *position < 0 || *position > playlist.TrackCount {
+				return ErrInvalidPosition
+			}
+			at = *position
+		}
+		if playlist.TrackCount+len(spotifyIDs) > playlists.MaxTracks {
+			return ErrPlaylistFull
+		}
+
+		if at < playlist.TrackCount {
+			err := tx.Model(&playlists.PlaylistTrack{}).
+				Where("playlist_id = ?", playlistID).Where("position >= ?", at).
+				Update("position", gorm.Expr("position + ?", len(spotifyIDs))).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		tracks := make([]playlists.PlaylistTrack, len(spotifyIDs))
+		for idx, spotifyID := range spotifyIDs {
+			tracks[idx] = playlists.PlaylistTrack{
+				PlaylistID: playlistID,
+				Position:   at + idx,
+				SpotifyID:  spotifyID,
+				AddedBy:    updatedBy,
+			}
+		}
+		if err := tx.Create(&tracks).Error; err != nil {
+			return err
+		}
+		playlist.TrackCount += len(spotifyIDs)
+		return nil
+	})
+}
+
+// RemovePlaylistTracks removes the tracks at the positions and closes the
+// gaps they leave.
+func (r *repository) RemovePlaylistTracks(ctx context.Context, playlistID uint, version int, positions []int, updatedBy string) (*playlists.Playlist, error) {
+	return r.editPlaylist(playlistID, version, updatedBy, func(tx *gorm.DB, playlist *playlists.Playlist) error {
+		unique := make([]int, 0, len(positions))
+		seen := make(map[int]bool, len(positions))
+		for _, position := range positions {
+			if position < 0 || position >= playlist.TrackCount {
+				return ErrInvalidPosition
+			}
+			if !seen[position] {
+				seen[position] = true
+				unique = append(unique, position)
+			}
+		}
+
+		err := tx.Where("playlist_id = ?", playlistID).Where("position IN ?", unique).Delete(&playlists.PlaylistTrack{}).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Exec(`UPDATE playlist_tracks SET position = renumbered.position
+			FROM (
+				SELECT id, row_number() OVER (ORDER BY position) - 1 AS position
+				FROM playlist_tracks WHERE playlist_id = ?
+			) AS renumbered
+			WHERE playlist_tracks.id = renumbered.id AND playlist_tracks.position <> renumbered.position`,
+			playlistID,
+		).Error
+		if err != nil {
+			return err
+		}
+		playlist.TrackCount -= len(unique)
+		return nil
+	})
+}
+
+// MovePlaylistTracks moves rangeLength tracks starting at rangeStart to
+// before the track at insertBefore, both positions as before the move.
+func (r *repository) MovePlaylistTracks(ctx context.Context, playlistID uint, version, rangeStart, rangeLength, insertBefore int, updatedBy string) (*playlists.Playlist, error) {
+	return r.editPlaylist(playlistID, version, updatedBy, func(tx *gorm.DB, playlist *playlists.Playlist) error {
+		rangeEnd := rangeStart + rangeLength
+		if rangeStart < 0 || rangeLength < 1 || rangeEnd > playlist.TrackCount || insertBefore < 0 || insertBefore > playlist.TrackCount {
+			return ErrInvalidPosition
+		}
+
+		switch {
+		case insertBefore > rangeEnd:
+			// the range moves down, the tracks between move up in its place
+			return tx.Exec(`UPDATE playlist_tracks SET position = CASE WHEN position < ? THEN position + ? ELSE position - ? END
+				WHERE playlist_id = ? AND position >= ? AND position < ?`,
+				rangeEnd, insertBefore-rangeEnd, rangeLength,
+				playlistID, rangeStart, insertBefore,
+			).Error
+		case insertBefore < rangeStart:
+			// the range moves up, the tracks between move down behind it
+			return tx.Exec(`UPDATE playlist_tracks SET position = CASE WHEN position >= ? THEN position - ? ELSE position + ? END
+				WHERE playlist_id = ? AND position >= ? AND position < ?`,
+				rangeStart, rangeStart-insertBefore, rangeLength,
+				playlistID, insertBefore, rangeEnd,
+			).Error
+		default:
+			// inserting right before or after itself leaves the order as is
+			return nil
+		}
+	})
+}
+
+// editPlaylist runs edit on the playlist locked for the transaction, so
+// changes to the positions of one playlist happen one after the other, and
+// saves the playlist afterwards. Edits of the tracks pass the version they
+// were made on and move the playlist to the next version, other edits pass
+// 0 and leave the version alone.
+func (r *repository) editPlaylist(id uint, version int, updatedBy string, edit func(tx *gorm.DB, playlist *playlists.Playlist) error) (*playlists.Playlist, error) {
+	playlist := playlists.Playlist{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&playlist).Error
+		if err != nil {
+			return err
+		}
+		if version > 0 {
+			if playlist.Version != version {
+				return ErrVersionConflict
+			}
+			playlist.Version++
+		}
+		if err := edit(tx, &playlist); err != nil {
+			return err
+		}
+		playlist.UpdatedBy = updatedBy
+		return tx.Save(&playlist).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
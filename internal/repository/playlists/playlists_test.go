@@ -0,0 +1,360 @@
+package playlists
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var playlistColumns = []string{"id", "created_at", "updated_at", "deleted_at", "user_id", "name", "track_count", "version", "share_slug", "created_by", "updated_by"}
+
+func Test_repository_AddPlaylistTracks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	position := 1
+	outOfRange := 4
+
+	tests := []struct {
+		name     string
+		position *int
+		want     *playlists.Playlist
+		wantErr  error
+		mockFn   func()
+	}{
+		{
+			name:     "success: insert before a track",
+			position: &position,
+			want: &playlists.Playlist{
+				Model:  gorm.Model{ID: 1, CreatedAt: now},
+				UserID: 2, Name: "road trip", TrackCount: 5, CreatedBy: "2", UpdatedBy: "2",
+			},
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" WHERE id = \$1 AND "playlists"."deleted_at" IS NULL ORDER BY "playlists"."id" LIMIT \$2 FOR UPDATE`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 3, 4, nil, "2", "2"))
+				mock.ExpectExec(`UPDATE "playlist_tracks" SET "position"=position \+ \$1 WHERE playlist_id = \$2 AND position >= \$3`).
+					WithArgs(2, 1, 1).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectQuery(`INSERT INTO "playlist_tracks" \("playlist_id","position","spotify_id","added_by","created_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5\),\(\$6,\$7,\$8,\$9,\$10\) RETURNING "id"`).
+					WithArgs(1, 1, "track1", "2", sqlmock.AnyArg(), 1, 2, "track2", "2", sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(11))
+				mock.ExpectExec(`UPDATE "playlists" SET (.+) WHERE "playlists"."deleted_at" IS NULL AND "id" = (.+)`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 2, "road trip", 5, 5, nil, "2", "2", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: position out of range",
+			wantErr: ErrInvalidPosition,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 3, 4, nil, "2", "2"))
+				mock.ExpectRollback()
+			},
+			position: &outOfRange,
+		},
+		{
+			name:    "failed: playlist full",
+			wantErr: ErrPlaylistFull,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", playlists.MaxTracks-1, 4, nil, "2", "2"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name:     "failed: changed in the meantime",
+			position: &position,
+			wantErr:  ErrVersionConflict,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 3, 5, nil, "2", "2"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name:    "failed: not found",
+			wantErr: gorm.ErrRecordNotFound,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns))
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.AddPlaylistTracks(context.Background(), 1, 4, tt.position, []string{"track1", "track2"}, "2")
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.want != nil {
+				assert.Equal(t, tt.want.TrackCount, got.TrackCount)
+				assert.Equal(t, tt.want.Name, got.Name)
+			} else {
+				assert.Nil(t, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_RemovePlaylistTracks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		positions []int
+		want      int
+		wantErr   error
+		mockFn    func()
+	}{
+		{
+			name:      "success",
+			positions: []int{0, 2, 0},
+			want:      1,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 3, 4, nil, "2", "2"))
+				mock.ExpectExec(`DELETE FROM "playlist_tracks" WHERE playlist_id = \$1 AND position IN \(\$2,\$3\)`).
+					WithArgs(1, 0, 2).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`UPDATE playlist_tracks SET position = renumbered.position\s+FROM \(\s+SELECT id, row_number\(\) OVER \(ORDER BY position\) - 1 AS position\s+FROM playlist_tracks WHERE playlist_id = \$1\s+\) AS renumbered\s+WHERE playlist_tracks.id = renumbered.id AND playlist_tracks.position <> renumbered.position`).
+					WithArgs(1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE "playlists" SET (.+)`).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 2, "road trip", 1, 5, nil, "2", "2", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:      "failed: position out of range",
+			positions: []int{3},
+			wantErr:   ErrInvalidPosition,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 3, 4, nil, "2", "2"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.RemovePlaylistTracks(context.Background(), 1, 4, tt.positions, "2")
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.Equal(t, tt.want, got.TrackCount)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_MovePlaylistTracks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	type args struct {
+		rangeStart   int
+		rangeLength  int
+		insertBefore int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: move down",
+			args: args{rangeStart: 1, rangeLength: 2, insertBefore: 5},
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 6, 4, nil, "2", "2"))
+				mock.ExpectExec(`UPDATE playlist_tracks SET position = CASE WHEN position < \$1 THEN position \+ \$2 ELSE position - \$3 END\s+WHERE playlist_id = \$4 AND position >= \$5 AND position < \$6`).
+					WithArgs(3, 2, 2, 1, 1, 5).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+				mock.ExpectExec(`UPDATE "playlists" SET (.+)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "success: move up",
+			args: args{rangeStart: 4, rangeLength: 2, insertBefore: 0},
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 6, 4, nil, "2", "2"))
+				mock.ExpectExec(`UPDATE playlist_tracks SET position = CASE WHEN position >= \$1 THEN position - \$2 ELSE position \+ \$3 END\s+WHERE playlist_id = \$4 AND position >= \$5 AND position < \$6`).
+					WithArgs(4, 4, 2, 1, 0, 6).
+					WillReturnResult(sqlmock.NewResult(0, 6))
+				mock.ExpectExec(`UPDATE "playlists" SET (.+)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "success: in place",
+			args: args{rangeStart: 1, rangeLength: 2, insertBefore: 3},
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 6, 4, nil, "2", "2"))
+				mock.ExpectExec(`UPDATE "playlists" SET (.+)`).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: range past the end",
+			args:    args{rangeStart: 5, rangeLength: 2, insertBefore: 0},
+			wantErr: ErrInvalidPosition,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT \* FROM "playlists" (.+) FOR UPDATE`).
+					WillReturnRows(sqlmock.NewRows(playlistColumns).AddRow(1, now, now, nil, 2, "road trip", 6, 4, nil, "2", "2"))
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			_, err := r.MovePlaylistTracks(context.Background(), 1, 4, tt.args.rangeStart, tt.args.rangeLength, tt.args.insertBefore, "2")
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_DeletePlaylist(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "playlist_tracks" WHERE playlist_id = \$1`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`DELETE FROM "playlist_members" WHERE playlist_id = \$1`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(`DELETE FROM "playlists" WHERE id = \$1`).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	err = r.DeletePlaylist(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_ListPlaylists(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT playlists.\*, COALESCE\(playlist_members.role, \$1\) AS role FROM "playlists" LEFT JOIN playlist_members ON playlist_members.playlist_id = playlists.id AND playlist_members.user_id = \$2 WHERE \(playlists.user_id = \$3 OR playlist_members.id IS NOT NULL\) AND "playlists"."deleted_at" IS NULL ORDER BY playlists.updated_at DESC, playlists.id DESC`).
+		WithArgs("owner", 2, 2).
+		WillReturnRows(sqlmock.NewRows(append(playlistColumns, "role")).
+			AddRow(1, now, now, nil, 2, "road trip", 3, 4, nil, "2", "2", "owner").
+			AddRow(5, now, now, nil, 3, "gym", 7, 9, nil, "3", "3", "editor"))
+
+	r := &repository{
+		db: gormDB,
+	}
+	got, err := r.ListPlaylists(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(got))
+	assert.Equal(t, "road trip", got[0].Name)
+	assert.Equal(t, "owner", got[0].Role)
+	assert.Equal(t, uint(5), got[1].ID)
+	assert.Equal(t, 9, got[1].Version)
+	assert.Equal(t, "editor", got[1].Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_CreatePlaylistWithTracks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "playlists" \("created_at","updated_at","deleted_at","user_id","name","track_count","version","share_slug","created_by","updated_by"\) VALUES (.+) RETURNING "id"`).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 2, "imported", 2, 1, nil, "2", "2").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery(`INSERT INTO "playlist_tracks" \("playlist_id","position","spotify_id","added_by","created_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5\),\(\$6,\$7,\$8,\$9,\$10\) RETURNING "id"`).
+		WithArgs(7, 0, "track1", "2", sqlmock.AnyArg(), 7, 1, "track2", "2", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10).AddRow(11))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	playlist := &playlists.Playlist{UserID: 2, Name: "imported", CreatedBy: "2", UpdatedBy: "2"}
+	err = r.CreatePlaylistWithTracks(context.Background(), playlist, []string{"track1", "track2"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), playlist.ID)
+	assert.Equal(t, 1, playlist.Version)
+	assert.Equal(t, 2, playlist.TrackCount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
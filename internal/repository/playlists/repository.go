@@ -0,0 +1,52 @@
+package playlists
+
+import (
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/pkg/internalsql"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *gorm.DB) *repository {
+	return &repository{db: db}
+}
+
+// foreignKeys go in order, playlists of deleted users before the rows of
+// deleted playlists.
+var foreignKeys = []internalsql.ForeignKey{
+	{Table: "playlists", Column: "user_id", RefTable: "users"},
+	{Table: "playlist_tracks", Column: "playlist_id", RefTable: "playlists"},
+	{Table: "playlist_members", Column: "playlist_id", RefTable: "playlists"},
+	{Table: "playlist_members", Column: "user_id", RefTable: "users"},
+}
+
+// Migrate creates the tables of the repository. A playlist is deleted with
+// its owner, its tracks and members with the playlist, and a membership
+// with the member.
+func (r *repository) Migrate() error {
+	err := r.db.AutoMigrate(&playlists.Playlist{}, &playlists.PlaylistTrack{}, &playlists.PlaylistMember{})
+	if err != nil {
+		return err
+	}
+
+	for _, fk := range foreignKeys {
+		if err := internalsql.AddCascadingForeignKey(r.db, fk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteOrphans deletes the playlists of deleted users and the rows of
+// deleted playlists, which Migrate can't add the foreign keys over.
+func (r *repository) DeleteOrphans() error {
+	for _, fk := range foreignKeys {
+		if err := internalsql.DeleteOrphans(r.db, fk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
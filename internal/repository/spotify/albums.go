@@ -0,0 +1,55 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SpotifyAlbumDetail struct {
+	AlbumType   string                `json:"album_type"`
+	Artists     []SpotifyArtistObject `json:"artists"`
+	Href        string                `json:"href"`
+	ID          string                `json:"id"`
+	Images      []SpotifyAlbumImage   `json:"images"`
+	Label       string                `json:"label"`
+	Name        string                `json:"name"`
+	Popularity  int                   `json:"popularity"`
+	ReleaseDate string                `json:"release_date"`
+	TotalTracks int                   `json:"total_tracks"`
+	Tracks      SpotifyTracks         `json:"tracks"`
+}
+
+func (o *outbound) GetAlbum(ctx context.Context, albumID string) (*SpotifyAlbumDetail, error) {
+	urlPath := o.apiURL("/albums/" + url.PathEscape(albumID))
+
+	var response SpotifyAlbumDetail
+	err := o.get(ctx, urlPath, &response)
+	if err != nil {
+		log.Error().Err(err).Str("albumID", albumID).Msg("error get album from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetAlbumTracks returns a page of the album tracks. Spotify sends simplified
+// track objects here, the album of every item is left empty.
+func (o *outbound) GetAlbumTracks(ctx context.Context, albumID string, limit, offset int) (*SpotifyTracks, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	basePath := o.apiURL("/albums/" + url.PathEscape(albumID) + "/tracks")
+	urlPath := fmt.Sprintf("%s?%s", basePath, params.Encode())
+
+	var response SpotifyTracks
+	err := o.get(ctx, urlPath, &response)
+	if err != nil {
+		log.Error().Err(err).Str("albumID", albumID).Msg("error get album tracks from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
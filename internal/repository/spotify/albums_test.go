@@ -0,0 +1,98 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_outbound_GetAlbum(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	tests := []struct {
+		name       string
+		albumID    string
+		wantName   string
+		wantTracks []string
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			albumID:    "2noRn2Aes5aoNVsU6iWThc",
+			wantName:   "Discovery",
+			wantTracks: []string{"0DiWol3AO6WpXZgp0goxAV", "2VEZx7NWsZ1D0eJ4uv5Fym"},
+		},
+		{
+			name:    "failed: not found",
+			albumID: "unknown",
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.GetAlbum(context.Background(), tt.albumID)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, "Daft Punk", got.Artists[0].Name)
+
+			trackIDs := make([]string, 0)
+			for _, item := range got.Tracks.Items {
+				trackIDs = append(trackIDs, item.ID)
+			}
+			assert.Equal(t, tt.wantTracks, trackIDs)
+		})
+	}
+}
+
+func Test_outbound_GetAlbumTracks(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	tests := []struct {
+		name      string
+		albumID   string
+		limit     int
+		offset    int
+		wantTotal int
+		wantIDs   []string
+		wantErr   error
+	}{
+		{
+			name:      "success",
+			albumID:   "6dVIqQ8qmQ5GBnJ9shOYGE",
+			limit:     1,
+			offset:    1,
+			wantTotal: 2,
+			wantIDs:   []string{"2CVV8PtUYYsux8XOzWkCP0"},
+		},
+		{
+			name:    "failed: not found",
+			albumID: "unknown",
+			limit:   10,
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.GetAlbumTracks(context.Background(), tt.albumID, tt.limit, tt.offset)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTotal, got.Total)
+
+			trackIDs := make([]string, 0)
+			for _, item := range got.Items {
+				trackIDs = append(trackIDs, item.ID)
+				assert.Empty(t, item.Album.Name)
+			}
+			assert.Equal(t, tt.wantIDs, trackIDs)
+		})
+	}
+}
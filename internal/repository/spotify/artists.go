@@ -0,0 +1,58 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SpotifyArtistDetail struct {
+	Followers  SpotifyFollowers    `json:"followers"`
+	Genres     []string            `json:"genres"`
+	Href       string              `json:"href"`
+	ID         string              `json:"id"`
+	Images     []SpotifyAlbumImage `json:"images"`
+	Name       string              `json:"name"`
+	Popularity int                 `json:"popularity"`
+}
+
+type SpotifyFollowers struct {
+	Total int `json:"total"`
+}
+
+type SpotifyArtistTopTracksResponse struct {
+	Tracks []SpotifyTrackObject `json:"tracks"`
+}
+
+func (o *outbound) GetArtist(ctx context.Context, artistID string) (*SpotifyArtistDetail, error) {
+	urlPath := o.apiURL("/artists/" + url.PathEscape(artistID))
+
+	var response SpotifyArtistDetail
+	err := o.get(ctx, urlPath, &response)
+	if err != nil {
+		log.Error().Err(err).Str("artistID", artistID).Msg("error get artist from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (o *outbound) GetArtistTopTracks(ctx context.Context, artistID, market string) (*SpotifyArtistTopTracksResponse, error) {
+	if market == "" {
+		market = defaultMarket
+	}
+	params := url.Values{}
+	params.Set("market", market)
+
+	basePath := o.apiURL("/artists/" + url.PathEscape(artistID) + "/top-tracks")
+	urlPath := fmt.Sprintf("%s?%s", basePath, params.Encode())
+
+	var response SpotifyArtistTopTracksResponse
+	err := o.get(ctx, urlPath, &response)
+	if err != nil {
+		log.Error().Err(err).Str("artistID", artistID).Msg("error get artist top tracks from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,84 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_outbound_GetArtist(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	tests := []struct {
+		name     string
+		artistID string
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			artistID: "1dfeR4HaWDbWqFHLkxsg1d",
+			wantName: "Queen",
+		},
+		{
+			name:     "failed: not found",
+			artistID: "unknown",
+			wantErr:  ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.GetArtist(context.Background(), tt.artistID)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.artistID, got.ID)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.NotZero(t, got.Followers.Total)
+		})
+	}
+}
+
+func Test_outbound_GetArtistTopTracks(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	tests := []struct {
+		name       string
+		artistID   string
+		market     string
+		wantTracks int
+		wantErr    error
+	}{
+		{
+			name:       "success",
+			artistID:   "4tZwfgrHOc3mvqYlEYSvVi",
+			market:     "US",
+			wantTracks: 2,
+		},
+		{
+			name:       "success: default market",
+			artistID:   "1dfeR4HaWDbWqFHLkxsg1d",
+			wantTracks: 4,
+		},
+		{
+			name:     "failed: not found",
+			artistID: "unknown",
+			wantErr:  ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.GetArtistTopTracks(context.Background(), tt.artistID, tt.market)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, got.Tracks, tt.wantTracks)
+		})
+	}
+}
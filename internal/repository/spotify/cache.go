@@ -0,0 +1,111 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/pkg/cache"
+)
+
+const defaultCacheTTL = 10 * time.Minute
+
+// cachedOutbound decorates outbound with a response cache for search and
+// recommendations. Entries hold the raw spotify answer only, user specific
+// state is merged by the caller, so one entry serves every user.
+type cachedOutbound struct {
+	*outbound
+	backend cache.Backend
+	ttl     time.Duration
+}
+
+// NewCachedOutbound wraps o with backend. A nil backend disables caching.
+func NewCachedOutbound(o *outbound, backend cache.Backend, ttl time.Duration) *cachedOutbound {
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return &cachedOutbound{
+		outbound: o,
+		backend:  backend,
+		ttl:      ttl,
+	}
+}
+
+func (c *cachedOutbound) Search(ctx context.Context, query string, limit, offset int, market string) (*SpotifySearchResponse, error) {
+	key := fmt.Sprintf("spotify:search:%s:%d:%d:%s", normalizeQuery(query), limit, offset, market)
+
+	var response SpotifySearchResponse
+	if c.load(ctx, key, &response) {
+		return &response, nil
+	}
+
+	result, err := c.outbound.Search(ctx, query, limit, offset, market)
+	if err != nil {
+		return nil, err
+	}
+	c.store(ctx, key, result)
+	return result, nil
+}
+
+func (c *cachedOutbound) GetRecommendation(ctx context.Context, params RecommendationParams) (*SpotifyRecommendationResponse, error) {
+	// the encoded query is sorted by key, so equal params share an entry
+	key := "spotify:recommendations:" + params.values().Encode()
+
+	var response SpotifyRecommendationResponse
+	if c.load(ctx, key, &response) {
+		return &response, nil
+	}
+
+	result, err := c.outbound.GetRecommendation(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	c.store(ctx, key, result)
+	return result, nil
+}
+
+// load reads key into response. Cache failures are logged and treated as a
+// miss so a broken backend never fails the request.
+func (c *cachedOutbound) load(ctx context.Context, key string, response interface{}) bool {
+	if c.backend == nil {
+		return false
+	}
+
+	value, ok, err := c.backend.Get(ctx, key)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("error get spotify response from cache")
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	if err := json.Unmarshal(value, response); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("error unmarshal cached spotify response")
+		return false
+	}
+	return true
+}
+
+func (c *cachedOutbound) store(ctx context.Context, key string, response interface{}) {
+	if c.backend == nil {
+		return
+	}
+
+	value, err := json.Marshal(response)
+	if err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("error marshal spotify response for cache")
+		return
+	}
+	if err := c.backend.Set(ctx, key, value, c.ttl); err != nil {
+		log.Warn().Err(err).Str("key", key).Msg("error set spotify response to cache")
+	}
+}
+
+func normalizeQuery(query string) string {
+	return url.QueryEscape(strings.Join(strings.Fields(strings.ToLower(query)), " "))
+}
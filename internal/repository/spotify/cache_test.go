@@ -0,0 +1,136 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/pkg/cache"
+	"github.com/xprasetio/go-spotify/pkg/httpclient"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_cachedOutbound_Search(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockHTTPClient := httpclient.NewMockHTTPClient(mockCtrl)
+	mockBackend := cache.NewMockBackend(mockCtrl)
+
+	type args struct {
+		query  string
+		market string
+	}
+	tests := []struct {
+		name      string
+		backend   func() cache.Backend
+		calls     []args
+		wantTotal int
+		mockFn    func()
+	}{
+		{
+			name:    "success: second call served from cache",
+			backend: func() cache.Backend { return cache.NewMemory(10) },
+			calls: []args{
+				{query: "bohemian rhapsody", market: "ID"},
+				{query: "  Bohemian   Rhapsody ", market: "ID"},
+			},
+			wantTotal: 905,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusOK, nil, searchResponse), nil).Times(1)
+			},
+		},
+		{
+			name:    "success: market is part of the key",
+			backend: func() cache.Backend { return cache.NewMemory(10) },
+			calls: []args{
+				{query: "bohemian rhapsody", market: "ID"},
+				{query: "bohemian rhapsody", market: "US"},
+			},
+			wantTotal: 905,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					return newResponse(http.StatusOK, nil, searchResponse), nil
+				}).Times(2)
+			},
+		},
+		{
+			name:    "success: backend failure falls through",
+			backend: func() cache.Backend { return mockBackend },
+			calls: []args{
+				{query: "bohemian rhapsody", market: "ID"},
+			},
+			wantTotal: 905,
+			mockFn: func() {
+				mockBackend.EXPECT().Get(gomock.Any(), "spotify:search:bohemian+rhapsody:10:0:ID").Return(nil, false, assert.AnError)
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusOK, nil, searchResponse), nil)
+				mockBackend.EXPECT().Set(gomock.Any(), "spotify:search:bohemian+rhapsody:10:0:ID", gomock.Any(), time.Minute).Return(assert.AnError)
+			},
+		},
+		{
+			name:    "success: caching disabled",
+			backend: func() cache.Backend { return nil },
+			calls: []args{
+				{query: "bohemian rhapsody", market: "ID"},
+				{query: "bohemian rhapsody", market: "ID"},
+			},
+			wantTotal: 905,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					return newResponse(http.StatusOK, nil, searchResponse), nil
+				}).Times(2)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			o := &outbound{
+				cfg:    &configs.Config{},
+				client: mockHTTPClient,
+				token: tokenSource{
+					accessToken: "accessToken",
+					tokenType:   "Bearer",
+					expiredAt:   time.Now().Add(time.Hour),
+				},
+			}
+			c := NewCachedOutbound(o, tt.backend(), time.Minute)
+			for _, call := range tt.calls {
+				got, err := c.Search(context.Background(), call.query, 10, 0, call.market)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTotal, got.Tracks.Total)
+				assert.Len(t, got.Tracks.Items, 2)
+			}
+		})
+	}
+}
+
+func Test_cachedOutbound_GetRecommendation(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockHTTPClient := httpclient.NewMockHTTPClient(mockCtrl)
+	mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		return newResponse(http.StatusOK, nil, recommendationResponse), nil
+	}).Times(2)
+
+	o := &outbound{
+		cfg:    &configs.Config{},
+		client: mockHTTPClient,
+		token: tokenSource{
+			accessToken: "accessToken",
+			tokenType:   "Bearer",
+			expiredAt:   time.Now().Add(time.Hour),
+		},
+	}
+	c := NewCachedOutbound(o, cache.NewMemory(10), time.Minute)
+
+	for _, trackID := range []string{"trackA", "trackA", "trackB", "trackB"} {
+		got, err := c.GetRecommendation(context.Background(), RecommendationParams{Limit: 10, SeedTracks: []string{trackID}})
+		assert.NoError(t, err)
+		assert.Len(t, got.Tracks, 2)
+	}
+}
@@ -0,0 +1,111 @@
+package spotify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrBadRequest   = errors.New("spotify rejected the request")
+	ErrUnauthorized = errors.New("spotify rejected the credentials")
+	ErrNotFound     = errors.New("spotify resource not found")
+	ErrRateLimited  = errors.New("spotify rate limit exceeded")
+	ErrUpstream     = errors.New("spotify upstream error")
+	ErrUnavailable  = errors.New("spotify unavailable")
+	// ErrNotLinked is returned for calls on behalf of a user who hasn't
+	// connected their spotify account.
+	ErrNotLinked = errors.New("spotify account is not linked")
+)
+
+// APIError is returned for every non-2xx answer from spotify. It unwraps to
+// one of the sentinel errors above so callers can use errors.Is.
+type APIError struct {
+	StatusCode int
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("%s: status %d", e.Unwrap(), e.StatusCode)
+	}
+	return fmt.Sprintf("%s: status %d: %s", e.Unwrap(), e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return errorKind(e.StatusCode)
+}
+
+// errorFromResponse reads the error body of resp and builds an APIError.
+// It understands both the web api and the accounts service error formats.
+func errorFromResponse(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return apiErr
+	}
+
+	var webAPIError struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &webAPIError) == nil && webAPIError.Error.Message != "" {
+		apiErr.Message = webAPIError.Error.Message
+		return apiErr
+	}
+
+	var accountsError struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if json.Unmarshal(body, &accountsError) == nil && accountsError.Error != "" {
+		apiErr.Message = accountsError.Error
+		if accountsError.ErrorDescription != "" {
+			apiErr.Message = fmt.Sprintf("%s: %s", accountsError.Error, accountsError.ErrorDescription)
+		}
+	}
+	return apiErr
+}
+
+func errorKind(statusCode int) error {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode >= http.StatusInternalServerError:
+		return ErrUpstream
+	default:
+		return ErrBadRequest
+	}
+}
+
+// parseRetryAfter accepts both forms of the Retry-After header, delay in
+// seconds and http date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
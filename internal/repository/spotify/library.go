@@ -0,0 +1,67 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+type SpotifySavedTracksResponse struct {
+	Href   string              `json:"href"`
+	Limit  int                 `json:"limit"`
+	Next   *string             `json:"next"`
+	Offset int                 `json:"offset"`
+	Total  int                 `json:"total"`
+	Items  []SpotifySavedTrack `json:"items"`
+}
+
+type SpotifySavedTrack struct {
+	AddedAt time.Time          `json:"added_at"`
+	Track   SpotifyTrackObject `json:"track"`
+}
+
+// GetSavedTracks returns a page of the user's liked songs, newest first.
+func (o *outbound) GetSavedTracks(ctx context.Context, accessToken string, limit, offset int) (*SpotifySavedTracksResponse, error) {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("offset", strconv.Itoa(offset))
+
+	var response SpotifySavedTracksResponse
+	err := o.getAsUser(ctx, accessToken, o.apiURL("/me/tracks?"+params.Encode()), &response)
+	if err != nil {
+		log.Error().Err(err).Msg("error get saved tracks from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SaveTracks adds at most 50 tracks to the user's liked songs.
+func (o *outbound) SaveTracks(ctx context.Context, accessToken string, trackIDs []string) error {
+	err := o.sendAsUser(ctx, http.MethodPut, accessToken, o.savedTracksURL(trackIDs), nil)
+	if err != nil {
+		log.Error().Err(err).Msg("error save tracks to spotify")
+		return err
+	}
+	return nil
+}
+
+// RemoveSavedTracks removes at most 50 tracks from the user's liked songs.
+func (o *outbound) RemoveSavedTracks(ctx context.Context, accessToken string, trackIDs []string) error {
+	err := o.sendAsUser(ctx, http.MethodDelete, accessToken, o.savedTracksURL(trackIDs), nil)
+	if err != nil {
+		log.Error().Err(err).Msg("error remove saved tracks from spotify")
+		return err
+	}
+	return nil
+}
+
+func (o *outbound) savedTracksURL(trackIDs []string) string {
+	params := url.Values{}
+	params.Set("ids", strings.Join(trackIDs, ","))
+	return o.apiURL("/me/tracks?" + params.Encode())
+}
@@ -1,18 +1,34 @@
 package spotify
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog/log"
 	"github.com/xprasetio/go-spotify/internal/configs"
 	"github.com/xprasetio/go-spotify/pkg/httpclient"
 )
 
+const (
+	defaultAPIBaseURL      = "https://api.spotify.com/v1"
+	defaultAccountsBaseURL = "https://accounts.spotify.com"
+
+	// defaultMarket is used for requests that need a market when none is given.
+	defaultMarket = "ID"
+)
+
 type outbound struct {
-	cfg         *configs.Config
-	client      httpclient.HTTPClient
-	AccessToken string
-	TokenType   string
-	ExpiredAt   time.Time
+	cfg    *configs.Config
+	client httpclient.HTTPClient
+	token  tokenSource
+
+	// sleep replaces the retry wait in tests.
+	sleep func(ctx context.Context, delay time.Duration) error
 }
 
 func NewSpotifyOutbound(cfg *configs.Config, client httpclient.HTTPClient) *outbound {
@@ -21,3 +37,59 @@ func NewSpotifyOutbound(cfg *configs.Config, client httpclient.HTTPClient) *outb
 		client: client,
 	}
 }
+
+// apiURL joins path to the configured web api base url.
+func (o *outbound) apiURL(path string) string {
+	baseURL := o.cfg.SpotifyConfig.APIBaseURL
+	if baseURL == "" {
+		baseURL = defaultAPIBaseURL
+	}
+	return strings.TrimRight(baseURL, "/") + path
+}
+
+// accountsURL joins path to the configured accounts service base url.
+func (o *outbound) accountsURL(path string) string {
+	baseURL := o.cfg.SpotifyConfig.AccountsBaseURL
+	if baseURL == "" {
+		baseURL = defaultAccountsBaseURL
+	}
+	return strings.TrimRight(baseURL, "/") + path
+}
+
+// get performs an authorized GET against the web api and decodes the json
+// answer into response. When spotify rejects the access token, e.g. because
+// it was revoked, the token is fetched again and the call retried once.
+func (o *outbound) get(ctx context.Context, urlPath string, response interface{}) error {
+	for attempt := 0; ; attempt++ {
+		var accessToken string
+		resp, err := o.do(ctx, func() (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlPath, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			var tokenType string
+			accessToken, tokenType, err = o.GetTokenDetails(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			bearerToken := fmt.Sprintf("%s %s", tokenType, accessToken)
+			req.Header.Set("Authorization", bearerToken)
+			return req, nil
+		})
+
+		var apiErr *APIError
+		if attempt == 0 && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized {
+			log.Warn().Err(err).Msg("spotify rejected access token, refreshing")
+			o.token.invalidate(accessToken)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		return json.NewDecoder(resp.Body).Decode(response)
+	}
+}
@@ -0,0 +1,88 @@
+package spotify
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/pkg/fakespotify"
+	"github.com/xprasetio/go-spotify/pkg/httpclient"
+)
+
+func Test_outbound_baseURL(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             configs.SpotifyConfig
+		wantAPIURL      string
+		wantAccountsURL string
+	}{
+		{
+			name:            "default",
+			cfg:             configs.SpotifyConfig{},
+			wantAPIURL:      "https://api.spotify.com/v1/search",
+			wantAccountsURL: "https://accounts.spotify.com/api/token",
+		},
+		{
+			name: "configured",
+			cfg: configs.SpotifyConfig{
+				APIBaseURL:      "http://localhost:8080/v1/",
+				AccountsBaseURL: "http://localhost:8080",
+			},
+			wantAPIURL:      "http://localhost:8080/v1/search",
+			wantAccountsURL: "http://localhost:8080/api/token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &outbound{
+				cfg: &configs.Config{SpotifyConfig: tt.cfg},
+			}
+			assert.Equal(t, tt.wantAPIURL, o.apiURL("/search"))
+			assert.Equal(t, tt.wantAccountsURL, o.accountsURL("/api/token"))
+		})
+	}
+}
+
+// newFakeOutbound returns an outbound talking to a fake spotify server
+// seeded with the default catalogue.
+func newFakeOutbound(t *testing.T) *outbound {
+	server := fakespotify.NewServer(
+		fakespotify.WithTracks(fakespotify.DefaultCatalog()...),
+		fakespotify.WithClientCredentials("clientID", "clientSecret"),
+	)
+	t.Cleanup(server.Close)
+
+	return NewSpotifyOutbound(&configs.Config{
+		SpotifyConfig: configs.SpotifyConfig{
+			ClientID:        "clientID",
+			ClientSecret:    "clientSecret",
+			APIBaseURL:      server.APIBaseURL(),
+			AccountsBaseURL: server.AccountsBaseURL(),
+		},
+	}, httpclient.NewClient(&http.Client{}))
+}
+
+func Test_outbound_fakeServer(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	search, err := o.Search(context.Background(), "bohemian rhapsody", 1, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, search.Tracks.Total)
+	assert.Len(t, search.Tracks.Items, 1)
+	assert.Equal(t, "3z8h0TU7ReDPLIbEnYhWZb", search.Tracks.Items[0].ID)
+	assert.Equal(t, "Queen", search.Tracks.Items[0].Artists[0].Name)
+	assert.NotNil(t, search.Tracks.Next)
+
+	recommendation, err := o.GetRecommendation(context.Background(), RecommendationParams{
+		Limit:      3,
+		SeedTracks: []string{"3z8h0TU7ReDPLIbEnYhWZb"},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, recommendation.Tracks, 3)
+	for _, track := range recommendation.Tracks {
+		assert.Equal(t, "Queen", track.Artists[0].Name)
+		assert.NotEqual(t, "3z8h0TU7ReDPLIbEnYhWZb", track.ID)
+	}
+}
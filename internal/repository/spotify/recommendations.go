@@ -2,49 +2,55 @@ package spotify
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 )
 
-func (o *outbound) GetRecommendation(ctx context.Context, limit int, trackID string) (*SpotifyRecommendationResponse, error) {
-	params := url.Values{}
-	params.Set("limit", strconv.Itoa(limit))
-	params.Set("market", "ID")
-	params.Set("seed_tracks", trackID)
+type RecommendationParams struct {
+	Limit       int
+	Market      string
+	SeedTracks  []string
+	SeedArtists []string
+	SeedGenres  []string
+	// Tunables holds the tunable track attributes keyed by their spotify
+	// query name, e.g. target_energy or min_tempo.
+	Tunables map[string]float64
+}
 
-	basePath := `https://api.spotify.com/v1/recommendations`
-	urlPath := fmt.Sprintf("%s?%s", basePath, params.Encode())
-	req, err := http.NewRequest(http.MethodGet, urlPath, nil)
-	if err != nil {
-		log.Error().Err(err).Msg("error create recommendation request for spotify")
-		return nil, err
+func (p RecommendationParams) values() url.Values {
+	params := url.Values{}
+	params.Set("limit", strconv.Itoa(p.Limit))
+	params.Set("market", defaultMarket)
+	if p.Market != "" {
+		params.Set("market", p.Market)
 	}
-
-	accessToken, tokenType, err := o.GetTokenDetails()
-	if err != nil {
-		log.Error().Err(err).Msg("error get token details for recommendation")
-		return nil, err
+	if len(p.SeedTracks) > 0 {
+		params.Set("seed_tracks", strings.Join(p.SeedTracks, ","))
 	}
-
-	bearerToken := fmt.Sprintf("%s %s", tokenType, accessToken)
-	req.Header.Set("Authorization", bearerToken)
-
-	resp, err := o.client.Do(req)
-	if err != nil {
-		log.Error().Err(err).Msg("error execute recommendation request for spotify")
-		return nil, err
+	if len(p.SeedArtists) > 0 {
+		params.Set("seed_artists", strings.Join(p.SeedArtists, ","))
 	}
-	defer resp.Body.Close()
+	if len(p.SeedGenres) > 0 {
+		params.Set("seed_genres", strings.Join(p.SeedGenres, ","))
+	}
+	for name, value := range p.Tunables {
+		params.Set(name, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return params
+}
+
+func (o *outbound) GetRecommendation(ctx context.Context, params RecommendationParams) (*SpotifyRecommendationResponse, error) {
+	basePath := o.apiURL("/recommendations")
+	urlPath := fmt.Sprintf("%s?%s", basePath, params.values().Encode())
 
 	var response SpotifyRecommendationResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := o.get(ctx, urlPath, &response)
 	if err != nil {
-		log.Error().Err(err).Msg("error unmarshal recommendation response from spotify")
+		log.Error().Err(err).Msg("error get recommendation from spotify")
 		return nil, err
 	}
 	return &response, nil
@@ -47,6 +47,7 @@ func Test_outbound_GetRecommendation(t *testing.T) {
 						Album: SpotifyAlbumObject{
 							AlbumType:   "album",
 							TotalTracks: 22,
+							ID:          "6i6folBtxKV28WX3msQ4FE",
 							Images: []SpotifyAlbumImage{
 								{
 									URL: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b",
@@ -58,23 +59,31 @@ func Test_outbound_GetRecommendation(t *testing.T) {
 									URL: "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b",
 								},
 							},
-							Name: "Bohemian Rhapsody (The Original Soundtrack)",
+							Name:        "Bohemian Rhapsody (The Original Soundtrack)",
+							ReleaseDate: "2018-10-19",
 						},
 						Artists: []SpotifyArtistObject{
 							{
 								Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+								ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 								Name: "Queen",
 							},
 						},
-						Explicit: false,
-						Href:     "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
-						ID:       "3z8h0TU7ReDPLIbEnYhWZb",
-						Name:     "Bohemian Rhapsody",
+						DiscNumber:  1,
+						DurationMs:  354947,
+						Explicit:    false,
+						ExternalIDs: SpotifyExternalIDs{ISRC: "GBUM71029604"},
+						Href:        "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
+						ID:          "3z8h0TU7ReDPLIbEnYhWZb",
+						Name:        "Bohemian Rhapsody",
+						Popularity:  72,
+						TrackNumber: 7,
 					},
 					{
 						Album: SpotifyAlbumObject{
 							AlbumType:   "album",
 							TotalTracks: 12,
+							ID:          "1GbtB4zTqAsyfZEsm1RZfx",
 							Images: []SpotifyAlbumImage{
 								{
 									URL: "https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0",
@@ -86,18 +95,25 @@ func Test_outbound_GetRecommendation(t *testing.T) {
 									URL: "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0",
 								},
 							},
-							Name: "A Night At The Opera (2011 Remaster)",
+							Name:        "A Night At The Opera (2011 Remaster)",
+							ReleaseDate: "1975-11-21",
 						},
 						Artists: []SpotifyArtistObject{
 							{
 								Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+								ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 								Name: "Queen",
 							},
 						},
-						Explicit: false,
-						Href:     "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
-						ID:       "4u7EnebtmKWzUH433cf5Qv",
-						Name:     "Bohemian Rhapsody - Remastered 2011",
+						DiscNumber:  1,
+						DurationMs:  354320,
+						Explicit:    false,
+						ExternalIDs: SpotifyExternalIDs{ISRC: "GBUM71029604"},
+						Href:        "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
+						ID:          "4u7EnebtmKWzUH433cf5Qv",
+						Name:        "Bohemian Rhapsody - Remastered 2011",
+						Popularity:  80,
+						TrackNumber: 11,
 					},
 				},
 			},
@@ -178,13 +194,18 @@ func Test_outbound_GetRecommendation(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn(tt.args)
 			o := &outbound{
-				cfg:         &configs.Config{},
-				client:      mockHTTPClient,
-				AccessToken: "accessToken",
-				TokenType:   "Bearer",
-				ExpiredAt:   time.Now().Add(1 * time.Hour),
+				cfg:    &configs.Config{},
+				client: mockHTTPClient,
+				token: tokenSource{
+					accessToken: "accessToken",
+					tokenType:   "Bearer",
+					expiredAt:   time.Now().Add(1 * time.Hour),
+				},
 			}
-			got, err := o.GetRecommendation(context.Background(), tt.args.limit, tt.args.trackID)
+			got, err := o.GetRecommendation(context.Background(), RecommendationParams{
+				Limit:      tt.args.limit,
+				SeedTracks: []string{tt.args.trackID},
+			})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("outbound.GetRecommendation() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -195,3 +216,40 @@ func Test_outbound_GetRecommendation(t *testing.T) {
 		})
 	}
 }
+
+func TestRecommendationParams_values(t *testing.T) {
+	tests := []struct {
+		name   string
+		params RecommendationParams
+		want   string
+	}{
+		{
+			name: "default market",
+			params: RecommendationParams{
+				Limit:      10,
+				SeedTracks: []string{"trackA"},
+			},
+			want: "limit=10&market=ID&seed_tracks=trackA",
+		},
+		{
+			name: "combined seeds and tunables",
+			params: RecommendationParams{
+				Limit:       5,
+				Market:      "US",
+				SeedTracks:  []string{"trackA", "trackB"},
+				SeedArtists: []string{"artistA"},
+				SeedGenres:  []string{"rock", "jazz"},
+				Tunables: map[string]float64{
+					"target_energy": 0.8,
+					"min_tempo":     120,
+				},
+			},
+			want: "limit=5&market=US&min_tempo=120&seed_artists=artistA&seed_genres=rock%2Cjazz&seed_tracks=trackA%2CtrackB&target_energy=0.8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.params.values().Encode())
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultRetryBaseDelay = 200 * time.Millisecond
+	defaultRetryMaxDelay  = 5 * time.Second
+	defaultRetryBudget    = 10 * time.Second
+)
+
+type retryPolicy struct {
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	budget     time.Duration
+}
+
+func (o *outbound) retryPolicy() retryPolicy {
+	cfg := o.cfg.SpotifyConfig.Retry
+	policy := retryPolicy{
+		maxRetries: cfg.MaxRetries,
+		baseDelay:  cfg.BaseDelay,
+		maxDelay:   cfg.MaxDelay,
+		budget:     cfg.Budget,
+	}
+	if policy.baseDelay <= 0 {
+		policy.baseDelay = defaultRetryBaseDelay
+	}
+	if policy.maxDelay <= 0 {
+		policy.maxDelay = defaultRetryMaxDelay
+	}
+	if policy.budget <= 0 {
+		policy.budget = defaultRetryBudget
+	}
+	return policy
+}
+
+// backoff returns the jittered exponential delay before retry number attempt.
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.maxDelay
+	if attempt < 32 && p.baseDelay<<attempt < p.maxDelay {
+		delay = p.baseDelay << attempt
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// do executes the request built by newRequest. Rate limited, 5xx and network
+// failures are retried until the retry count or the wait budget of the call
+// runs out, every other non-2xx answer is returned as an *APIError.
+// newRequest is called once per attempt so request bodies can be replayed.
+func (o *outbound) do(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	policy := o.retryPolicy()
+
+	var waited time.Duration
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			lastErr error
+			delay   time.Duration
+		)
+		resp, err := o.client.Do(req)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = fmt.Errorf("%w: %w", ErrUnavailable, err)
+			delay = policy.backoff(attempt)
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return resp, nil
+		default:
+			apiErr := errorFromResponse(resp)
+			resp.Body.Close()
+			if !retryable(resp.StatusCode) {
+				return nil, apiErr
+			}
+			lastErr = apiErr
+			delay = apiErr.RetryAfter
+			if delay == 0 {
+				delay = policy.backoff(attempt)
+			}
+		}
+
+		if attempt >= policy.maxRetries || waited+delay > policy.budget {
+			return nil, lastErr
+		}
+
+		log.Warn().Err(lastErr).Int("attempt", attempt+1).Dur("delay", delay).Msg("retrying spotify request")
+		if err := o.wait(ctx, delay); err != nil {
+			return nil, err
+		}
+		waited += delay
+	}
+}
+
+func (o *outbound) wait(ctx context.Context, delay time.Duration) error {
+	if o.sleep != nil {
+		return o.sleep(ctx, delay)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,192 @@
+package spotify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/pkg/httpclient"
+	"go.uber.org/mock/gomock"
+)
+
+func newResponse(statusCode int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func Test_outbound_do(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockHTTPClient := httpclient.NewMockHTTPClient(mockCtrl)
+
+	tests := []struct {
+		name        string
+		retry       configs.RetryConfig
+		wantErr     error
+		wantStatus  int
+		wantDelays  []time.Duration
+		checkDelays func(t *testing.T, delays []time.Duration)
+		mockFn      func()
+	}{
+		{
+			name:       "success: no retry",
+			retry:      configs.RetryConfig{MaxRetries: 3},
+			wantStatus: http.StatusOK,
+			wantDelays: nil,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusOK, nil, `{}`), nil)
+			},
+		},
+		{
+			name:       "success: waits retry-after on 429",
+			retry:      configs.RetryConfig{MaxRetries: 3},
+			wantStatus: http.StatusOK,
+			wantDelays: []time.Duration{2 * time.Second},
+			mockFn: func() {
+				gomock.InOrder(
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"2"}}, ``), nil),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusOK, nil, `{}`), nil),
+				)
+			},
+		},
+		{
+			name:       "success: backs off on 5xx and network errors",
+			retry:      configs.RetryConfig{MaxRetries: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second},
+			wantStatus: http.StatusOK,
+			checkDelays: func(t *testing.T, delays []time.Duration) {
+				assert.Len(t, delays, 2)
+				assert.GreaterOrEqual(t, delays[0], 50*time.Millisecond)
+				assert.LessOrEqual(t, delays[0], 100*time.Millisecond)
+				assert.GreaterOrEqual(t, delays[1], 100*time.Millisecond)
+				assert.LessOrEqual(t, delays[1], 200*time.Millisecond)
+			},
+			mockFn: func() {
+				gomock.InOrder(
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusBadGateway, nil, `bad gateway`), nil),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(nil, assert.AnError),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusOK, nil, `{}`), nil),
+				)
+			},
+		},
+		{
+			name:    "failed: retries exhausted",
+			retry:   configs.RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond},
+			wantErr: ErrUpstream,
+			checkDelays: func(t *testing.T, delays []time.Duration) {
+				assert.Len(t, delays, 1)
+			},
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusInternalServerError, nil, ``), nil).Times(2)
+			},
+		},
+		{
+			name:    "failed: network error",
+			retry:   configs.RetryConfig{},
+			wantErr: ErrUnavailable,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+		{
+			name:       "failed: retry-after exceeds budget",
+			retry:      configs.RetryConfig{MaxRetries: 3, Budget: time.Second},
+			wantErr:    ErrRateLimited,
+			wantDelays: nil,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"30"}}, ``), nil)
+			},
+		},
+		{
+			name:       "failed: unauthorized is not retried",
+			retry:      configs.RetryConfig{MaxRetries: 3},
+			wantErr:    ErrUnauthorized,
+			wantDelays: nil,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusUnauthorized, nil, `{"error":{"status":401,"message":"Invalid access token"}}`), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+
+			var delays []time.Duration
+			o := &outbound{
+				cfg:    &configs.Config{SpotifyConfig: configs.SpotifyConfig{Retry: tt.retry}},
+				client: mockHTTPClient,
+				sleep: func(ctx context.Context, delay time.Duration) error {
+					delays = append(delays, delay)
+					return nil
+				},
+			}
+			resp, err := o.do(context.Background(), func() (*http.Request, error) {
+				return http.NewRequest(http.MethodGet, "https://api.spotify.com/v1/search", nil)
+			})
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			}
+			if tt.checkDelays != nil {
+				tt.checkDelays(t, delays)
+			} else {
+				assert.Equal(t, tt.wantDelays, delays)
+			}
+		})
+	}
+}
+
+func Test_errorFromResponse(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		resp           *http.Response
+		wantKind       error
+		wantMessage    string
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:           "web api error",
+			resp:           newResponse(http.StatusTooManyRequests, http.Header{"Retry-After": []string{"5"}}, `{"error":{"status":429,"message":"API rate limit exceeded"}}`),
+			wantKind:       ErrRateLimited,
+			wantMessage:    "API rate limit exceeded",
+			wantRetryAfter: 5 * time.Second,
+		},
+		{
+			name:        "accounts error",
+			resp:        newResponse(http.StatusBadRequest, nil, `{"error":"invalid_client","error_description":"Invalid client secret"}`),
+			wantKind:    ErrBadRequest,
+			wantMessage: "invalid_client: Invalid client secret",
+		},
+		{
+			name:        "plain text",
+			resp:        newResponse(http.StatusServiceUnavailable, nil, `Service Unavailable`),
+			wantKind:    ErrUpstream,
+			wantMessage: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errorFromResponse(tt.resp)
+			assert.True(t, errors.Is(got, tt.wantKind))
+			assert.Equal(t, tt.wantMessage, got.Message)
+			assert.Equal(t, tt.wantRetryAfter, got.RetryAfter)
+		})
+	}
+
+	assert.Equal(t, 90*time.Second, parseRetryAfter(now.Add(90*time.Second).UTC().Format(http.TimeFormat), now.Truncate(time.Second)))
+}
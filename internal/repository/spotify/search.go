@@ -2,9 +2,7 @@ package spotify
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"net/url"
 	"strconv"
 
@@ -30,19 +28,31 @@ type SpotifyTracks struct {
 }
 
 type SpotifyTrackObject struct {
-	Album    SpotifyAlbumObject    `json:"album"`
-	Artists  []SpotifyArtistObject `json:"artists"`
-	Explicit bool                  `json:"explicit"`
-	Href     string                `json:"href"`
-	ID       string                `json:"id"`
-	Name     string                `json:"name"`
+	Album       SpotifyAlbumObject    `json:"album"`
+	Artists     []SpotifyArtistObject `json:"artists"`
+	DiscNumber  int                   `json:"disc_number"`
+	DurationMs  int                   `json:"duration_ms"`
+	Explicit    bool                  `json:"explicit"`
+	ExternalIDs SpotifyExternalIDs    `json:"external_ids"`
+	Href        string                `json:"href"`
+	ID          string                `json:"id"`
+	Name        string                `json:"name"`
+	Popularity  int                   `json:"popularity"`
+	PreviewURL  *string               `json:"preview_url"`
+	TrackNumber int                   `json:"track_number"`
+}
+
+type SpotifyExternalIDs struct {
+	ISRC string `json:"isrc"`
 }
 
 type SpotifyAlbumObject struct {
 	AlbumType   string              `json:"album_type"`
 	TotalTracks int                 `json:"total_tracks"`
+	ID          string              `json:"id"`
 	Images      []SpotifyAlbumImage `json:"images"`
 	Name        string              `json:"name"`
+	ReleaseDate string              `json:"release_date"`
 }
 
 type SpotifyAlbumImage struct {
@@ -51,44 +61,27 @@ type SpotifyAlbumImage struct {
 
 type SpotifyArtistObject struct {
 	Href string `json:"href"`
+	ID   string `json:"id"`
 	Name string `json:"name"`
 }
 
-func (o *outbound) Search(ctx context.Context, query string, limit, offset int) (*SpotifySearchResponse, error) {
+func (o *outbound) Search(ctx context.Context, query string, limit, offset int, market string) (*SpotifySearchResponse, error) {
 	params := url.Values{}
 	params.Set("q", query)
 	params.Set("type", "track")
 	params.Set("limit", strconv.Itoa(limit))
 	params.Set("offset", strconv.Itoa(offset))
-
-	basePath := `https://api.spotify.com/v1/search`
-	urlPath := fmt.Sprintf("%s?%s", basePath, params.Encode())
-	req, err := http.NewRequest(http.MethodGet, urlPath, nil)
-	if err != nil {
-		log.Error().Err(err).Msg("error create search request for spotify")
-		return nil, err
+	if market != "" {
+		params.Set("market", market)
 	}
 
-	accessToken, tokenType, err := o.GetTokenDetails()
-	if err != nil {
-		log.Error().Err(err).Msg("error get token details")
-		return nil, err
-	}
-
-	bearerToken := fmt.Sprintf("%s %s", tokenType, accessToken)
-	req.Header.Set("Authorization", bearerToken)
-
-	resp, err := o.client.Do(req)
-	if err != nil {
-		log.Error().Err(err).Msg("error execute search request for spotify")
-		return nil, err
-	}
-	defer resp.Body.Close()
+	basePath := o.apiURL("/search")
+	urlPath := fmt.Sprintf("%s?%s", basePath, params.Encode())
 
 	var response SpotifySearchResponse
-	err = json.NewDecoder(resp.Body).Decode(&response)
+	err := o.get(ctx, urlPath, &response)
 	if err != nil {
-		log.Error().Err(err).Msg("error unmarshal search response from spotify")
+		log.Error().Err(err).Msg("error search track to spotify")
 		return nil, err
 	}
 	return &response, nil
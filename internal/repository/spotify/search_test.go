@@ -29,6 +29,7 @@ func Test_outbound_Search(t *testing.T) {
 		query  string
 		limit  int
 		offset int
+		market string
 	}
 	tests := []struct {
 		name    string
@@ -56,6 +57,7 @@ func Test_outbound_Search(t *testing.T) {
 							Album: SpotifyAlbumObject{
 								AlbumType:   "album",
 								TotalTracks: 22,
+								ID:          "6i6folBtxKV28WX3msQ4FE",
 								Images: []SpotifyAlbumImage{
 									{
 										URL: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b",
@@ -67,23 +69,31 @@ func Test_outbound_Search(t *testing.T) {
 										URL: "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b",
 									},
 								},
-								Name: "Bohemian Rhapsody (The Original Soundtrack)",
+								Name:        "Bohemian Rhapsody (The Original Soundtrack)",
+								ReleaseDate: "2018-10-19",
 							},
 							Artists: []SpotifyArtistObject{
 								{
 									Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+									ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 									Name: "Queen",
 								},
 							},
-							Explicit: false,
-							Href:     "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
-							ID:       "3z8h0TU7ReDPLIbEnYhWZb",
-							Name:     "Bohemian Rhapsody",
+							DiscNumber:  1,
+							DurationMs:  354947,
+							Explicit:    false,
+							ExternalIDs: SpotifyExternalIDs{ISRC: "GBUM71029604"},
+							Href:        "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
+							ID:          "3z8h0TU7ReDPLIbEnYhWZb",
+							Name:        "Bohemian Rhapsody",
+							Popularity:  72,
+							TrackNumber: 7,
 						},
 						{
 							Album: SpotifyAlbumObject{
 								AlbumType:   "album",
 								TotalTracks: 12,
+								ID:          "1GbtB4zTqAsyfZEsm1RZfx",
 								Images: []SpotifyAlbumImage{
 									{
 										URL: "https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0",
@@ -95,18 +105,25 @@ func Test_outbound_Search(t *testing.T) {
 										URL: "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0",
 									},
 								},
-								Name: "A Night At The Opera (2011 Remaster)",
+								Name:        "A Night At The Opera (2011 Remaster)",
+								ReleaseDate: "1975-11-21",
 							},
 							Artists: []SpotifyArtistObject{
 								{
 									Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+									ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 									Name: "Queen",
 								},
 							},
-							Explicit: false,
-							Href:     "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
-							ID:       "4u7EnebtmKWzUH433cf5Qv",
-							Name:     "Bohemian Rhapsody - Remastered 2011",
+							DiscNumber:  1,
+							DurationMs:  354320,
+							Explicit:    false,
+							ExternalIDs: SpotifyExternalIDs{ISRC: "GBUM71029604"},
+							Href:        "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
+							ID:          "4u7EnebtmKWzUH433cf5Qv",
+							Name:        "Bohemian Rhapsody - Remastered 2011",
+							Popularity:  80,
+							TrackNumber: 11,
 						},
 					},
 				},
@@ -165,13 +182,15 @@ func Test_outbound_Search(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn(tt.args)
 			o := &outbound{
-				cfg:         &configs.Config{},
-				client:      mockHTTPClient,
-				AccessToken: "accessToken",
-				TokenType:   "Bearer",
-				ExpiredAt:   time.Now().Add(1 * time.Hour),
+				cfg:    &configs.Config{},
+				client: mockHTTPClient,
+				token: tokenSource{
+					accessToken: "accessToken",
+					tokenType:   "Bearer",
+					expiredAt:   time.Now().Add(1 * time.Hour),
+				},
 			}
-			got, err := o.Search(context.Background(), tt.args.query, tt.args.limit, tt.args.offset)
+			got, err := o.Search(context.Background(), tt.args.query, tt.args.limit, tt.args.offset, tt.args.market)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("outbound.Search() error = %v, wantErr %v", err, tt.wantErr)
 				return
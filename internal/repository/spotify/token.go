@@ -1,33 +1,107 @@
 package spotify
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/singleflight"
 )
 
+const defaultTokenRefreshSkew = 30 * time.Second
+
 type SpotifyTokenResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
 	ExpiresIn   int    `json:"expires_in"`
 }
 
-func (o *outbound) GetTokenDetails() (string, string, error) {
-	if o.AccessToken == "" || time.Now().After(o.ExpiredAt) {
-		// call spotify api token here
-		err := o.generateToken()
+// tokenSource caches the client credentials token. It is safe for
+// concurrent use, refreshes are collapsed into a single accounts call by
+// GetTokenDetails.
+type tokenSource struct {
+	mu          sync.RWMutex
+	accessToken string
+	tokenType   string
+	expiredAt   time.Time
+
+	refresh singleflight.Group
+}
+
+// get returns the cached token when it is still valid at the given time.
+func (t *tokenSource) get(at time.Time) (string, string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.accessToken == "" || !at.Before(t.expiredAt) {
+		return "", "", false
+	}
+	return t.accessToken, t.tokenType, true
+}
+
+func (t *tokenSource) set(accessToken, tokenType string, expiredAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.accessToken = accessToken
+	t.tokenType = tokenType
+	t.expiredAt = expiredAt
+}
+
+// invalidate drops accessToken, unless it was already replaced by a newer one.
+func (t *tokenSource) invalidate(accessToken string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.accessToken == accessToken {
+		t.accessToken = ""
+		t.expiredAt = time.Time{}
+	}
+}
+
+func (o *outbound) tokenRefreshSkew() time.Duration {
+	if o.cfg.SpotifyConfig.TokenRefreshSkew > 0 {
+		return o.cfg.SpotifyConfig.TokenRefreshSkew
+	}
+	return defaultTokenRefreshSkew
+}
+
+func (o *outbound) GetTokenDetails(ctx context.Context) (string, string, error) {
+	validUntil := time.Now().Add(o.tokenRefreshSkew())
+	if accessToken, tokenType, ok := o.token.get(validUntil); ok {
+		return accessToken, tokenType, nil
+	}
+
+	// the refresh is shared by every waiting caller, so it must not be
+	// cancelled by whichever request happened to start it.
+	refreshCtx := context.WithoutCancel(ctx)
+	result := o.token.refresh.DoChan("token", func() (interface{}, error) {
+		if accessToken, tokenType, ok := o.token.get(time.Now().Add(o.tokenRefreshSkew())); ok {
+			return [2]string{accessToken, tokenType}, nil
+		}
+		response, err := o.generateToken(refreshCtx)
 		if err != nil {
-			return "", "", err
+			return nil, err
 		}
+		o.token.set(response.AccessToken, response.TokenType, time.Now().Add(time.Duration(response.ExpiresIn)*time.Second))
+		return [2]string{response.AccessToken, response.TokenType}, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return "", "", ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return "", "", res.Err
+		}
+		token := res.Val.([2]string)
+		return token[0], token[1], nil
 	}
-	return o.AccessToken, o.TokenType, nil
 }
 
-func (o *outbound) generateToken() error {
+func (o *outbound) generateToken(ctx context.Context) (*SpotifyTokenResponse, error) {
 	formData := url.Values{}
 	formData.Set("grant_type", "client_credentials")
 	formData.Set("client_id", o.cfg.SpotifyConfig.ClientID)
@@ -35,18 +109,17 @@ func (o *outbound) generateToken() error {
 
 	encodedURL := formData.Encode()
 
-	req, err := http.NewRequest(http.MethodPost, `https://accounts.spotify.com/api/token`, strings.NewReader(encodedURL))
-	if err != nil {
-		log.Error().Err(err).Msg("error create request for spotify")
-		return err
-	}
-
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-
-	resp, err := o.client.Do(req)
+	resp, err := o.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.accountsURL("/api/token"), strings.NewReader(encodedURL))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	})
 	if err != nil {
-		log.Error().Err(err).Msg("error execute request for spotify")
-		return err
+		log.Error().Err(err).Msg("error execute token request for spotify")
+		return nil, err
 	}
 	defer resp.Body.Close()
 
@@ -54,11 +127,7 @@ func (o *outbound) generateToken() error {
 	err = json.NewDecoder(resp.Body).Decode(&response)
 	if err != nil {
 		log.Error().Err(err).Msg("error unmarshal response from spotify")
-		return err
+		return nil, err
 	}
-
-	o.AccessToken = response.AccessToken
-	o.TokenType = response.TokenType
-	o.ExpiredAt = time.Now().Add(time.Duration(response.ExpiresIn) * time.Second)
-	return nil
+	return &response, nil
 }
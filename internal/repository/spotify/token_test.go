@@ -0,0 +1,177 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/pkg/httpclient"
+	"go.uber.org/mock/gomock"
+)
+
+func isTokenRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && req.URL.Path == "/api/token"
+}
+
+func Test_outbound_GetTokenDetails(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockHTTPClient := httpclient.NewMockHTTPClient(mockCtrl)
+
+	tests := []struct {
+		name            string
+		expiredAt       time.Time
+		wantAccessToken string
+		wantErr         bool
+		mockFn          func()
+	}{
+		{
+			name:            "success: cached token",
+			expiredAt:       time.Now().Add(time.Hour),
+			wantAccessToken: "accessToken",
+			mockFn:          func() {},
+		},
+		{
+			name:            "success: refresh inside skew",
+			expiredAt:       time.Now().Add(10 * time.Second),
+			wantAccessToken: "newAccessToken",
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).
+					Return(newResponse(http.StatusOK, nil, `{"access_token":"newAccessToken","token_type":"Bearer","expires_in":3600}`), nil)
+			},
+		},
+		{
+			name:      "failed: accounts error",
+			expiredAt: time.Now().Add(-time.Minute),
+			wantErr:   true,
+			mockFn: func() {
+				mockHTTPClient.EXPECT().Do(gomock.Any()).
+					Return(newResponse(http.StatusBadRequest, nil, `{"error":"invalid_client"}`), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			o := &outbound{
+				cfg:    &configs.Config{SpotifyConfig: configs.SpotifyConfig{TokenRefreshSkew: 30 * time.Second}},
+				client: mockHTTPClient,
+				token: tokenSource{
+					accessToken: "accessToken",
+					tokenType:   "Bearer",
+					expiredAt:   tt.expiredAt,
+				},
+			}
+			accessToken, tokenType, err := o.GetTokenDetails(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("outbound.GetTokenDetails() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr {
+				assert.Equal(t, tt.wantAccessToken, accessToken)
+				assert.Equal(t, "Bearer", tokenType)
+			}
+		})
+	}
+}
+
+func Test_outbound_GetTokenDetails_concurrentRefresh(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockHTTPClient := httpclient.NewMockHTTPClient(mockCtrl)
+	mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+		// keep the refresh in flight long enough for every caller to queue up
+		time.Sleep(50 * time.Millisecond)
+		return newResponse(http.StatusOK, nil, `{"access_token":"accessToken","token_type":"Bearer","expires_in":3600}`), nil
+	}).Times(1)
+
+	o := &outbound{
+		cfg:    &configs.Config{},
+		client: mockHTTPClient,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			accessToken, _, err := o.GetTokenDetails(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, "accessToken", accessToken)
+		}()
+	}
+	wg.Wait()
+}
+
+func Test_outbound_get_revokedToken(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockHTTPClient := httpclient.NewMockHTTPClient(mockCtrl)
+
+	unauthorized := `{"error":{"status":401,"message":"The access token expired"}}`
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: refetch token and retry once",
+			mockFn: func() {
+				gomock.InOrder(
+					mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal(t, "Bearer revokedToken", req.Header.Get("Authorization"))
+						return newResponse(http.StatusUnauthorized, nil, unauthorized), nil
+					}),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.True(t, isTokenRequest(req))
+						return newResponse(http.StatusOK, nil, `{"access_token":"freshToken","token_type":"Bearer","expires_in":3600}`), nil
+					}),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+						assert.Equal(t, "Bearer freshToken", req.Header.Get("Authorization"))
+						return newResponse(http.StatusOK, nil, `{"tracks":[]}`), nil
+					}),
+				)
+			},
+		},
+		{
+			name:    "failed: still unauthorized after refetch",
+			wantErr: ErrUnauthorized,
+			mockFn: func() {
+				gomock.InOrder(
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusUnauthorized, nil, unauthorized), nil),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusOK, nil, `{"access_token":"freshToken","token_type":"Bearer","expires_in":3600}`), nil),
+					mockHTTPClient.EXPECT().Do(gomock.Any()).Return(newResponse(http.StatusUnauthorized, nil, unauthorized), nil),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			o := &outbound{
+				cfg:    &configs.Config{},
+				client: mockHTTPClient,
+				token: tokenSource{
+					accessToken: "revokedToken",
+					tokenType:   "Bearer",
+					expiredAt:   time.Now().Add(time.Hour),
+				},
+			}
+			var response SpotifyRecommendationResponse
+			err := o.get(context.Background(), "https://api.spotify.com/v1/recommendations", &response)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
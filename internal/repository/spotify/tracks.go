@@ -0,0 +1,65 @@
+package spotify
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// maxTracksPerRequest is the id limit of spotify's several tracks api.
+	maxTracksPerRequest  = 50
+	getTracksConcurrency = 4
+)
+
+type SpotifySeveralTracksResponse struct {
+	Tracks []*SpotifyTrackObject `json:"tracks"`
+}
+
+func (o *outbound) GetTrack(ctx context.Context, trackID string) (*SpotifyTrackObject, error) {
+	urlPath := o.apiURL("/tracks/" + url.PathEscape(trackID))
+
+	var response SpotifyTrackObject
+	err := o.get(ctx, urlPath, &response)
+	if err != nil {
+		log.Error().Err(err).Str("trackID", trackID).Msg("error get track from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetTracks fetches the given tracks in chunks of 50 ids. The result is
+// aligned with trackIDs, ids unknown to spotify are left nil.
+func (o *outbound) GetTracks(ctx context.Context, trackIDs []string) ([]*SpotifyTrackObject, error) {
+	tracks := make([]*SpotifyTrackObject, len(trackIDs))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(getTracksConcurrency)
+	for start := 0; start < len(trackIDs); start += maxTracksPerRequest {
+		end := min(start+maxTracksPerRequest, len(trackIDs))
+		chunk := trackIDs[start:end]
+		result := tracks[start:end]
+
+		g.Go(func() error {
+			params := url.Values{}
+			params.Set("ids", strings.Join(chunk, ","))
+			urlPath := fmt.Sprintf("%s?%s", o.apiURL("/tracks"), params.Encode())
+
+			var response SpotifySeveralTracksResponse
+			if err := o.get(ctx, urlPath, &response); err != nil {
+				return err
+			}
+			copy(result, response.Tracks)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		log.Error().Err(err).Int("count", len(trackIDs)).Msg("error get tracks from spotify")
+		return nil, err
+	}
+	return tracks, nil
+}
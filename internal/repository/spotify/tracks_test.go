@@ -0,0 +1,77 @@
+package spotify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_outbound_GetTrack(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	tests := []struct {
+		name     string
+		trackID  string
+		wantName string
+		wantErr  error
+	}{
+		{
+			name:     "success",
+			trackID:  "3z8h0TU7ReDPLIbEnYhWZb",
+			wantName: "Bohemian Rhapsody",
+		},
+		{
+			name:    "failed: not found",
+			trackID: "unknown",
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := o.GetTrack(context.Background(), tt.trackID)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "error = %v, want %v", err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.trackID, got.ID)
+			assert.Equal(t, tt.wantName, got.Name)
+			assert.Equal(t, "Bohemian Rhapsody (The Original Soundtrack)", got.Album.Name)
+		})
+	}
+}
+
+func Test_outbound_GetTracks(t *testing.T) {
+	o := newFakeOutbound(t)
+
+	// 120 ids span three chunks, every third one unknown to spotify
+	known := []string{"3z8h0TU7ReDPLIbEnYhWZb", "0DiWol3AO6WpXZgp0goxAV"}
+	trackIDs := make([]string, 120)
+	for idx := range trackIDs {
+		if idx%3 == 2 {
+			trackIDs[idx] = fmt.Sprintf("unknown%d", idx)
+			continue
+		}
+		trackIDs[idx] = known[idx%3]
+	}
+
+	got, err := o.GetTracks(context.Background(), trackIDs)
+	assert.NoError(t, err)
+	assert.Len(t, got, len(trackIDs))
+	for idx, track := range got {
+		if idx%3 == 2 {
+			assert.Nil(t, track, "index %d", idx)
+			continue
+		}
+		if assert.NotNil(t, track, "index %d", idx) {
+			assert.Equal(t, trackIDs[idx], track.ID)
+		}
+	}
+
+	got, err = o.GetTracks(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
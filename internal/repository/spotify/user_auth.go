@@ -0,0 +1,131 @@
+package spotify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SpotifyUserTokenResponse is the answer to an authorization code exchange,
+// the tokens act on behalf of the user who approved the request.
+type SpotifyUserTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scope        string `json:"scope"`
+}
+
+type SpotifyUser struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+}
+
+// AuthorizeURL is where the user is sent to approve the app, using the
+// authorization code flow with a S256 PKCE challenge.
+func (o *outbound) AuthorizeURL(state, codeChallenge, redirectURI string, scopes []string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", o.cfg.SpotifyConfig.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("state", state)
+	query.Set("code_challenge_method", "S256")
+	query.Set("code_challenge", codeChallenge)
+	if len(scopes) > 0 {
+		query.Set("scope", strings.Join(scopes, " "))
+	}
+	return o.accountsURL("/authorize") + "?" + query.Encode()
+}
+
+// ExchangeCode trades the code spotify redirected back with for the user's
+// tokens. The verifier proves the exchange comes from whoever started the
+// flow, so no client secret is sent.
+func (o *outbound) ExchangeCode(ctx context.Context, code, codeVerifier, redirectURI string) (*SpotifyUserTokenResponse, error) {
+	formData := url.Values{}
+	formData.Set("grant_type", "authorization_code")
+	formData.Set("code", code)
+	formData.Set("redirect_uri", redirectURI)
+	formData.Set("client_id", o.cfg.SpotifyConfig.ClientID)
+	formData.Set("code_verifier", codeVerifier)
+	return o.userToken(ctx, formData)
+}
+
+// RefreshUserToken gets a new access token for a user. The refresh token in
+// the answer is empty unless spotify rotated it.
+func (o *outbound) RefreshUserToken(ctx context.Context, refreshToken string) (*SpotifyUserTokenResponse, error) {
+	formData := url.Values{}
+	formData.Set("grant_type", "refresh_token")
+	formData.Set("refresh_token", refreshToken)
+	formData.Set("client_id", o.cfg.SpotifyConfig.ClientID)
+	return o.userToken(ctx, formData)
+}
+
+func (o *outbound) userToken(ctx context.Context, formData url.Values) (*SpotifyUserTokenResponse, error) {
+	encodedURL := formData.Encode()
+
+	resp, err := o.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.accountsURL("/api/token"), strings.NewReader(encodedURL))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("grantType", formData.Get("grant_type")).Msg("error execute user token request for spotify")
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response SpotifyUserTokenResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		log.Error().Err(err).Msg("error unmarshal response from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetCurrentUser returns the profile of the user the access token belongs to.
+func (o *outbound) GetCurrentUser(ctx context.Context, accessToken string) (*SpotifyUser, error) {
+	var response SpotifyUser
+	err := o.getAsUser(ctx, accessToken, o.apiURL("/me"), &response)
+	if err != nil {
+		log.Error().Err(err).Msg("error get current user from spotify")
+		return nil, err
+	}
+	return &response, nil
+}
+
+// getAsUser performs a GET with the access token of a user rather than the
+// client credentials token. It is not retried with a new token on 401, the
+// caller owns the user's token.
+func (o *outbound) getAsUser(ctx context.Context, accessToken, urlPath string, response interface{}) error {
+	return o.sendAsUser(ctx, http.MethodGet, accessToken, urlPath, response)
+}
+
+// sendAsUser is getAsUser for any method. A nil response discards the body.
+func (o *outbound) sendAsUser(ctx context.Context, method, accessToken, urlPath string, response interface{}) error {
+	resp, err := o.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, urlPath, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if response == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(response)
+}
@@ -0,0 +1,142 @@
+package spotify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/pkg/fakespotify"
+	"github.com/xprasetio/go-spotify/pkg/httpclient"
+)
+
+func Test_outbound_userAuthorization(t *testing.T) {
+	o := newFakeOutbound(t)
+	const (
+		redirectURI = "http://localhost:9999/memberships/oauth/spotify/callback"
+		verifier    = "verifier-with-enough-entropy-for-the-test"
+	)
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	authorizeURL := o.AuthorizeURL("state", challenge, redirectURI, []string{"user-library-read"})
+
+	// follow the browser to the authorize page, which approves right away
+	client := &http.Client{CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authorizeURL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	assert.NoError(t, err)
+	assert.Equal(t, "state", location.Query().Get("state"))
+	code := location.Query().Get("code")
+	assert.NotEmpty(t, code)
+
+	_, err = o.ExchangeCode(context.Background(), code, "wrong verifier", redirectURI)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	// the failed attempt used the code up
+	_, err = o.ExchangeCode(context.Background(), code, verifier, redirectURI)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	resp, err = client.Get(authorizeURL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	location, err = url.Parse(resp.Header.Get("Location"))
+	assert.NoError(t, err)
+
+	token, err := o.ExchangeCode(context.Background(), location.Query().Get("code"), verifier, redirectURI)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token.AccessToken)
+	assert.NotEmpty(t, token.RefreshToken)
+	assert.Equal(t, "user-library-read", token.Scope)
+
+	user, err := o.GetCurrentUser(context.Background(), token.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, &SpotifyUser{ID: "fakeuser", DisplayName: "Fake User", Email: "fakeuser@example.com"}, user)
+
+	_, err = o.GetCurrentUser(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+}
+
+func Test_outbound_GetSavedTracks(t *testing.T) {
+	server := fakespotify.NewServer(
+		fakespotify.WithTracks(fakespotify.DefaultCatalog()...),
+		fakespotify.WithClientCredentials("clientID", "clientSecret"),
+		fakespotify.WithSavedTracks("fakeuser", "3z8h0TU7ReDPLIbEnYhWZb", "unknownTrack"),
+	)
+	t.Cleanup(server.Close)
+	o := NewSpotifyOutbound(&configs.Config{
+		SpotifyConfig: configs.SpotifyConfig{
+			ClientID:        "clientID",
+			APIBaseURL:      server.APIBaseURL(),
+			AccountsBaseURL: server.AccountsBaseURL(),
+		},
+	}, httpclient.NewClient(&http.Client{}))
+
+	_, err := o.RefreshUserToken(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	token, err := o.RefreshUserToken(context.Background(), server.AuthorizeUser("fakeuser"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, token.AccessToken)
+	assert.Empty(t, token.RefreshToken)
+
+	page, err := o.GetSavedTracks(context.Background(), token.AccessToken, 1, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	assert.NotNil(t, page.Next)
+	assert.Len(t, page.Items, 1)
+	assert.Equal(t, "3z8h0TU7ReDPLIbEnYhWZb", page.Items[0].Track.ID)
+	assert.Equal(t, "Bohemian Rhapsody", page.Items[0].Track.Name)
+	assert.False(t, page.Items[0].AddedAt.IsZero())
+
+	page, err = o.GetSavedTracks(context.Background(), token.AccessToken, 1, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, page.Next)
+	assert.Equal(t, "unknownTrack", page.Items[0].Track.ID)
+
+	_, err = o.GetSavedTracks(context.Background(), "unknown", 1, 0)
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+}
+
+func Test_outbound_SaveAndRemoveSavedTracks(t *testing.T) {
+	server := fakespotify.NewServer(
+		fakespotify.WithTracks(fakespotify.DefaultCatalog()...),
+		fakespotify.WithClientCredentials("clientID", "clientSecret"),
+		fakespotify.WithSavedTracks("fakeuser", "3z8h0TU7ReDPLIbEnYhWZb"),
+	)
+	t.Cleanup(server.Close)
+	o := NewSpotifyOutbound(&configs.Config{
+		SpotifyConfig: configs.SpotifyConfig{
+			ClientID:        "clientID",
+			APIBaseURL:      server.APIBaseURL(),
+			AccountsBaseURL: server.AccountsBaseURL(),
+		},
+	}, httpclient.NewClient(&http.Client{}))
+
+	token, err := o.RefreshUserToken(context.Background(), server.AuthorizeUser("fakeuser"))
+	assert.NoError(t, err)
+
+	err = o.SaveTracks(context.Background(), token.AccessToken, []string{"0DiWol3AO6WpXZgp0goxAV", "3z8h0TU7ReDPLIbEnYhWZb"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0DiWol3AO6WpXZgp0goxAV", "3z8h0TU7ReDPLIbEnYhWZb"}, server.SavedTracks("fakeuser"))
+
+	err = o.RemoveSavedTracks(context.Background(), token.AccessToken, []string{"3z8h0TU7ReDPLIbEnYhWZb"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0DiWol3AO6WpXZgp0goxAV"}, server.SavedTracks("fakeuser"))
+
+	err = o.SaveTracks(context.Background(), token.AccessToken, nil)
+	assert.True(t, errors.Is(err, ErrBadRequest))
+
+	err = o.RemoveSavedTracks(context.Background(), "unknown", []string{"0DiWol3AO6WpXZgp0goxAV"})
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+}
@@ -0,0 +1,136 @@
+package trackactivities
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrImportClaimLost is returned when another worker took the job over.
+var ErrImportClaimLost = errors.New("import job was claimed by another worker")
+
+// CreateImportJob returns gorm.ErrDuplicatedKey when the user has an active job.
+func (r *repository) CreateImportJob(ctx context.Context, model *trackactivities.ImportJob) error {
+	return r.db.Create(model).Error
+}
+
+func (r *repository) GetImportJob(ctx context.Context, id uint) (*trackactivities.ImportJob, error) {
+	job := trackactivities.ImportJob{}
+	res := r.db.Where("id = ?", id).First(&job)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &job, nil
+}
+
+// GetActiveImportJob returns the pending or running job of the user.
+func (r *repository) GetActiveImportJob(ctx context.Context, userID uint) (*trackactivities.ImportJob, error) {
+	job := trackactivities.ImportJob{}
+	res := r.db.Where("user_id = ?", userID).
+		Where("status IN ?", []string{trackactivities.ImportStatusPending, trackactivities.ImportStatusRunning}).
+		First(&job)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return &job, nil
+}
+
+// ClaimImportJob leases the oldest pending or abandoned job until leaseUntil.
+func (r *repository) ClaimImportJob(ctx context.Context, now, leaseUntil time.Time) (*trackactivities.ImportJob, error) {
+	job := trackactivities.ImportJob{}
+	res := r.db.Raw(`UPDATE import_jobs SET status = ?, attempt = attempt + 1, lease_until = ?, updated_at = ?
+		WHERE id = (
+			SELECT id FROM import_jobs
+			WHERE deleted_at IS NULL AND (status = ? OR (status = ? AND lease_until < ?))
+			ORDER BY id LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		trackactivities.ImportStatusRunning, leaseUntil, now,
+		trackactivities.ImportStatusPending, trackactivities.ImportStatusRunning, now,
+	).Scan(&job)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &job, nil
+}
+
+// SaveImportPage likes a page of saved tracks and moves the job past it in one transaction.
+func (r *repository) SaveImportPage(ctx context.Context, job trackactivities.ImportJob, activities []trackactivities.TrackActivity, leaseUntil time.Time) (int, error) {
+	imported := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		imported, err = upsertRemoteLikes(tx, activities)
+		if err != nil {
+			return err
+		}
+
+		res := tx.Model(&trackactivities.ImportJob{}).
+			Where("id = ?", job.ID).Where("attempt = ?", job.Attempt).Where("status = ?", trackactivities.ImportStatusRunning).
+			Updates(map[string]interface{}{
+				"offset":      job.Offset,
+				"total":       job.Total,
+				"imported":    gorm.Expr("imported + ?", imported),
+				"lease_until": leaseUntil,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != 1 {
+			return ErrImportClaimLost
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return imported, nil
+}
+
+// upsertRemoteLikes stores likes made on spotify unless changed here since.
+func upsertRemoteLikes(tx *gorm.DB, activities []trackactivities.TrackActivity) (int, error) {
+	if len(activities) == 0 {
+		return 0, nil
+	}
+	res := tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}, {Name: "spotify_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"is_liked":   true,
+			"updated_at": gorm.Expr("excluded.updated_at"),
+			"updated_by": gorm.Expr("excluded.updated_by"),
+		}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("track_activities.updated_at < excluded.updated_at AND track_activities.is_liked IS DISTINCT FROM true"),
+		}},
+	}).Create(&activities)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return int(res.RowsAffected), nil
+}
+
+// FinishImportJob marks the job completed or failed.
+func (r *repository) FinishImportJob(ctx context.Context, job trackactivities.ImportJob, status, errMessage string, finishedAt time.Time) error {
+	res := r.db.Model(&trackactivities.ImportJob{}).
+		Where("id = ?", job.ID).Where("attempt = ?", job.Attempt).Where("status = ?", trackactivities.ImportStatusRunning).
+		Updates(map[string]interface{}{
+			"status":      status,
+			"error":       errMessage,
+			"finished_at": finishedAt,
+			"lease_until": nil,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return ErrImportClaimLost
+	}
+	return nil
+}
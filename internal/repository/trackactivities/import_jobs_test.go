@@ -0,0 +1,142 @@
+package trackactivities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_ClaimImportJob(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		want    *trackactivities.ImportJob
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: &trackactivities.ImportJob{
+				Model:  gorm.Model{ID: 1},
+				UserID: 2, Status: "running", Offset: 50, Attempt: 2,
+			},
+			mockFn: func() {
+				mock.ExpectQuery(`UPDATE import_jobs SET status = \$1, attempt = attempt \+ 1, lease_until = \$2, updated_at = \$3\s+WHERE id = \(\s+SELECT id FROM import_jobs\s+WHERE deleted_at IS NULL AND \(status = \$4 OR \(status = \$5 AND lease_until < \$6\)\)\s+ORDER BY id LIMIT 1\s+FOR UPDATE SKIP LOCKED\s+\)\s+RETURNING \*`).
+					WithArgs("running", leaseUntil, now, "pending", "running", now).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "offset", "attempt"}).
+						AddRow(1, 2, "running", 50, 2))
+			},
+		},
+		{
+			name:    "success: nothing to do",
+			wantErr: gorm.ErrRecordNotFound,
+			mockFn: func() {
+				mock.ExpectQuery(`UPDATE import_jobs (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mock.ExpectQuery(`UPDATE import_jobs (.+)`).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.ClaimImportJob(context.Background(), now, leaseUntil)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_SaveImportPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	addedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaseUntil := time.Now().Add(time.Minute)
+	isLiked := true
+	job := trackactivities.ImportJob{Model: gorm.Model{ID: 1}, Offset: 2, Total: 3, Attempt: 2}
+	activities := []trackactivities.TrackActivity{
+		{Model: gorm.Model{CreatedAt: addedAt, UpdatedAt: addedAt}, UserID: 2, SpotifyID: "track1", IsLiked: &isLiked, CreatedBy: "2", UpdatedBy: "2"},
+		{Model: gorm.Model{CreatedAt: addedAt, UpdatedAt: addedAt}, UserID: 2, SpotifyID: "track2", IsLiked: &isLiked, CreatedBy: "2", UpdatedBy: "2"},
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: 1,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "track_activities" (.+) VALUES (.+),(.+) ON CONFLICT \("user_id","spotify_id"\) DO UPDATE SET "is_liked"=\$17,"updated_at"=excluded.updated_at,"updated_by"=excluded.updated_by WHERE track_activities.updated_at < excluded.updated_at AND track_activities.is_liked IS DISTINCT FROM true RETURNING "id"`).
+					WithArgs(
+						addedAt, addedAt, nil, 2, "track1", true, "2", "2",
+						addedAt, addedAt, nil, 2, "track2", true, "2", "2",
+						true,
+					).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec(`UPDATE "import_jobs" SET "imported"=imported \+ \$1,"lease_until"=\$2,"offset"=\$3,"total"=\$4,"updated_at"=\$5 WHERE id = \$6 AND attempt = \$7 AND status = \$8 AND "import_jobs"."deleted_at" IS NULL`).
+					WithArgs(1, leaseUntil, 2, 3, sqlmock.AnyArg(), 1, 2, "running").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: claimed by another worker",
+			wantErr: ErrImportClaimLost,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`INSERT INTO "track_activities" (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectExec(`UPDATE "import_jobs" (.+)`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.SaveImportPage(context.Background(), job, activities, leaseUntil)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package trackactivities
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"gorm.io/gorm"
+)
+
+// ErrLikeSyncClaimLost is returned when another worker claimed the like sync.
+var ErrLikeSyncClaimLost = errors.New("like sync was claimed by another worker")
+
+func createLikeSync(tx *gorm.DB, likeSync *trackactivities.LikeSync) error {
+	if likeSync == nil {
+		return nil
+	}
+	return tx.Create(likeSync).Error
+}
+
+// ClaimLikeSyncs leases up to limit due like syncs of one user until leaseUntil.
+func (r *repository) ClaimLikeSyncs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]trackactivities.LikeSync, error) {
+	syncs := make([]trackactivities.LikeSync, 0)
+	res := r.db.Raw(`UPDATE like_syncs SET attempts = attempts + 1, next_attempt_at = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM like_syncs
+			WHERE deleted_at IS NULL AND status = ? AND next_attempt_at <= ? AND user_id = (
+				SELECT user_id FROM like_syncs
+				WHERE deleted_at IS NULL AND status = ? AND next_attempt_at <= ?
+				ORDER BY id LIMIT 1
+				FOR UPDATE SKIP LOCKED
+			)
+			ORDER BY id LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		leaseUntil, now,
+		trackactivities.LikeSyncStatusPending, now,
+		trackactivities.LikeSyncStatusPending, now,
+		limit,
+	).Scan(&syncs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	sort.Slice(syncs, func(i, j int) bool {
+		return syncs[i].ID < syncs[j].ID
+	})
+	return syncs, nil
+}
+
+// FinishLikeSync gives the like sync its final status.
+func (r *repository) FinishLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, status, errMessage string) error {
+	return r.updateClaimedLikeSync(likeSync, map[string]interface{}{
+		"status": status,
+		"error":  errMessage,
+	})
+}
+
+// RetryLikeSync leaves the like sync pending until nextAttemptAt.
+func (r *repository) RetryLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, nextAttemptAt time.Time, errMessage string) error {
+	return r.updateClaimedLikeSync(likeSync, map[string]interface{}{
+		"next_attempt_at": nextAttemptAt,
+		"error":           errMessage,
+	})
+}
+
+// PruneLikeSyncs deletes the synced and skipped like syncs changed before the given time.
+func (r *repository) PruneLikeSyncs(ctx context.Context, before time.Time) (int64, error) {
+	res := r.db.Unscoped().
+		Where("status IN ?", []string{trackactivities.LikeSyncStatusSynced, trackactivities.LikeSyncStatusSkipped}).
+		Where("updated_at < ?", before).
+		Delete(&trackactivities.LikeSync{})
+	return res.RowsAffected, res.Error
+}
+
+func (r *repository) updateClaimedLikeSync(likeSync trackactivities.LikeSync, fields map[string]interface{}) error {
+	res := r.db.Model(&trackactivities.LikeSync{}).
+		Where("id = ?", likeSync.ID).Where("attempts = ?", likeSync.Attempts).Where("status = ?", trackactivities.LikeSyncStatusPending).
+		Updates(fields)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected != 1 {
+		return ErrLikeSyncClaimLost
+	}
+	return nil
+}
+
+// SaveRemoteLikes stores likes made on spotify after the change here.
+func (r *repository) SaveRemoteLikes(ctx context.Context, activities []trackactivities.TrackActivity) (int, error) {
+	return upsertRemoteLikes(r.db, activities)
+}
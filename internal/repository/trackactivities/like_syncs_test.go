@@ -0,0 +1,174 @@
+package trackactivities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func Test_repository_ClaimLikeSyncs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		want    []trackactivities.LikeSync
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: oldest first",
+			want: []trackactivities.LikeSync{
+				{Model: gorm.Model{ID: 3}, UserID: 2, SpotifyID: "track1", Liked: true, Status: "pending", Attempts: 1},
+				{Model: gorm.Model{ID: 4}, UserID: 2, SpotifyID: "track2", Liked: false, Status: "pending", Attempts: 2},
+			},
+			mockFn: func() {
+				mock.ExpectQuery(`UPDATE like_syncs SET attempts = attempts \+ 1, next_attempt_at = \$1, updated_at = \$2\s+WHERE id IN \(\s+SELECT id FROM like_syncs\s+WHERE deleted_at IS NULL AND status = \$3 AND next_attempt_at <= \$4 AND user_id = \(\s+SELECT user_id FROM like_syncs\s+WHERE deleted_at IS NULL AND status = \$5 AND next_attempt_at <= \$6\s+ORDER BY id LIMIT 1\s+FOR UPDATE SKIP LOCKED\s+\)\s+ORDER BY id LIMIT \$7\s+FOR UPDATE SKIP LOCKED\s+\)\s+RETURNING \*`).
+					WithArgs(leaseUntil, now, "pending", now, "pending", now, 50).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "spotify_id", "liked", "status", "attempts"}).
+						AddRow(4, 2, "track2", false, "pending", 2).
+						AddRow(3, 2, "track1", true, "pending", 1))
+			},
+		},
+		{
+			name: "success: nothing to do",
+			want: []trackactivities.LikeSync{},
+			mockFn: func() {
+				mock.ExpectQuery(`UPDATE like_syncs (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mock.ExpectQuery(`UPDATE like_syncs (.+)`).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.ClaimLikeSyncs(context.Background(), now, leaseUntil, 50)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_FinishLikeSync(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	likeSync := trackactivities.LikeSync{Model: gorm.Model{ID: 3}, Attempts: 2}
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "like_syncs" SET "error"=\$1,"status"=\$2,"updated_at"=\$3 WHERE id = \$4 AND attempts = \$5 AND status = \$6 AND "like_syncs"."deleted_at" IS NULL`).
+					WithArgs("too many requests", "dead", sqlmock.AnyArg(), 3, 2, "pending").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:    "failed: claimed by another worker",
+			wantErr: ErrLikeSyncClaimLost,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`UPDATE "like_syncs" (.+)`).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			r := &repository{
+				db: gormDB,
+			}
+			err := r.FinishLikeSync(context.Background(), likeSync, trackactivities.LikeSyncStatusDead, "too many requests")
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_repository_PruneLikeSyncs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	before := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "like_syncs" WHERE status IN \(\$1,\$2\) AND updated_at < \$3`).
+		WithArgs("synced", "skipped", before).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	got, err := r.PruneLikeSyncs(context.Background(), before)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_repository_RetryLikeSync(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	likeSync := trackactivities.LikeSync{Model: gorm.Model{ID: 3}, Attempts: 2}
+	nextAttemptAt := time.Now().Add(time.Minute)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "like_syncs" SET "error"=\$1,"next_attempt_at"=\$2,"updated_at"=\$3 WHERE id = \$4 AND attempts = \$5 AND status = \$6 AND "like_syncs"."deleted_at" IS NULL`).
+		WithArgs("service unavailable", nextAttemptAt, sqlmock.AnyArg(), 3, 2, "pending").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	r := &repository{
+		db: gormDB,
+	}
+	err = r.RetryLikeSync(context.Background(), likeSync, nextAttemptAt, "service unavailable")
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,10 @@
 package trackactivities
 
-import "gorm.io/gorm"
+import (
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"github.com/xprasetio/go-spotify/pkg/internalsql"
+	"gorm.io/gorm"
+)
 
 type repository struct {
 	db *gorm.DB
@@ -9,3 +13,70 @@ type repository struct {
 func NewRepository(db *gorm.DB) *repository {
 	return &repository{db: db}
 }
+
+var foreignKeys = []internalsql.ForeignKey{
+	{Table: "track_activities", Column: "user_id", RefTable: "users"},
+	{Table: "import_jobs", Column: "user_id", RefTable: "users"},
+	{Table: "like_syncs", Column: "user_id", RefTable: "users"},
+}
+
+// Migrate creates the tables of the repository and their unique indexes.
+// Rows written before an index existed may break it, so they are cleaned
+// up first: repeated activities of a track keep the latest one, and of
+// the imports active at once for a user the oldest carries on. Every row
+// references its user and is deleted with it.
+func (r *repository) Migrate() error {
+	migrator := r.db.Migrator()
+	if migrator.HasTable(&trackactivities.TrackActivity{}) && !migrator.HasIndex(&trackactivities.TrackActivity{}, "idx_track_activities_user_spotify") {
+		err := r.db.Exec(`DELETE FROM track_activities WHERE id IN (
+			SELECT id FROM (
+				SELECT id, row_number() OVER (
+					PARTITION BY user_id, spotify_id
+					ORDER BY deleted_at IS NULL DESC, updated_at DESC, id DESC
+				) AS rank
+				FROM track_activities
+			) ranked
+			WHERE rank > 1
+		)`).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	err := r.db.AutoMigrate(&trackactivities.TrackActivity{}, &trackactivities.ImportJob{}, &trackactivities.LikeSync{})
+	if err != nil {
+		return err
+	}
+
+	for _, fk := range foreignKeys {
+		if err := internalsql.AddCascadingForeignKey(r.db, fk); err != nil {
+			return err
+		}
+	}
+
+	if migrator.HasIndex(&trackactivities.ImportJob{}, "idx_import_jobs_active_user") {
+		return nil
+	}
+	active := []string{trackactivities.ImportStatusPending, trackactivities.ImportStatusRunning}
+	err = r.db.Exec(`UPDATE import_jobs SET status = ?, error = ?, finished_at = now(), lease_until = NULL
+		WHERE deleted_at IS NULL AND status IN ? AND id NOT IN (
+			SELECT min(id) FROM import_jobs WHERE deleted_at IS NULL AND status IN ? GROUP BY user_id
+		)`, trackactivities.ImportStatusFailed, "superseded by another import", active, active).Error
+	if err != nil {
+		return err
+	}
+	// a user has at most one pending or running import, see StartLibraryImport
+	return r.db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_import_jobs_active_user ON import_jobs (user_id)
+		WHERE deleted_at IS NULL AND status IN ('pending', 'running')`).Error
+}
+
+// DeleteOrphans deletes the rows of users deleted before their rows were
+// deleted with them, which Migrate can't add the foreign keys over.
+func (r *repository) DeleteOrphans() error {
+	for _, fk := range foreignKeys {
+		if err := internalsql.DeleteOrphans(r.db, fk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
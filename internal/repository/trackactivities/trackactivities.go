@@ -4,14 +4,27 @@ import (
 	"context"
 
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"gorm.io/gorm"
 )
 
-func (r *repository) Create(ctx context.Context, model trackactivities.TrackActivity) error {
-	return r.db.Create(&model).Error
+// Create stores a new activity together with a non nil likeSync.
+func (r *repository) Create(ctx context.Context, model trackactivities.TrackActivity, likeSync *trackactivities.LikeSync) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&model).Error; err != nil {
+			return err
+		}
+		return createLikeSync(tx, likeSync)
+	})
 }
 
-func (r *repository) Update(ctx context.Context, model trackactivities.TrackActivity) error {
-	return r.db.Save(&model).Error
+// Update stores a changed activity together with a non nil likeSync.
+func (r *repository) Update(ctx context.Context, model trackactivities.TrackActivity, likeSync *trackactivities.LikeSync) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(&model).Error; err != nil {
+			return err
+		}
+		return createLikeSync(tx, likeSync)
+	})
 }
 
 func (r *repository) Get(ctx context.Context, userID uint, spotifyID string) (*trackactivities.TrackActivity, error) {
@@ -36,3 +49,26 @@ func (r *repository) GetBulkSpotifyIDs(ctx context.Context, userID uint, spotify
 	}
 	return result, nil
 }
+
+// List returns a page of the user's liked or disliked tracks ordered by
+// updated time, id breaks ties so the cursor is stable.
+func (r *repository) List(ctx context.Context, userID uint, params trackactivities.ListParams) ([]trackactivities.TrackActivity, error) {
+	order := "updated_at DESC, id DESC"
+	compare := "<"
+	if params.Ascending {
+		order = "updated_at ASC, id ASC"
+		compare = ">"
+	}
+
+	query := r.db.Where("user_id = ?", userID).Where("is_liked = ?", params.IsLiked)
+	if params.After != nil {
+		query = query.Where("(updated_at, id) "+compare+" (?, ?)", params.After.UpdatedAt, params.After.ID)
+	}
+
+	activities := make([]trackactivities.TrackActivity, 0)
+	res := query.Order(order).Limit(params.Limit).Find(&activities)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return activities, nil
+}
@@ -25,7 +25,8 @@ func Test_repository_Create(t *testing.T) {
 	now := time.Now()
 	isLiked := true
 	type args struct {
-		model trackactivities.TrackActivity
+		model    trackactivities.TrackActivity
+		likeSync *trackactivities.LikeSync
 	}
 	tests := []struct {
 		name    string
@@ -67,6 +68,72 @@ func Test_repository_Create(t *testing.T) {
 				mock.ExpectCommit()
 			},
 		},
+		{
+			name: "success: with like sync",
+			args: args{
+				model: trackactivities.TrackActivity{
+					Model: gorm.Model{
+						CreatedAt: now,
+						UpdatedAt: now,
+					},
+					UserID:    1,
+					SpotifyID: "spotifyID",
+					IsLiked:   &isLiked,
+					CreatedBy: "1",
+					UpdatedBy: "1",
+				},
+				likeSync: &trackactivities.LikeSync{
+					UserID:        1,
+					SpotifyID:     "spotifyID",
+					Liked:         true,
+					ChangedAt:     now,
+					Status:        trackactivities.LikeSyncStatusPending,
+					NextAttemptAt: now,
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery(`INSERT INTO "track_activities" (.+) VALUES (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint(1)))
+				mock.ExpectQuery(`INSERT INTO "like_syncs" (.+) VALUES (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint(1)))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "failed: rolls back the activity when the like sync fails",
+			args: args{
+				model: trackactivities.TrackActivity{
+					UserID:    1,
+					SpotifyID: "spotifyID",
+					IsLiked:   &isLiked,
+					CreatedBy: "1",
+					UpdatedBy: "1",
+				},
+				likeSync: &trackactivities.LikeSync{
+					UserID:        1,
+					SpotifyID:     "spotifyID",
+					Liked:         true,
+					ChangedAt:     now,
+					Status:        trackactivities.LikeSyncStatusPending,
+					NextAttemptAt: now,
+				},
+			},
+			wantErr: true,
+			mockFn: func(args args) {
+				mock.ExpectBegin()
+
+				mock.ExpectQuery(`INSERT INTO "track_activities" (.+) VALUES (.+)`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uint(1)))
+				mock.ExpectQuery(`INSERT INTO "like_syncs" (.+) VALUES (.+)`).
+					WillReturnError(assert.AnError)
+
+				mock.ExpectRollback()
+			},
+		},
 		{
 			name: "error",
 			args: args{
@@ -108,7 +175,7 @@ func Test_repository_Create(t *testing.T) {
 			r := &repository{
 				db: gormDB,
 			}
-			if err := r.Create(context.Background(), tt.args.model); (err != nil) != tt.wantErr {
+			if err := r.Create(context.Background(), tt.args.model, tt.args.likeSync); (err != nil) != tt.wantErr {
 				t.Errorf("repository.Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -215,7 +282,7 @@ func Test_repository_Update(t *testing.T) {
 			r := &repository{
 				db: gormDB,
 			}
-			if err := r.Update(context.Background(), tt.args.model); (err != nil) != tt.wantErr {
+			if err := r.Update(context.Background(), tt.args.model, nil); (err != nil) != tt.wantErr {
 				t.Errorf("repository.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -383,3 +450,111 @@ func Test_repository_GetBulkSpotifyIDs(t *testing.T) {
 		})
 	}
 }
+
+func Test_repository_List(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	isLiked := true
+
+	type args struct {
+		userID uint
+		params trackactivities.ListParams
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []trackactivities.TrackActivity
+		wantErr bool
+		mockFn  func(args args)
+	}{
+		{
+			name: "success: first page",
+			args: args{
+				userID: 1,
+				params: trackactivities.ListParams{
+					IsLiked: true,
+					Limit:   5,
+				},
+			},
+			want: []trackactivities.TrackActivity{
+				{
+					Model: gorm.Model{
+						ID:        2,
+						CreatedAt: now,
+						UpdatedAt: now,
+					},
+					UserID:    1,
+					SpotifyID: "spotifyID",
+					IsLiked:   &isLiked,
+					CreatedBy: "test@gmail.com",
+					UpdatedBy: "test@gmail.com",
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mock.ExpectQuery(`SELECT \* FROM "track_activities" WHERE user_id = \$1 AND is_liked = \$2 AND "track_activities"."deleted_at" IS NULL ORDER BY updated_at DESC, id DESC LIMIT \$3`).
+					WithArgs(args.userID, true, args.params.Limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "user_id", "spotify_id", "is_liked", "created_by", "updated_by"}).
+						AddRow(2, now, now, 1, "spotifyID", true, "test@gmail.com", "test@gmail.com"))
+			},
+		},
+		{
+			name: "success: ascending after cursor",
+			args: args{
+				userID: 1,
+				params: trackactivities.ListParams{
+					IsLiked:   false,
+					Limit:     5,
+					Ascending: true,
+					After:     &trackactivities.Cursor{UpdatedAt: now, ID: 7},
+				},
+			},
+			want:    []trackactivities.TrackActivity{},
+			wantErr: false,
+			mockFn: func(args args) {
+				mock.ExpectQuery(`SELECT \* FROM "track_activities" WHERE user_id = \$1 AND is_liked = \$2 AND \(updated_at, id\) > \(\$3, \$4\) AND "track_activities"."deleted_at" IS NULL ORDER BY updated_at ASC, id ASC LIMIT \$5`).
+					WithArgs(args.userID, false, now, uint(7), args.params.Limit).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "user_id", "spotify_id", "is_liked", "created_by", "updated_by"}))
+			},
+		},
+		{
+			name: "failed",
+			args: args{
+				userID: 1,
+				params: trackactivities.ListParams{
+					IsLiked: true,
+					Limit:   5,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mock.ExpectQuery(`SELECT \* FROM "track_activities" .+`).WithArgs(args.userID, true, args.params.Limit).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.args)
+			r := &repository{
+				db: gormDB,
+			}
+			got, err := r.List(context.Background(), tt.args.userID, tt.args.params)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("repository.List() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("repository.List() = %v, want %v", got, tt.want)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -1,36 +1,171 @@
 package memberships
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
-	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
-func (s *service) Login(request memberships.LoginRequest) (string, error) {
+const (
+	defaultLoginMaxFailures      = 5
+	defaultLoginMaxFailuresPerIP = 20
+	defaultLoginLockout          = 15 * time.Minute
+	defaultLoginBaseDelay        = time.Second
+	defaultLoginMaxDelay         = 30 * time.Second
+
+	// dummyPasswordHash is compared against when the email is unknown, so
+	// the response takes as long as for a wrong password.
+	dummyPasswordHash = "$2a$10$VSvs98Wps1l5S/BFj2Mc0Od4HMzBbUK9hvT3ZRmjhenclObC8CeDC"
+)
+
+// ErrInvalidCredentials is the one error for an unknown email and a wrong
+// password alike, so login doesn't reveal which emails have an account.
+var ErrInvalidCredentials = errors.New("invalid email or password")
+
+func (s *service) Login(request memberships.LoginRequest) (*memberships.LoginResponse, error) {
+	ctx := context.Background()
+	request.Email = normalizeEmail(request.Email)
+	accountKey := "email:" + request.Email
+	keys := s.guardKeys(accountKey, request.ClientIP)
+
+	err := s.startAttempt(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
 	userDetail, err := s.repository.GetUser(request.Email, "", 0)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		log.Error().Err(err).Msg("error get user from database")
-		return "", err
+		return nil, err
+	}
+
+	passwordHash := dummyPasswordHash
+	if userDetail != nil {
+		passwordHash = userDetail.Password
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(request.Password))
+	if userDetail == nil || err != nil {
+		return nil, ErrInvalidCredentials
 	}
 
-	if userDetail == nil {
-		return "", errors.New("email not exists")
+	err = s.attemptSucceeded(ctx, keys)
+	if err != nil {
+		return nil, err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(userDetail.Password), []byte(request.Password))
+	return s.completeLogin(userDetail)
+}
+
+// completeLogin lets in a user who proved who they are, asking for the
+// second factor first when two-factor login is on.
+func (s *service) completeLogin(userDetail *memberships.User) (*memberships.LoginResponse, error) {
+	if userDetail.EmailVerifiedAt == nil && s.cfg.Service.UnverifiedLogin == unverifiedLoginBlock {
+		return nil, ErrEmailNotVerified
+	}
+
+	mfaToken, err := s.startMFA(userDetail.ID)
 	if err != nil {
-		return "", errors.New("email and password not match")
+		return nil, err
+	}
+	if mfaToken != "" {
+		return &memberships.LoginResponse{MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	return s.issueTokens(userDetail, "")
+}
+
+// guardKey is a key failed logins are counted under.
+type guardKey struct {
+	key    string
+	policy loginguard.Policy
+}
+
+// guardKeys returns the account key and, when known, the client address.
+func (s *service) guardKeys(accountKey, clientIP string) []guardKey {
+	accountPolicy, ipPolicy := s.loginPolicies()
+	keys := []guardKey{{key: accountKey, policy: accountPolicy}}
+	if clientIP != "" {
+		keys = append(keys, guardKey{key: "ip:" + clientIP, policy: ipPolicy})
 	}
+	return keys
+}
+
+// startAttempt counts the attempt against every key before the credentials
+// are compared, so a burst of parallel guesses can't all get past the
+// throttle. When a key has to wait, the keys counted so far get their
+// attempt back.
+func (s *service) startAttempt(ctx context.Context, keys []guardKey) error {
+	for idx, k := range keys {
+		err := s.loginGuard.Attempt(ctx, k.key, k.policy)
+		if err == nil {
+			continue
+		}
+		var locked *loginguard.LockedError
+		if !errors.As(err, &locked) {
+			log.Error().Err(err).Msg("error record login attempt")
+		}
+		for _, counted := range keys[:idx] {
+			if err := s.loginGuard.Succeed(ctx, counted.key, counted.policy); err != nil {
+				log.Error().Err(err).Msg("error take back login attempt")
+			}
+		}
+		return err
+	}
+	return nil
+}
 
-	accessToken, err := jwt.CreateToken(userDetail.ID, userDetail.Username, s.cfg.Service.SecretKey)
+// attemptSucceeded forgets the failures of the account, the first key. The
+// client address only gets this attempt back and keeps its earlier
+// failures, otherwise logging into an own account would clear the way for
+// guessing at others.
+func (s *service) attemptSucceeded(ctx context.Context, keys []guardKey) error {
+	err := s.loginGuard.Reset(ctx, keys[0].key)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to create JWT Token")
-		return "", err
+		log.Error().Err(err).Msg("error reset failed logins")
+		return err
 	}
+	for _, k := range keys[1:] {
+		if err := s.loginGuard.Succeed(ctx, k.key, k.policy); err != nil {
+			log.Error().Err(err).Msg("error take back login attempt")
+			return err
+		}
+	}
+	return nil
+}
 
-	return accessToken, nil
+// loginPolicies returns the throttling of failed logins per account and per
+// client address. Addresses get more attempts since users may share one.
+func (s *service) loginPolicies() (account, ip loginguard.Policy) {
+	cfg := s.cfg.Service.LoginGuard
+	account = loginguard.Policy{
+		MaxFailures: cfg.MaxFailures,
+		Lockout:     cfg.Lockout,
+		BaseDelay:   cfg.BaseDelay,
+		MaxDelay:    cfg.MaxDelay,
+	}
+	if account.MaxFailures <= 0 {
+		account.MaxFailures = defaultLoginMaxFailures
+	}
+	if account.Lockout <= 0 {
+		account.Lockout = defaultLoginLockout
+	}
+	if account.BaseDelay <= 0 {
+		account.BaseDelay = defaultLoginBaseDelay
+	}
+	if account.MaxDelay <= 0 {
+		account.MaxDelay = defaultLoginMaxDelay
+	}
+
+	ip = account
+	ip.MaxFailures = cfg.MaxFailuresPerIP
+	if ip.MaxFailures <= 0 {
+		ip.MaxFailures = defaultLoginMaxFailuresPerIP
+	}
+	return account, ip
 }
@@ -2,10 +2,12 @@ package memberships
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xprasetio/go-spotify/internal/configs"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -15,26 +17,68 @@ func Test_service_Login(t *testing.T) {
 	defer ctrlMock.Finish()
 
 	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+	mockGuard := NewMockloginGuard(ctrlMock)
+
+	accountPolicy := loginguard.Policy{MaxFailures: 5, Lockout: defaultLoginLockout, BaseDelay: defaultLoginBaseDelay, MaxDelay: defaultLoginMaxDelay}
+	ipPolicy := accountPolicy
+	ipPolicy.MaxFailures = defaultLoginMaxFailuresPerIP
+	lockedErr := &loginguard.LockedError{RetryAfter: time.Minute}
 
 	type args struct {
 		request memberships.LoginRequest
 	}
 	tests := []struct {
-		name    string
-		args    args
-		wantErr bool
-		mockFn  func(args args)
+		name            string
+		args            args
+		unverifiedLogin string
+		wantMFA         bool
+		wantErr         error
+		mockFn          func(args args)
 	}{
 		{
 			name: "success",
+			args: args{
+				request: memberships.LoginRequest{
+					Email:    "Test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				},
+			},
+			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "ip:10.0.0.1", ipPolicy).Return(nil)
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email:    "test@gmail.com",
+					Password: "$2a$10$VSvs98Wps1l5S/BFj2Mc0Od4HMzBbUK9hvT3ZRmjhenclObC8CeDC",
+					Username: "yeremia",
+				}, nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "email:test@gmail.com").Return(nil)
+				mockGuard.EXPECT().Succeed(gomock.Any(), "ip:10.0.0.1", ipPolicy).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockStore.EXPECT().TokenVersion(gomock.Any(), uint(1)).Return(uint(0), nil)
+				mockRepo.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(model memberships.Session) error {
+					assert.Equal(t, uint(1), model.UserID)
+					assert.NotEmpty(t, model.FamilyID)
+					assert.Len(t, model.TokenHash, 64)
+					return nil
+				})
+			},
+		},
+		{
+			name: "success: two-factor login is on",
 			args: args{
 				request: memberships.LoginRequest{
 					Email:    "test@gmail.com",
 					Password: "password",
 				},
 			},
-			wantErr: false,
+			wantMFA: true,
 			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
 				mockRepo.EXPECT().GetUser(args.request.Email, "", uint(0)).Return(&memberships.User{
 					Model: gorm.Model{
 						ID: 1,
@@ -43,6 +87,62 @@ func Test_service_Login(t *testing.T) {
 					Password: "$2a$10$VSvs98Wps1l5S/BFj2Mc0Od4HMzBbUK9hvT3ZRmjhenclObC8CeDC",
 					Username: "yeremia",
 				}, nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "email:test@gmail.com").Return(nil)
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 1, ConfirmedAt: &confirmedAt}, nil)
+			},
+		},
+		{
+			name: "failed when email not verified and login is blocked",
+			args: args{
+				request: memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+				},
+			},
+			unverifiedLogin: unverifiedLoginBlock,
+			wantErr:         ErrEmailNotVerified,
+			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
+				mockRepo.EXPECT().GetUser(args.request.Email, "", uint(0)).Return(&memberships.User{
+					Model: gorm.Model{
+						ID: 1,
+					},
+					Email:    "test@gmail.com",
+					Password: "$2a$10$VSvs98Wps1l5S/BFj2Mc0Od4HMzBbUK9hvT3ZRmjhenclObC8CeDC",
+					Username: "yeremia",
+				}, nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "email:test@gmail.com").Return(nil)
+			},
+		},
+		{
+			name: "failed when account is locked out",
+			args: args{
+				request: memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				},
+			},
+			wantErr: lockedErr,
+			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(lockedErr)
+			},
+		},
+		{
+			name: "failed when address is locked out",
+			args: args{
+				request: memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				},
+			},
+			wantErr: lockedErr,
+			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "ip:10.0.0.1", ipPolicy).Return(lockedErr)
+				mockGuard.EXPECT().Succeed(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
 			},
 		},
 		{
@@ -53,21 +153,41 @@ func Test_service_Login(t *testing.T) {
 					Password: "password",
 				},
 			},
-			wantErr: true,
+			wantErr: assert.AnError,
 			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
 				mockRepo.EXPECT().GetUser(args.request.Email, "", uint(0)).Return(nil, assert.AnError)
 			},
 		},
+		{
+			name: "failed when email not exists",
+			args: args{
+				request: memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+					ClientIP: "10.0.0.1",
+				},
+			},
+			wantErr: ErrInvalidCredentials,
+			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "ip:10.0.0.1", ipPolicy).Return(nil)
+				mockRepo.EXPECT().GetUser(args.request.Email, "", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
 		{
 			name: "failed when password not match",
 			args: args{
 				request: memberships.LoginRequest{
 					Email:    "test@gmail.com",
 					Password: "password",
+					ClientIP: "10.0.0.1",
 				},
 			},
-			wantErr: true,
+			wantErr: ErrInvalidCredentials,
 			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "ip:10.0.0.1", ipPolicy).Return(nil)
 				mockRepo.EXPECT().GetUser(args.request.Email, "", uint(0)).Return(&memberships.User{
 					Model: gorm.Model{
 						ID: 1,
@@ -78,6 +198,19 @@ func Test_service_Login(t *testing.T) {
 				}, nil)
 			},
 		},
+		{
+			name: "failed when record attempt",
+			args: args{
+				request: memberships.LoginRequest{
+					Email:    "test@gmail.com",
+					Password: "password",
+				},
+			},
+			wantErr: assert.AnError,
+			mockFn: func(args args) {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "email:test@gmail.com", accountPolicy).Return(assert.AnError)
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -85,21 +218,31 @@ func Test_service_Login(t *testing.T) {
 			s := &service{
 				cfg: &configs.Config{
 					Service: configs.Service{
-						SecretKey: "abc",
+						SecretKey:       "abc",
+						UnverifiedLogin: tt.unverifiedLogin,
 					},
 				},
-				repository: mockRepo,
+				repository:      mockRepo,
+				revocationStore: mockStore,
+				loginGuard:      mockGuard,
 			}
 			got, err := s.Login(tt.args.request)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("service.Login() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Empty(t, got)
 				return
 			}
-			if !tt.wantErr {
-				assert.NotEmpty(t, got)
-			} else {
-				assert.Empty(t, got)
+			assert.NoError(t, err)
+			if tt.wantMFA {
+				assert.True(t, got.MFARequired)
+				assert.Empty(t, got.AccessToken)
+				userID, ok := s.parseToken(mfaPendingPurpose, got.MFAToken)
+				assert.True(t, ok)
+				assert.Equal(t, "1", userID)
+				return
 			}
+			assert.NotEmpty(t, got.AccessToken)
+			assert.NotEmpty(t, got.RefreshToken)
 		})
 	}
 }
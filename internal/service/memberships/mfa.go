@@ -0,0 +1,268 @@
+package memberships
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/totp"
+	"gorm.io/gorm"
+)
+
+const (
+	mfaPendingPurpose = "mfa-pending"
+	mfaPendingTTL     = 5 * time.Minute
+
+	defaultTOTPIssuer = "go-spotify"
+	// totpSkew accepts codes of one step before and after the current one,
+	// to allow for clock drift of the phone.
+	totpSkew = 1
+
+	recoveryCodeCount = 10
+)
+
+var (
+	ErrTOTPAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrInvalidMFACode     = errors.New("invalid two-factor code")
+	ErrInvalidMFAToken    = errors.New("invalid or expired mfa token")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// EnrollTOTP creates a new authenticator secret and recovery codes. Login
+// only asks for a code once the enrollment is confirmed, enrolling again
+// before that replaces the secret and codes.
+func (s *service) EnrollTOTP(userID uint) (*memberships.EnrollTOTPResponse, error) {
+	userDetail, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	userTOTP, err := s.repository.GetUserTOTP(userID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Msg("error get user totp from database")
+		return nil, err
+	}
+	if userTOTP != nil && userTOTP.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		log.Error().Err(err).Msg("error generate totp secret")
+		return nil, err
+	}
+	codes := make([]string, recoveryCodeCount)
+	models := make([]memberships.RecoveryCode, recoveryCodeCount)
+	for idx := range codes {
+		codes[idx], err = randomRecoveryCode()
+		if err != nil {
+			log.Error().Err(err).Msg("error generate recovery code")
+			return nil, err
+		}
+		models[idx] = memberships.RecoveryCode{
+			UserID:   userID,
+			CodeHash: hashToken(normalizeRecoveryCode(codes[idx])),
+		}
+	}
+
+	sealed, err := s.keyring.Encrypt([]byte(secret), totpSecretAAD(userID))
+	if err != nil {
+		log.Error().Err(err).Msg("error encrypt totp secret")
+		return nil, err
+	}
+	err = s.repository.EnrollTOTP(memberships.UserTOTP{UserID: userID, Secret: sealed}, models)
+	if err != nil {
+		log.Error().Err(err).Msg("error enroll totp to database")
+		return nil, err
+	}
+
+	issuer := s.cfg.Service.TokenIssuer
+	if issuer == "" {
+		issuer = defaultTOTPIssuer
+	}
+	return &memberships.EnrollTOTPResponse{
+		Secret:        secret,
+		OTPAuthURI:    totp.URI(issuer, userDetail.Email, secret),
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// ConfirmTOTP turns two-factor login on once the user shows a first code,
+// proving the authenticator app was set up.
+func (s *service) ConfirmTOTP(userID uint, request memberships.ConfirmTOTPRequest) error {
+	userTOTP, err := s.repository.GetUserTOTP(userID)
+	if err == gorm.ErrRecordNotFound {
+		return ErrTOTPNotEnrolled
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user totp from database")
+		return err
+	}
+	if userTOTP.ConfirmedAt != nil {
+		return ErrTOTPAlreadyEnabled
+	}
+
+	secret, err := s.openTOTPSecret(userTOTP)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	step, ok := totp.Validate(secret, strings.TrimSpace(request.Code), now, totpSkew)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	confirmed, err := s.repository.ConfirmTOTP(userID, step, now)
+	if err != nil {
+		log.Error().Err(err).Msg("error confirm totp in database")
+		return err
+	}
+	if !confirmed {
+		return ErrTOTPAlreadyEnabled
+	}
+	return nil
+}
+
+// LoginMFA exchanges the mfa token handed out by Login and a TOTP or
+// recovery code for the access and refresh tokens.
+func (s *service) LoginMFA(request memberships.LoginMFARequest) (*memberships.LoginResponse, error) {
+	ctx := context.Background()
+	payload, ok := s.parseToken(mfaPendingPurpose, request.MFAToken)
+	if !ok {
+		return nil, ErrInvalidMFAToken
+	}
+	userID, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	// codes are few enough to guess, so they are throttled like passwords
+	accountKey := "mfa:" + payload
+	keys := s.guardKeys(accountKey, request.ClientIP)
+	err = s.startAttempt(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	userTOTP, err := s.repository.GetUserTOTP(uint(userID))
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidMFAToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user totp from database")
+		return nil, err
+	}
+	if userTOTP.ConfirmedAt == nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	ok, err = s.useSecondFactor(userTOTP, request.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	err = s.attemptSucceeded(ctx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	userDetail, err := s.getUser(uint(userID))
+	if err == ErrUserNotFound {
+		return nil, ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.issueTokens(userDetail, "")
+}
+
+// startMFA returns an mfa token when the user has two-factor login on, or
+// an empty token when the password is enough.
+func (s *service) startMFA(userID uint) (string, error) {
+	userTOTP, err := s.repository.GetUserTOTP(userID)
+	if err == gorm.ErrRecordNotFound {
+		return "", nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user totp from database")
+		return "", err
+	}
+	if userTOTP.ConfirmedAt == nil {
+		return "", nil
+	}
+	return s.signMFAToken(userID)
+}
+
+func (s *service) signMFAToken(userID uint) (string, error) {
+	return s.signToken(mfaPendingPurpose, strconv.FormatUint(uint64(userID), 10), time.Now().Add(mfaPendingTTL))
+}
+
+// useSecondFactor accepts a TOTP code not used before, or an unused
+// recovery code which is then used up.
+func (s *service) useSecondFactor(userTOTP *memberships.UserTOTP, code string) (bool, error) {
+	secret, err := s.openTOTPSecret(userTOTP)
+	if err != nil {
+		return false, err
+	}
+	code = strings.TrimSpace(code)
+	now := time.Now()
+	if step, ok := totp.Validate(secret, code, now, totpSkew); ok {
+		used, err := s.repository.UseTOTPStep(userTOTP.UserID, step)
+		if err != nil {
+			log.Error().Err(err).Msg("error use totp step in database")
+			return false, err
+		}
+		return used, nil
+	}
+
+	used, err := s.repository.UseRecoveryCode(userTOTP.UserID, hashToken(normalizeRecoveryCode(code)), now)
+	if err != nil {
+		log.Error().Err(err).Msg("error use recovery code in database")
+		return false, err
+	}
+	return used, nil
+}
+
+// openTOTPSecret decrypts the authenticator secret of a user.
+func (s *service) openTOTPSecret(userTOTP *memberships.UserTOTP) (string, error) {
+	secret, err := s.keyring.Decrypt(userTOTP.Secret, totpSecretAAD(userTOTP.UserID))
+	if err != nil {
+		log.Error().Err(err).Uint("userID", userTOTP.UserID).Msg("error decrypt totp secret")
+		return "", err
+	}
+	return string(secret), nil
+}
+
+// totpSecretAAD binds a sealed authenticator secret to the user it belongs
+// to.
+func totpSecretAAD(userID uint) []byte {
+	return []byte("user_totps|" + strconv.FormatUint(uint64(userID), 10))
+}
+
+// randomRecoveryCode returns a code like "abcde-fghij".
+func randomRecoveryCode() (string, error) {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))
+	return code[:5] + "-" + code[5:], nil
+}
+
+// normalizeRecoveryCode lets users type codes in any case and with or
+// without the dash.
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
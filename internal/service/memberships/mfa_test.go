@@ -0,0 +1,299 @@
+package memberships
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/encryption"
+	"github.com/xprasetio/go-spotify/pkg/totp"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+// sealedTOTPSecret is testTOTPSecret as EnrollTOTP stores it for user 1.
+func sealedTOTPSecret(t *testing.T, keyring keyring) string {
+	sealed, err := keyring.Encrypt([]byte(testTOTPSecret), totpSecretAAD(1))
+	assert.NoError(t, err)
+	return sealed
+}
+
+func currentTOTPCode(t *testing.T) (string, int64) {
+	step := totp.Step(time.Now())
+	code, err := totp.Code(testTOTPSecret, step)
+	assert.NoError(t, err)
+	return code, step
+}
+
+func Test_service_EnrollTOTP(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	keyring := testKeyring(t)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).DoAndReturn(func(model memberships.UserTOTP, codes []memberships.RecoveryCode) error {
+					assert.Equal(t, uint(1), model.UserID)
+					secret, err := keyring.Decrypt(model.Secret, totpSecretAAD(1))
+					assert.NoError(t, err)
+					assert.Len(t, secret, 32)
+					assert.Nil(t, model.ConfirmedAt)
+					assert.Len(t, codes, recoveryCodeCount)
+					for _, code := range codes {
+						assert.Equal(t, uint(1), code.UserID)
+						assert.Len(t, code.CodeHash, 64)
+					}
+					return nil
+				})
+			},
+		},
+		{
+			name: "success: replaces an unconfirmed enrollment",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 1, Secret: sealedTOTPSecret(t, keyring)}, nil)
+				mockRepo.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: already enabled",
+			wantErr: ErrTOTPAlreadyEnabled,
+			mockFn: func() {
+				confirmedAt := time.Now()
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 1, ConfirmedAt: &confirmedAt}, nil)
+			},
+		},
+		{
+			name:    "failed: when enroll",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().EnrollTOTP(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:        &configs.Config{},
+				repository: mockRepo,
+				keyring:    keyring,
+			}
+			got, err := s.EnrollTOTP(1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(got.OTPAuthURI, "otpauth://totp/go-spotify:test@gmail.com?"))
+			assert.Contains(t, got.OTPAuthURI, "secret="+got.Secret)
+			assert.Len(t, got.RecoveryCodes, recoveryCodeCount)
+			assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, got.RecoveryCodes[0])
+		})
+	}
+}
+
+func Test_service_ConfirmTOTP(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	keyring := testKeyring(t)
+	sealed := sealedTOTPSecret(t, keyring)
+	code, step := currentTOTPCode(t)
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			code: code,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 1, Secret: sealed}, nil)
+				mockRepo.EXPECT().ConfirmTOTP(uint(1), step, gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:    "failed: secret sealed for another user",
+			code:    code,
+			wantErr: encryption.ErrDecrypt,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 2, Secret: sealed}, nil)
+			},
+		},
+		{
+			name:    "failed: wrong code",
+			code:    "abcdef",
+			wantErr: ErrInvalidMFACode,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 1, Secret: sealed}, nil)
+			},
+		},
+		{
+			name:    "failed: not enrolled",
+			code:    code,
+			wantErr: ErrTOTPNotEnrolled,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: confirmed concurrently",
+			code:    code,
+			wantErr: ErrTOTPAlreadyEnabled,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(&memberships.UserTOTP{UserID: 1, Secret: sealed}, nil)
+				mockRepo.EXPECT().ConfirmTOTP(uint(1), step, gomock.Any()).Return(false, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:        &configs.Config{},
+				repository: mockRepo,
+				keyring:    keyring,
+			}
+			err := s.ConfirmTOTP(1, memberships.ConfirmTOTPRequest{Code: tt.code})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_service_LoginMFA(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+	mockGuard := NewMockloginGuard(ctrlMock)
+
+	s := &service{
+		cfg: &configs.Config{
+			Service: configs.Service{
+				SecretKey: "abc",
+			},
+		},
+		repository:      mockRepo,
+		revocationStore: mockStore,
+		loginGuard:      mockGuard,
+		keyring:         testKeyring(t),
+	}
+	mfaToken, err := s.signMFAToken(1)
+	assert.NoError(t, err)
+	verificationToken, err := s.signVerificationToken("1", time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	accountPolicy, _ := s.loginPolicies()
+	confirmedAt := time.Now()
+	enabled := &memberships.UserTOTP{UserID: 1, Secret: sealedTOTPSecret(t, s.keyring), ConfirmedAt: &confirmedAt}
+	code, step := currentTOTPCode(t)
+
+	tests := []struct {
+		name    string
+		request memberships.LoginMFARequest
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success: totp code",
+			request: memberships.LoginMFARequest{MFAToken: mfaToken, Code: code},
+			mockFn: func() {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "mfa:1", accountPolicy).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UseTOTPStep(uint(1), step).Return(true, nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "mfa:1").Return(nil)
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockStore.EXPECT().TokenVersion(gomock.Any(), uint(1)).Return(uint(0), nil)
+				mockRepo.EXPECT().CreateSession(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "success: recovery code",
+			request: memberships.LoginMFARequest{MFAToken: mfaToken, Code: "ABCDE-FGHIJ"},
+			mockFn: func() {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "mfa:1", accountPolicy).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UseRecoveryCode(uint(1), hashToken("abcdefghij"), gomock.Any()).Return(true, nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "mfa:1").Return(nil)
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockStore.EXPECT().TokenVersion(gomock.Any(), uint(1)).Return(uint(0), nil)
+				mockRepo.EXPECT().CreateSession(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: replayed totp code",
+			request: memberships.LoginMFARequest{MFAToken: mfaToken, Code: code},
+			wantErr: ErrInvalidMFACode,
+			mockFn: func() {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "mfa:1", accountPolicy).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UseTOTPStep(uint(1), step).Return(false, nil)
+			},
+		},
+		{
+			name:    "failed: used recovery code",
+			request: memberships.LoginMFARequest{MFAToken: mfaToken, Code: "abcde-fghij"},
+			wantErr: ErrInvalidMFACode,
+			mockFn: func() {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "mfa:1", accountPolicy).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(enabled, nil)
+				mockRepo.EXPECT().UseRecoveryCode(uint(1), hashToken("abcdefghij"), gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:    "failed: token of another purpose",
+			request: memberships.LoginMFARequest{MFAToken: verificationToken, Code: code},
+			wantErr: ErrInvalidMFAToken,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: two-factor login is off",
+			request: memberships.LoginMFARequest{MFAToken: mfaToken, Code: code},
+			wantErr: ErrInvalidMFAToken,
+			mockFn: func() {
+				mockGuard.EXPECT().Attempt(gomock.Any(), "mfa:1", accountPolicy).Return(nil)
+				mockRepo.EXPECT().GetUserTOTP(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			got, err := s.LoginMFA(tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.AccessToken)
+			assert.NotEmpty(t, got.RefreshToken)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package memberships
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	spotifyOAuthStateTTL = 10 * time.Minute
+	spotifyAccountSource = "spotify"
+)
+
+var (
+	ErrInvalidOAuthState    = errors.New("invalid or expired oauth state")
+	ErrSpotifyAuthDenied    = errors.New("spotify authorization was denied")
+	ErrSpotifyAccountLinked = errors.New("spotify account is linked to another user")
+	// ErrSpotifyEmailTaken is returned instead of linking by email, whoever
+	// controls a spotify account with that email would get into the account.
+	ErrSpotifyEmailTaken   = errors.New("email is used by another account, log in and link spotify from there")
+	ErrSpotifyEmailMissing = errors.New("spotify account has no email")
+)
+
+// StartSpotifyOAuth begins log in with spotify. With a userID the spotify
+// account is linked to that user instead. The returned state has to come
+// back to the callback both in the url and in the cookie of the browser.
+func (s *service) StartSpotifyOAuth(userID uint) (*memberships.StartSpotifyOAuthResponse, error) {
+	state, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	model := memberships.OAuthState{
+		StateHash:    hashToken(state),
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(spotifyOAuthStateTTL),
+	}
+	if userID != 0 {
+		model.UserID = &userID
+	}
+	err = s.repository.CreateOAuthState(model)
+	if err != nil {
+		log.Error().Err(err).Msg("error create oauth state to database")
+		return nil, err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	spotifyCfg := s.cfg.SpotifyConfig
+	return &memberships.StartSpotifyOAuthResponse{
+		AuthorizeURL: s.spotifyAuth.AuthorizeURL(state, base64.RawURLEncoding.EncodeToString(challenge[:]), spotifyCfg.RedirectURL, spotifyCfg.Scopes),
+		State:        state,
+	}, nil
+}
+
+// SpotifyCallback finishes what StartSpotifyOAuth began. A log in returns
+// the tokens of the user owning the spotify account, creating the user on
+// first use. A link returns nil.
+func (s *service) SpotifyCallback(request memberships.SpotifyCallbackRequest) (*memberships.LoginResponse, error) {
+	ctx := context.Background()
+	if subtle.ConstantTimeCompare([]byte(request.State), []byte(request.CookieState)) != 1 {
+		return nil, ErrInvalidOAuthState
+	}
+	state, err := s.useOAuthState(request.State)
+	if err != nil {
+		return nil, err
+	}
+	if request.Error != "" || request.Code == "" {
+		return nil, ErrSpotifyAuthDenied
+	}
+
+	token, err := s.spotifyAuth.ExchangeCode(ctx, request.Code, state.CodeVerifier, s.cfg.SpotifyConfig.RedirectURL)
+	if err != nil {
+		log.Error().Err(err).Msg("error exchange spotify authorization code")
+		return nil, err
+	}
+	spotifyUser, err := s.spotifyAuth.GetCurrentUser(ctx, token.AccessToken)
+	if err != nil {
+		log.Error().Err(err).Msg("error get spotify user")
+		return nil, err
+	}
+
+	refreshToken, err := s.keyring.Encrypt([]byte(token.RefreshToken), spotifyTokenAAD(spotifyUser.ID))
+	if err != nil {
+		log.Error().Err(err).Msg("error encrypt spotify refresh token")
+		return nil, err
+	}
+	account := memberships.SpotifyAccount{
+		SpotifyUserID: spotifyUser.ID,
+		RefreshToken:  refreshToken,
+		Scope:         token.Scope,
+	}
+
+	linked, err := s.repository.GetSpotifyAccount(spotifyUser.ID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Msg("error get spotify account from database")
+		return nil, err
+	}
+
+	if state.UserID != nil {
+		if linked != nil && linked.UserID != *state.UserID {
+			return nil, ErrSpotifyAccountLinked
+		}
+		account.UserID = *state.UserID
+		err = s.repository.SaveSpotifyAccount(account)
+		if err != nil {
+			log.Error().Err(err).Msg("error save spotify account to database")
+			return nil, err
+		}
+		// a token cached for a spotify account linked before is stale now
+		s.spotifyTokens.drop(account.UserID)
+		return nil, nil
+	}
+
+	if linked != nil {
+		account.UserID = linked.UserID
+		err = s.repository.SaveSpotifyAccount(account)
+		if err != nil {
+			log.Error().Err(err).Msg("error save spotify account to database")
+			return nil, err
+		}
+		userDetail, err := s.getUser(linked.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return s.completeLogin(userDetail)
+	}
+
+	userDetail, err := s.createSpotifyUser(spotifyUser.ID, spotifyUser.Email, account)
+	if err != nil {
+		return nil, err
+	}
+	return s.completeLogin(userDetail)
+}
+
+func (s *service) useOAuthState(state string) (*memberships.OAuthState, error) {
+	model, err := s.repository.GetOAuthState(hashToken(state))
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidOAuthState
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get oauth state from database")
+		return nil, err
+	}
+	now := time.Now()
+	if model.UsedAt != nil || !now.Before(model.ExpiresAt) {
+		return nil, ErrInvalidOAuthState
+	}
+
+	used, err := s.repository.UseOAuthState(model.ID, now)
+	if err != nil {
+		log.Error().Err(err).Msg("error use oauth state in database")
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidOAuthState
+	}
+	return model, nil
+}
+
+// createSpotifyUser signs up the owner of a spotify account not linked
+// yet. The password is random, the user can set one with a password reset.
+func (s *service) createSpotifyUser(spotifyUserID, email string, account memberships.SpotifyAccount) (*memberships.User, error) {
+	email = normalizeEmail(email)
+	if email == "" {
+		return nil, ErrSpotifyEmailMissing
+	}
+	_, err := s.repository.GetUser(email, "", 0)
+	if err == nil {
+		return nil, ErrSpotifyEmailTaken
+	}
+	if err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Msg("error get user from database")
+		return nil, err
+	}
+
+	username := spotifyUserID
+	_, err = s.repository.GetUser("", username, 0)
+	if err == nil {
+		suffix, err := randomToken(4)
+		if err != nil {
+			return nil, err
+		}
+		username += "-" + suffix
+	} else if err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Msg("error get user from database")
+		return nil, err
+	}
+
+	password, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	pass, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error().Err(err).Msg("error hash password")
+		return nil, err
+	}
+
+	userDetail := &memberships.User{
+		Email:     email,
+		Username:  username,
+		Password:  string(pass),
+		CreatedBy: spotifyAccountSource,
+		UpdatedBy: spotifyAccountSource,
+	}
+	err = s.repository.CreateSpotifyUser(userDetail, account)
+	if err != nil {
+		log.Error().Err(err).Msg("error create spotify user to database")
+		return nil, err
+	}
+
+	// spotify doesn't promise the email is verified, so it is checked
+	// like on sign up
+	_ = s.sendVerification(userDetail.Email, userDetail.Username)
+	return userDetail, nil
+}
+
+// spotifyTokenAAD binds a sealed refresh token to the spotify account it
+// belongs to.
+func spotifyTokenAAD(spotifyUserID string) []byte {
+	return []byte("spotify_accounts|" + spotifyUserID)
+}
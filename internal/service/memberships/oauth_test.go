@@ -0,0 +1,317 @@
+package memberships
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/encryption"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func testKeyring(t *testing.T) *encryption.Keyring {
+	keyring, err := encryption.NewKeyring(encryption.Key{ID: "test", Secret: make([]byte, encryption.KeySize)})
+	assert.NoError(t, err)
+	return keyring
+}
+
+func Test_service_StartSpotifyOAuth(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockSpotify := NewMockspotifyAuth(ctrlMock)
+
+	tests := []struct {
+		name    string
+		userID  uint
+		wantErr error
+		mockFn  func(stored *memberships.OAuthState)
+	}{
+		{
+			name: "success: log in",
+			mockFn: func(stored *memberships.OAuthState) {
+				mockRepo.EXPECT().CreateOAuthState(gomock.Any()).DoAndReturn(func(model memberships.OAuthState) error {
+					*stored = model
+					return nil
+				})
+			},
+		},
+		{
+			name:   "success: link",
+			userID: 1,
+			mockFn: func(stored *memberships.OAuthState) {
+				mockRepo.EXPECT().CreateOAuthState(gomock.Any()).DoAndReturn(func(model memberships.OAuthState) error {
+					*stored = model
+					return nil
+				})
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: assert.AnError,
+			mockFn: func(stored *memberships.OAuthState) {
+				mockRepo.EXPECT().CreateOAuthState(gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stored memberships.OAuthState
+			tt.mockFn(&stored)
+			s := &service{
+				cfg: &configs.Config{
+					SpotifyConfig: configs.SpotifyConfig{
+						RedirectURL: "http://localhost/callback",
+						Scopes:      []string{"user-read-email"},
+					},
+				},
+				repository:  mockRepo,
+				spotifyAuth: mockSpotify,
+			}
+			if tt.wantErr == nil {
+				mockSpotify.EXPECT().AuthorizeURL(gomock.Any(), gomock.Any(), "http://localhost/callback", []string{"user-read-email"}).
+					DoAndReturn(func(state, codeChallenge, _ string, _ []string) string {
+						// the challenge is derived from the stored verifier
+						sum := sha256.Sum256([]byte(stored.CodeVerifier))
+						assert.Equal(t, base64.RawURLEncoding.EncodeToString(sum[:]), codeChallenge)
+						return "http://spotify/authorize?state=" + state
+					})
+			}
+
+			got, err := s.StartSpotifyOAuth(tt.userID)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "http://spotify/authorize?state="+got.State, got.AuthorizeURL)
+			assert.Equal(t, hashToken(got.State), stored.StateHash)
+			assert.True(t, stored.ExpiresAt.After(time.Now()))
+			if tt.userID != 0 {
+				assert.Equal(t, tt.userID, *stored.UserID)
+			} else {
+				assert.Nil(t, stored.UserID)
+			}
+		})
+	}
+}
+
+func Test_service_SpotifyCallback(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+	mockMailer := NewMockmailer(ctrlMock)
+	mockSpotify := NewMockspotifyAuth(ctrlMock)
+	keyring := testKeyring(t)
+
+	linkUserID := uint(1)
+	pendingState := func(userID *uint) *memberships.OAuthState {
+		return &memberships.OAuthState{
+			Model:        gorm.Model{ID: 3},
+			StateHash:    hashToken("state"),
+			CodeVerifier: "verifier",
+			UserID:       userID,
+			ExpiresAt:    time.Now().Add(time.Minute),
+		}
+	}
+	request := memberships.SpotifyCallbackRequest{Code: "code", State: "state", CookieState: "state"}
+	exchange := func() {
+		mockSpotify.EXPECT().ExchangeCode(gomock.Any(), "code", "verifier", "http://localhost/callback").
+			Return(&spotify.SpotifyUserTokenResponse{AccessToken: "access", RefreshToken: "refresh", Scope: "user-read-email"}, nil)
+		mockSpotify.EXPECT().GetCurrentUser(gomock.Any(), "access").
+			Return(&spotify.SpotifyUser{ID: "spotifyuser", Email: "spotify@example.com"}, nil)
+	}
+	// checkAccount asserts the refresh token is stored sealed for the user
+	checkAccount := func(userID uint) func(model memberships.SpotifyAccount) error {
+		return func(model memberships.SpotifyAccount) error {
+			assert.Equal(t, userID, model.UserID)
+			assert.Equal(t, "spotifyuser", model.SpotifyUserID)
+			assert.Equal(t, "user-read-email", model.Scope)
+			assert.NotContains(t, model.RefreshToken, "refresh")
+			refreshToken, err := keyring.Decrypt(model.RefreshToken, spotifyTokenAAD("spotifyuser"))
+			assert.NoError(t, err)
+			assert.Equal(t, "refresh", string(refreshToken))
+			return nil
+		}
+	}
+	issueTokens := func(userID uint) {
+		mockRepo.EXPECT().GetUserTOTP(userID).Return(nil, gorm.ErrRecordNotFound)
+		mockStore.EXPECT().TokenVersion(gomock.Any(), userID).Return(uint(0), nil)
+		mockRepo.EXPECT().CreateSession(gomock.Any()).Return(nil)
+	}
+
+	tests := []struct {
+		name       string
+		request    memberships.SpotifyCallbackRequest
+		wantTokens bool
+		wantErr    error
+		mockFn     func()
+	}{
+		{
+			name:       "success: log in a linked user",
+			request:    request,
+			wantTokens: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(nil), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+				exchange()
+				mockRepo.EXPECT().GetSpotifyAccount("spotifyuser").Return(&memberships.SpotifyAccount{UserID: 1, SpotifyUserID: "spotifyuser"}, nil)
+				mockRepo.EXPECT().SaveSpotifyAccount(gomock.Any()).DoAndReturn(checkAccount(1))
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				issueTokens(1)
+			},
+		},
+		{
+			name:       "success: signs up a new user",
+			request:    request,
+			wantTokens: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(nil), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+				exchange()
+				mockRepo.EXPECT().GetSpotifyAccount("spotifyuser").Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().GetUser("spotify@example.com", "", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().GetUser("", "spotifyuser", uint(0)).Return(testUser(), nil)
+				mockRepo.EXPECT().CreateSpotifyUser(gomock.Any(), gomock.Any()).DoAndReturn(func(user *memberships.User, account memberships.SpotifyAccount) error {
+					assert.Equal(t, "spotify@example.com", user.Email)
+					assert.Contains(t, user.Username, "spotifyuser-")
+					assert.NotEmpty(t, user.Password)
+					assert.Nil(t, user.EmailVerifiedAt)
+					user.ID = 7
+					return checkAccount(0)(account)
+				})
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+				issueTokens(7)
+			},
+		},
+		{
+			name:    "success: links the signed in user",
+			request: request,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(&linkUserID), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+				exchange()
+				mockRepo.EXPECT().GetSpotifyAccount("spotifyuser").Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().SaveSpotifyAccount(gomock.Any()).DoAndReturn(checkAccount(1))
+			},
+		},
+		{
+			name:    "failed: state not from this browser",
+			request: memberships.SpotifyCallbackRequest{Code: "code", State: "state", CookieState: "other"},
+			wantErr: ErrInvalidOAuthState,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: unknown state",
+			request: request,
+			wantErr: ErrInvalidOAuthState,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: expired state",
+			request: request,
+			wantErr: ErrInvalidOAuthState,
+			mockFn: func() {
+				state := pendingState(nil)
+				state.ExpiresAt = time.Now().Add(-time.Second)
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(state, nil)
+			},
+		},
+		{
+			name:    "failed: replayed callback",
+			request: request,
+			wantErr: ErrInvalidOAuthState,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(nil), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:    "failed: user declined",
+			request: memberships.SpotifyCallbackRequest{Error: "access_denied", State: "state", CookieState: "state"},
+			wantErr: ErrSpotifyAuthDenied,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(nil), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:    "failed: code exchange",
+			request: request,
+			wantErr: spotify.ErrBadRequest,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(nil), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+				mockSpotify.EXPECT().ExchangeCode(gomock.Any(), "code", "verifier", "http://localhost/callback").
+					Return(nil, &spotify.APIError{StatusCode: 400})
+			},
+		},
+		{
+			name:    "failed: spotify account linked to another user",
+			request: request,
+			wantErr: ErrSpotifyAccountLinked,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(&linkUserID), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+				exchange()
+				mockRepo.EXPECT().GetSpotifyAccount("spotifyuser").Return(&memberships.SpotifyAccount{UserID: 2, SpotifyUserID: "spotifyuser"}, nil)
+			},
+		},
+		{
+			name:    "failed: email used by an account not linked",
+			request: request,
+			wantErr: ErrSpotifyEmailTaken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetOAuthState(hashToken("state")).Return(pendingState(nil), nil)
+				mockRepo.EXPECT().UseOAuthState(uint(3), gomock.Any()).Return(true, nil)
+				exchange()
+				mockRepo.EXPECT().GetSpotifyAccount("spotifyuser").Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().GetUser("spotify@example.com", "", uint(0)).Return(testUser(), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg: &configs.Config{
+					Service: configs.Service{
+						SecretKey: "abc",
+					},
+					SpotifyConfig: configs.SpotifyConfig{
+						RedirectURL: "http://localhost/callback",
+					},
+				},
+				repository:      mockRepo,
+				revocationStore: mockStore,
+				mailer:          mockMailer,
+				spotifyAuth:     mockSpotify,
+				keyring:         keyring,
+			}
+			got, err := s.SpotifyCallback(tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			if !tt.wantTokens {
+				assert.Nil(t, got)
+				return
+			}
+			assert.NotEmpty(t, got.AccessToken)
+			assert.NotEmpty(t, got.RefreshToken)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package memberships
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/mail"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPasswordResetTTL = time.Hour
+
+	mailTimeout = 30 * time.Second
+)
+
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
+
+// ForgotPassword emails a reset link. Unknown emails are ignored and the
+// mail is sent in the background so the caller can't tell which emails
+// have an account, neither from the answer nor from its timing.
+func (s *service) ForgotPassword(request memberships.ForgotPasswordRequest) error {
+	userDetail, err := s.repository.GetUser(normalizeEmail(request.Email), "", 0)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+
+	token, err := randomToken(32)
+	if err != nil {
+		return err
+	}
+	ttl := s.cfg.Service.PasswordResetTTL
+	if ttl <= 0 {
+		ttl = defaultPasswordResetTTL
+	}
+	err = s.repository.CreatePasswordReset(memberships.PasswordReset{
+		UserID:    userDetail.ID,
+		TokenHash: hashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error create password reset to database")
+		return err
+	}
+
+	link := token
+	if s.cfg.Service.PasswordResetURL != "" {
+		link = s.cfg.Service.PasswordResetURL + "?token=" + url.QueryEscape(token)
+	}
+	s.sendMailAsync(mail.Message{
+		To:      userDetail.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset your password. Choose a new one with the link below, it expires in %s and works once.\n\n%s\n\nIf it wasn't you, ignore this email.\n",
+			userDetail.Username, ttl, link),
+	})
+	return nil
+}
+
+// sendMailAsync sends without making the caller wait for the mail server,
+// failures are logged.
+func (s *service) sendMailAsync(message mail.Message) {
+	s.mails.Add(1)
+	go func() {
+		defer s.mails.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), mailTimeout)
+		defer cancel()
+		if err := s.mailer.Send(ctx, message); err != nil {
+			log.Error().Err(err).Str("subject", message.Subject).Msg("error send email")
+		}
+	}()
+}
+
+// ResetPassword sets a new password and ends every login of the user.
+func (s *service) ResetPassword(request memberships.ResetPasswordRequest) error {
+	reset, err := s.repository.GetPasswordReset(hashToken(request.Token))
+	if err == gorm.ErrRecordNotFound {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get password reset from database")
+		return err
+	}
+
+	now := time.Now()
+	if reset.UsedAt != nil || !now.Before(reset.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	pass, err := bcrypt.GenerateFromPassword([]byte(request.Password), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error().Err(err).Msg("error hash password")
+		return err
+	}
+
+	used, err := s.repository.ResetPassword(reset.ID, reset.UserID, string(pass), now)
+	if err != nil {
+		log.Error().Err(err).Msg("error reset password in database")
+		return err
+	}
+	if !used {
+		return ErrInvalidResetToken
+	}
+
+	return s.LogoutAll(reset.UserID)
+}
@@ -0,0 +1,196 @@
+package memberships
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/mail"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func Test_service_ForgotPassword(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockMailer := NewMockmailer(ctrlMock)
+
+	tests := []struct {
+		name    string
+		email   string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model:    gorm.Model{ID: 1},
+					Email:    "test@gmail.com",
+					Username: "yeremia",
+				}, nil)
+
+				var tokenHash string
+				mockRepo.EXPECT().CreatePasswordReset(gomock.Any()).DoAndReturn(func(model memberships.PasswordReset) error {
+					assert.Equal(t, uint(1), model.UserID)
+					assert.WithinDuration(t, time.Now().Add(time.Hour), model.ExpiresAt, time.Minute)
+					tokenHash = model.TokenHash
+					return nil
+				})
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message mail.Message) error {
+					assert.Equal(t, "test@gmail.com", message.To)
+					// the mail carries the token whose hash was stored
+					_, link, _ := strings.Cut(message.Body, "http://localhost/reset?token=")
+					token, _, _ := strings.Cut(link, "\n")
+					assert.Equal(t, tokenHash, hashToken(token))
+					return nil
+				})
+			},
+		},
+		{
+			name:  "success: send failures are not reported",
+			email: " Test@Gmail.com ",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model: gorm.Model{ID: 1},
+					Email: "test@gmail.com",
+				}, nil)
+				mockRepo.EXPECT().CreatePasswordReset(gomock.Any()).Return(nil)
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+		},
+		{
+			name: "success: unknown email sends nothing",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: when create reset",
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model: gorm.Model{ID: 1},
+					Email: "test@gmail.com",
+				}, nil)
+				mockRepo.EXPECT().CreatePasswordReset(gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg: &configs.Config{
+					Service: configs.Service{
+						PasswordResetURL: "http://localhost/reset",
+					},
+				},
+				repository: mockRepo,
+				mailer:     mockMailer,
+			}
+			email := tt.email
+			if email == "" {
+				email = "test@gmail.com"
+			}
+			err := s.ForgotPassword(memberships.ForgotPasswordRequest{Email: email})
+			s.mails.Wait()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_service_ResetPassword(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+
+	tokenHash := hashToken("token")
+	now := time.Now()
+	newReset := func() *memberships.PasswordReset {
+		return &memberships.PasswordReset{
+			Model:     gorm.Model{ID: 7},
+			UserID:    1,
+			TokenHash: tokenHash,
+			ExpiresAt: now.Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: revokes every session",
+			mockFn: func() {
+				mockRepo.EXPECT().GetPasswordReset(tokenHash).Return(newReset(), nil)
+				mockRepo.EXPECT().ResetPassword(uint(7), uint(1), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_, _ uint, password string, _ time.Time) (bool, error) {
+						assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(password), []byte("new password")))
+						return true, nil
+					})
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(1), nil)
+				mockRepo.EXPECT().RevokeUserSessions(uint(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: unknown token",
+			wantErr: ErrInvalidResetToken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPasswordReset(tokenHash).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: expired",
+			wantErr: ErrInvalidResetToken,
+			mockFn: func() {
+				reset := newReset()
+				reset.ExpiresAt = now.Add(-time.Second)
+				mockRepo.EXPECT().GetPasswordReset(tokenHash).Return(reset, nil)
+			},
+		},
+		{
+			name:    "failed: already used",
+			wantErr: ErrInvalidResetToken,
+			mockFn: func() {
+				reset := newReset()
+				reset.UsedAt = &now
+				mockRepo.EXPECT().GetPasswordReset(tokenHash).Return(reset, nil)
+			},
+		},
+		{
+			name:    "failed: concurrent reset used the token first",
+			wantErr: ErrInvalidResetToken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPasswordReset(tokenHash).Return(newReset(), nil)
+				mockRepo.EXPECT().ResetPassword(uint(7), uint(1), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:             &configs.Config{},
+				repository:      mockRepo,
+				revocationStore: mockStore,
+			}
+			err := s.ResetPassword(memberships.ResetPasswordRequest{Token: "token", Password: "new password"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package memberships
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserNotFound    = errors.New("user not found")
+	ErrProfileTaken    = errors.New("email or username exists")
+	ErrInvalidPassword = errors.New("current password is incorrect")
+)
+
+func (s *service) GetProfile(userID uint) (*memberships.ProfileResponse, error) {
+	userDetail, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return profileResponse(userDetail), nil
+}
+
+// UpdateProfile changes the email and/or username. A new email has to be
+// verified again, so a verification mail is sent to it.
+func (s *service) UpdateProfile(userID uint, request memberships.UpdateProfileRequest) (*memberships.ProfileResponse, error) {
+	userDetail, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Email != nil {
+		email := normalizeEmail(*request.Email)
+		request.Email = &email
+	}
+	fields := make(map[string]interface{})
+	emailChanged := request.Email != nil && *request.Email != userDetail.Email
+	if emailChanged {
+		if err := s.checkAvailable(*request.Email, ""); err != nil {
+			return nil, err
+		}
+		fields["email"] = *request.Email
+		fields["email_verified_at"] = nil
+	}
+	if request.Username != nil && *request.Username != userDetail.Username {
+		if err := s.checkAvailable("", *request.Username); err != nil {
+			return nil, err
+		}
+		fields["username"] = *request.Username
+	}
+	if len(fields) == 0 {
+		return profileResponse(userDetail), nil
+	}
+	fields["updated_by"] = userDetail.Email
+
+	err = s.repository.UpdateUser(userID, fields)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// taken by a concurrent signup or update since the check
+		return nil, ErrProfileTaken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error update user in database")
+		return nil, err
+	}
+
+	if email, ok := fields["email"].(string); ok {
+		userDetail.Email = email
+		userDetail.EmailVerifiedAt = nil
+	}
+	if username, ok := fields["username"].(string); ok {
+		userDetail.Username = username
+	}
+	if emailChanged {
+		// the change is saved at this point, a failed email can be resent
+		_ = s.sendVerification(userDetail.Email, userDetail.Username)
+	}
+	return profileResponse(userDetail), nil
+}
+
+// ChangePassword sets a new password after checking the current one, then
+// ends every login of the user like a password reset does. Wrong current
+// passwords are throttled like failed logins, a stolen session mustn't be
+// a way to guess the password.
+func (s *service) ChangePassword(userID uint, request memberships.ChangePasswordRequest) error {
+	ctx := context.Background()
+	userDetail, err := s.getUser(userID)
+	if err != nil {
+		return err
+	}
+
+	keys := s.guardKeys("password:"+strconv.FormatUint(uint64(userID), 10), "")
+	err = s.startAttempt(ctx, keys)
+	if err != nil {
+		return err
+	}
+	err = bcrypt.CompareHashAndPassword([]byte(userDetail.Password), []byte(request.CurrentPassword))
+	if err != nil {
+		return ErrInvalidPassword
+	}
+	err = s.attemptSucceeded(ctx, keys)
+	if err != nil {
+		return err
+	}
+
+	pass, err := bcrypt.GenerateFromPassword([]byte(request.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		log.Error().Err(err).Msg("error hash password")
+		return err
+	}
+
+	err = s.repository.UpdateUser(userID, map[string]interface{}{
+		"password":   string(pass),
+		"updated_by": userDetail.Email,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error update password in database")
+		return err
+	}
+
+	return s.LogoutAll(userID)
+}
+
+// DeleteAccount removes the user and everything it owns. The token version is
+// bumped so access tokens still in flight stop working too.
+func (s *service) DeleteAccount(userID uint) error {
+	err := s.repository.DeleteUser(userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error delete user from database")
+		return err
+	}
+
+	_, err = s.revocationStore.BumpTokenVersion(context.Background(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error bump token version")
+		return err
+	}
+	return nil
+}
+
+func (s *service) getUser(userID uint) (*memberships.User, error) {
+	userDetail, err := s.repository.GetUser("", "", userID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return nil, err
+	}
+	return userDetail, nil
+}
+
+// checkAvailable fails when another user already has the email or username.
+func (s *service) checkAvailable(email, username string) error {
+	_, err := s.repository.GetUser(email, username, 0)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	return ErrProfileTaken
+}
+
+func profileResponse(user *memberships.User) *memberships.ProfileResponse {
+	return &memberships.ProfileResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Username:      user.Username,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		CreatedAt:     user.CreatedAt,
+	}
+}
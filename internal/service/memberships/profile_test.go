@@ -0,0 +1,342 @@
+package memberships
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
+	"github.com/xprasetio/go-spotify/pkg/mail"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func testUser() *memberships.User {
+	verifiedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	return &memberships.User{
+		Model: gorm.Model{
+			ID:        1,
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Email:           "test@gmail.com",
+		Username:        "yeremia",
+		Password:        "$2a$10$VSvs98Wps1l5S/BFj2Mc0Od4HMzBbUK9hvT3ZRmjhenclObC8CeDC",
+		EmailVerifiedAt: &verifiedAt,
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func Test_service_GetProfile(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+
+	tests := []struct {
+		name    string
+		want    *memberships.ProfileResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: &memberships.ProfileResponse{
+				ID:            1,
+				Email:         "test@gmail.com",
+				Username:      "yeremia",
+				EmailVerified: true,
+				CreatedAt:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+			},
+		},
+		{
+			name:    "failed: user not found",
+			wantErr: ErrUserNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:        &configs.Config{},
+				repository: mockRepo,
+			}
+			got, err := s.GetProfile(1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_UpdateProfile(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockMailer := NewMockmailer(ctrlMock)
+
+	tests := []struct {
+		name              string
+		request           memberships.UpdateProfileRequest
+		wantEmail         string
+		wantUsername      string
+		wantEmailVerified bool
+		wantErr           error
+		mockFn            func()
+	}{
+		{
+			name:              "success: change username",
+			request:           memberships.UpdateProfileRequest{Username: strPtr("newname")},
+			wantEmail:         "test@gmail.com",
+			wantUsername:      "newname",
+			wantEmailVerified: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUser("", "newname", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().UpdateUser(uint(1), map[string]interface{}{
+					"username":   "newname",
+					"updated_by": "test@gmail.com",
+				}).Return(nil)
+			},
+		},
+		{
+			name:         "success: change email needs verifying again",
+			request:      memberships.UpdateProfileRequest{Email: strPtr("new@gmail.com")},
+			wantEmail:    "new@gmail.com",
+			wantUsername: "yeremia",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUser("new@gmail.com", "", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().UpdateUser(uint(1), map[string]interface{}{
+					"email":             "new@gmail.com",
+					"email_verified_at": nil,
+					"updated_by":        "test@gmail.com",
+				}).Return(nil)
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message mail.Message) error {
+					assert.Equal(t, "new@gmail.com", message.To)
+					return nil
+				})
+			},
+		},
+		{
+			name:              "success: nothing changed",
+			request:           memberships.UpdateProfileRequest{Email: strPtr("test@gmail.com")},
+			wantEmail:         "test@gmail.com",
+			wantUsername:      "yeremia",
+			wantEmailVerified: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+			},
+		},
+		{
+			name:    "failed: username taken",
+			request: memberships.UpdateProfileRequest{Username: strPtr("taken")},
+			wantErr: ErrProfileTaken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUser("", "taken", uint(0)).Return(&memberships.User{Username: "taken"}, nil)
+			},
+		},
+		{
+			name:    "failed: username taken concurrently",
+			request: memberships.UpdateProfileRequest{Username: strPtr("newname")},
+			wantErr: ErrProfileTaken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUser("", "newname", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().UpdateUser(uint(1), gomock.Any()).Return(gorm.ErrDuplicatedKey)
+			},
+		},
+		{
+			name:    "failed: when update user",
+			request: memberships.UpdateProfileRequest{Username: strPtr("newname")},
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockRepo.EXPECT().GetUser("", "newname", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().UpdateUser(uint(1), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg: &configs.Config{
+					Service: configs.Service{
+						SecretKey: "abc",
+					},
+				},
+				repository: mockRepo,
+				mailer:     mockMailer,
+			}
+			got, err := s.UpdateProfile(1, tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantEmail, got.Email)
+			assert.Equal(t, tt.wantUsername, got.Username)
+			assert.Equal(t, tt.wantEmailVerified, got.EmailVerified)
+		})
+	}
+}
+
+func Test_service_ChangePassword(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+	mockGuard := NewMockloginGuard(ctrlMock)
+
+	s := &service{
+		cfg:             &configs.Config{},
+		repository:      mockRepo,
+		revocationStore: mockStore,
+		loginGuard:      mockGuard,
+	}
+	accountPolicy, _ := s.loginPolicies()
+	lockedErr := &loginguard.LockedError{RetryAfter: time.Minute}
+
+	tests := []struct {
+		name    string
+		request memberships.ChangePasswordRequest
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success",
+			request: memberships.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "new password"},
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "password:1", accountPolicy).Return(nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "password:1").Return(nil)
+				mockRepo.EXPECT().UpdateUser(uint(1), gomock.Any()).DoAndReturn(func(_ uint, fields map[string]interface{}) error {
+					assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(fields["password"].(string)), []byte("new password")))
+					assert.Equal(t, "test@gmail.com", fields["updated_by"])
+					return nil
+				})
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(1), nil)
+				mockRepo.EXPECT().RevokeUserSessions(uint(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: wrong current password",
+			request: memberships.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "new password"},
+			wantErr: ErrInvalidPassword,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "password:1", accountPolicy).Return(nil)
+			},
+		},
+		{
+			name:    "failed: too many wrong passwords",
+			request: memberships.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "new password"},
+			wantErr: lockedErr,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "password:1", accountPolicy).Return(lockedErr)
+			},
+		},
+		{
+			name:    "failed: when update user",
+			request: memberships.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "new password"},
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(testUser(), nil)
+				mockGuard.EXPECT().Attempt(gomock.Any(), "password:1", accountPolicy).Return(nil)
+				mockGuard.EXPECT().Reset(gomock.Any(), "password:1").Return(nil)
+				mockRepo.EXPECT().UpdateUser(uint(1), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			err := s.ChangePassword(1, tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_service_DeleteAccount(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mockRepo.EXPECT().DeleteUser(uint(1)).Return(nil)
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(1), nil)
+			},
+		},
+		{
+			name:    "failed: when delete user",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().DeleteUser(uint(1)).Return(assert.AnError)
+			},
+		},
+		{
+			name:    "failed: when bump token version",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().DeleteUser(uint(1)).Return(nil)
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(0), assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:             &configs.Config{},
+				repository:      mockRepo,
+				revocationStore: mockStore,
+			}
+			err := s.DeleteAccount(1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -1,24 +1,112 @@
 package memberships
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/xprasetio/go-spotify/internal/configs"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"github.com/xprasetio/go-spotify/pkg/loginguard"
+	"github.com/xprasetio/go-spotify/pkg/mail"
 )
 
 //go:generate mockgen -source=service.go -destination=service_mock_test.go -package=memberships
 type repository interface {
 	CreateUser(model memberships.User) error
 	GetUser(email, username string, id uint) (*memberships.User, error)
+	UpdateUser(userID uint, fields map[string]interface{}) error
+	DeleteUser(userID uint) error
+	CreateSession(model memberships.Session) error
+	GetSession(tokenHash string) (*memberships.Session, error)
+	RotateSession(id uint, rotatedAt time.Time) (bool, error)
+	RevokeSessionFamily(familyID string, revokedAt time.Time) error
+	RevokeUserSessions(userID uint, revokedAt time.Time) error
+	VerifyEmail(userID uint, verifiedAt time.Time) error
+	CreatePasswordReset(model memberships.PasswordReset) error
+	GetPasswordReset(tokenHash string) (*memberships.PasswordReset, error)
+	ResetPassword(resetID, userID uint, password string, usedAt time.Time) (bool, error)
+	GetUserTOTP(userID uint) (*memberships.UserTOTP, error)
+	EnrollTOTP(model memberships.UserTOTP, codes []memberships.RecoveryCode) error
+	ConfirmTOTP(userID uint, step int64, confirmedAt time.Time) (bool, error)
+	UseTOTPStep(userID uint, step int64) (bool, error)
+	UseRecoveryCode(userID uint, codeHash string, usedAt time.Time) (bool, error)
+	CreateOAuthState(model memberships.OAuthState) error
+	GetOAuthState(stateHash string) (*memberships.OAuthState, error)
+	UseOAuthState(id uint, usedAt time.Time) (bool, error)
+	GetSpotifyAccount(spotifyUserID string) (*memberships.SpotifyAccount, error)
+	GetSpotifyAccountByUser(userID uint) (*memberships.SpotifyAccount, error)
+	UpdateSpotifyRefreshToken(userID uint, refreshToken string) error
+	SaveSpotifyAccount(model memberships.SpotifyAccount) error
+	CreateSpotifyUser(user *memberships.User, account memberships.SpotifyAccount) error
+}
+
+type revocationStore interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	TokenVersion(ctx context.Context, userID uint) (uint, error)
+	BumpTokenVersion(ctx context.Context, userID uint) (uint, error)
+}
+
+type mailer interface {
+	Send(ctx context.Context, message mail.Message) error
+}
+
+type loginGuard interface {
+	Attempt(ctx context.Context, key string, policy loginguard.Policy) error
+	Succeed(ctx context.Context, key string, policy loginguard.Policy) error
+	Reset(ctx context.Context, key string) error
+}
+
+type spotifyAuth interface {
+	AuthorizeURL(state, codeChallenge, redirectURI string, scopes []string) string
+	ExchangeCode(ctx context.Context, code, codeVerifier, redirectURI string) (*spotify.SpotifyUserTokenResponse, error)
+	GetCurrentUser(ctx context.Context, accessToken string) (*spotify.SpotifyUser, error)
+	RefreshUserToken(ctx context.Context, refreshToken string) (*spotify.SpotifyUserTokenResponse, error)
+}
+
+type keyring interface {
+	Encrypt(plaintext, associatedData []byte) (string, error)
+	Decrypt(value string, associatedData []byte) ([]byte, error)
 }
 
 type service struct {
-	cfg        *configs.Config
-	repository repository
+	cfg             *configs.Config
+	repository      repository
+	revocationStore revocationStore
+	keySet          *jwt.KeySet
+	mailer          mailer
+	loginGuard      loginGuard
+	spotifyAuth     spotifyAuth
+	keyring         keyring
+	spotifyTokens   spotifyTokenCache
+	// mails tracks mails sent in the background
+	mails sync.WaitGroup
 }
 
-func NewService(cfg *configs.Config, repository repository) *service {
+func NewService(cfg *configs.Config, repository repository, revocationStore revocationStore, keySet *jwt.KeySet, mailer mailer, loginGuard loginGuard, spotifyAuth spotifyAuth, keyring keyring) *service {
 	return &service{
-		cfg:        cfg,
-		repository: repository,
+		cfg:             cfg,
+		repository:      repository,
+		revocationStore: revocationStore,
+		keySet:          keySet,
+		mailer:          mailer,
+		loginGuard:      loginGuard,
+		spotifyAuth:     spotifyAuth,
+		keyring:         keyring,
+	}
+}
+
+// signingKeys falls back to the configured SecretKey when no key set is given.
+func (s *service) signingKeys() *jwt.KeySet {
+	if s.keySet != nil {
+		return s.keySet
 	}
+	return jwt.NewHMACKeySet(s.cfg.Service.SecretKey)
+}
+
+// JWKS returns the public keys tokens are verified with.
+func (s *service) JWKS() jwt.JWKS {
+	return s.signingKeys().JWKS()
 }
@@ -10,9 +10,14 @@
 package memberships
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	memberships "github.com/xprasetio/go-spotify/internal/models/memberships"
+	spotify "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	loginguard "github.com/xprasetio/go-spotify/pkg/loginguard"
+	mail "github.com/xprasetio/go-spotify/pkg/mail"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +44,77 @@ func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
 	return m.recorder
 }
 
+// ConfirmTOTP mocks base method.
+func (m *Mockrepository) ConfirmTOTP(userID uint, step int64, confirmedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", userID, step, confirmedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockrepositoryMockRecorder) ConfirmTOTP(userID, step, confirmedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*Mockrepository)(nil).ConfirmTOTP), userID, step, confirmedAt)
+}
+
+// CreateOAuthState mocks base method.
+func (m *Mockrepository) CreateOAuthState(model memberships.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOAuthState", model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOAuthState indicates an expected call of CreateOAuthState.
+func (mr *MockrepositoryMockRecorder) CreateOAuthState(model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOAuthState", reflect.TypeOf((*Mockrepository)(nil).CreateOAuthState), model)
+}
+
+// CreatePasswordReset mocks base method.
+func (m *Mockrepository) CreatePasswordReset(model memberships.PasswordReset) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePasswordReset", model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePasswordReset indicates an expected call of CreatePasswordReset.
+func (mr *MockrepositoryMockRecorder) CreatePasswordReset(model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePasswordReset", reflect.TypeOf((*Mockrepository)(nil).CreatePasswordReset), model)
+}
+
+// CreateSession mocks base method.
+func (m *Mockrepository) CreateSession(model memberships.Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockrepositoryMockRecorder) CreateSession(model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*Mockrepository)(nil).CreateSession), model)
+}
+
+// CreateSpotifyUser mocks base method.
+func (m *Mockrepository) CreateSpotifyUser(user *memberships.User, account memberships.SpotifyAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSpotifyUser", user, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSpotifyUser indicates an expected call of CreateSpotifyUser.
+func (mr *MockrepositoryMockRecorder) CreateSpotifyUser(user, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSpotifyUser", reflect.TypeOf((*Mockrepository)(nil).CreateSpotifyUser), user, account)
+}
+
 // CreateUser mocks base method.
 func (m *Mockrepository) CreateUser(model memberships.User) error {
 	m.ctrl.T.Helper()
@@ -53,6 +129,109 @@ func (mr *MockrepositoryMockRecorder) CreateUser(model any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*Mockrepository)(nil).CreateUser), model)
 }
 
+// DeleteUser mocks base method.
+func (m *Mockrepository) DeleteUser(userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockrepositoryMockRecorder) DeleteUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*Mockrepository)(nil).DeleteUser), userID)
+}
+
+// EnrollTOTP mocks base method.
+func (m *Mockrepository) EnrollTOTP(model memberships.UserTOTP, codes []memberships.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", model, codes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockrepositoryMockRecorder) EnrollTOTP(model, codes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*Mockrepository)(nil).EnrollTOTP), model, codes)
+}
+
+// GetOAuthState mocks base method.
+func (m *Mockrepository) GetOAuthState(stateHash string) (*memberships.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOAuthState", stateHash)
+	ret0, _ := ret[0].(*memberships.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOAuthState indicates an expected call of GetOAuthState.
+func (mr *MockrepositoryMockRecorder) GetOAuthState(stateHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOAuthState", reflect.TypeOf((*Mockrepository)(nil).GetOAuthState), stateHash)
+}
+
+// GetPasswordReset mocks base method.
+func (m *Mockrepository) GetPasswordReset(tokenHash string) (*memberships.PasswordReset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordReset", tokenHash)
+	ret0, _ := ret[0].(*memberships.PasswordReset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordReset indicates an expected call of GetPasswordReset.
+func (mr *MockrepositoryMockRecorder) GetPasswordReset(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordReset", reflect.TypeOf((*Mockrepository)(nil).GetPasswordReset), tokenHash)
+}
+
+// GetSession mocks base method.
+func (m *Mockrepository) GetSession(tokenHash string) (*memberships.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", tokenHash)
+	ret0, _ := ret[0].(*memberships.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockrepositoryMockRecorder) GetSession(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*Mockrepository)(nil).GetSession), tokenHash)
+}
+
+// GetSpotifyAccount mocks base method.
+func (m *Mockrepository) GetSpotifyAccount(spotifyUserID string) (*memberships.SpotifyAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpotifyAccount", spotifyUserID)
+	ret0, _ := ret[0].(*memberships.SpotifyAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpotifyAccount indicates an expected call of GetSpotifyAccount.
+func (mr *MockrepositoryMockRecorder) GetSpotifyAccount(spotifyUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpotifyAccount", reflect.TypeOf((*Mockrepository)(nil).GetSpotifyAccount), spotifyUserID)
+}
+
+// GetSpotifyAccountByUser mocks base method.
+func (m *Mockrepository) GetSpotifyAccountByUser(userID uint) (*memberships.SpotifyAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpotifyAccountByUser", userID)
+	ret0, _ := ret[0].(*memberships.SpotifyAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpotifyAccountByUser indicates an expected call of GetSpotifyAccountByUser.
+func (mr *MockrepositoryMockRecorder) GetSpotifyAccountByUser(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpotifyAccountByUser", reflect.TypeOf((*Mockrepository)(nil).GetSpotifyAccountByUser), userID)
+}
+
 // GetUser mocks base method.
 func (m *Mockrepository) GetUser(email, username string, id uint) (*memberships.User, error) {
 	m.ctrl.T.Helper()
@@ -67,3 +246,481 @@ func (mr *MockrepositoryMockRecorder) GetUser(email, username, id any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*Mockrepository)(nil).GetUser), email, username, id)
 }
+
+// GetUserTOTP mocks base method.
+func (m *Mockrepository) GetUserTOTP(userID uint) (*memberships.UserTOTP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserTOTP", userID)
+	ret0, _ := ret[0].(*memberships.UserTOTP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserTOTP indicates an expected call of GetUserTOTP.
+func (mr *MockrepositoryMockRecorder) GetUserTOTP(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserTOTP", reflect.TypeOf((*Mockrepository)(nil).GetUserTOTP), userID)
+}
+
+// ResetPassword mocks base method.
+func (m *Mockrepository) ResetPassword(resetID, userID uint, password string, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", resetID, userID, password, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockrepositoryMockRecorder) ResetPassword(resetID, userID, password, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*Mockrepository)(nil).ResetPassword), resetID, userID, password, usedAt)
+}
+
+// RevokeSessionFamily mocks base method.
+func (m *Mockrepository) RevokeSessionFamily(familyID string, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionFamily", familyID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessionFamily indicates an expected call of RevokeSessionFamily.
+func (mr *MockrepositoryMockRecorder) RevokeSessionFamily(familyID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionFamily", reflect.TypeOf((*Mockrepository)(nil).RevokeSessionFamily), familyID, revokedAt)
+}
+
+// RevokeUserSessions mocks base method.
+func (m *Mockrepository) RevokeUserSessions(userID uint, revokedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeUserSessions", userID, revokedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeUserSessions indicates an expected call of RevokeUserSessions.
+func (mr *MockrepositoryMockRecorder) RevokeUserSessions(userID, revokedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserSessions", reflect.TypeOf((*Mockrepository)(nil).RevokeUserSessions), userID, revokedAt)
+}
+
+// RotateSession mocks base method.
+func (m *Mockrepository) RotateSession(id uint, rotatedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", id, rotatedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockrepositoryMockRecorder) RotateSession(id, rotatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*Mockrepository)(nil).RotateSession), id, rotatedAt)
+}
+
+// SaveSpotifyAccount mocks base method.
+func (m *Mockrepository) SaveSpotifyAccount(model memberships.SpotifyAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSpotifyAccount", model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSpotifyAccount indicates an expected call of SaveSpotifyAccount.
+func (mr *MockrepositoryMockRecorder) SaveSpotifyAccount(model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSpotifyAccount", reflect.TypeOf((*Mockrepository)(nil).SaveSpotifyAccount), model)
+}
+
+// UpdateSpotifyRefreshToken mocks base method.
+func (m *Mockrepository) UpdateSpotifyRefreshToken(userID uint, refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSpotifyRefreshToken", userID, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSpotifyRefreshToken indicates an expected call of UpdateSpotifyRefreshToken.
+func (mr *MockrepositoryMockRecorder) UpdateSpotifyRefreshToken(userID, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSpotifyRefreshToken", reflect.TypeOf((*Mockrepository)(nil).UpdateSpotifyRefreshToken), userID, refreshToken)
+}
+
+// UpdateUser mocks base method.
+func (m *Mockrepository) UpdateUser(userID uint, fields map[string]any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", userID, fields)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockrepositoryMockRecorder) UpdateUser(userID, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*Mockrepository)(nil).UpdateUser), userID, fields)
+}
+
+// UseOAuthState mocks base method.
+func (m *Mockrepository) UseOAuthState(id uint, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseOAuthState", id, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseOAuthState indicates an expected call of UseOAuthState.
+func (mr *MockrepositoryMockRecorder) UseOAuthState(id, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseOAuthState", reflect.TypeOf((*Mockrepository)(nil).UseOAuthState), id, usedAt)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *Mockrepository) UseRecoveryCode(userID uint, codeHash string, usedAt time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userID, codeHash, usedAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockrepositoryMockRecorder) UseRecoveryCode(userID, codeHash, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*Mockrepository)(nil).UseRecoveryCode), userID, codeHash, usedAt)
+}
+
+// UseTOTPStep mocks base method.
+func (m *Mockrepository) UseTOTPStep(userID uint, step int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", userID, step)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockrepositoryMockRecorder) UseTOTPStep(userID, step any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*Mockrepository)(nil).UseTOTPStep), userID, step)
+}
+
+// VerifyEmail mocks base method.
+func (m *Mockrepository) VerifyEmail(userID uint, verifiedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", userID, verifiedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockrepositoryMockRecorder) VerifyEmail(userID, verifiedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*Mockrepository)(nil).VerifyEmail), userID, verifiedAt)
+}
+
+// MockrevocationStore is a mock of revocationStore interface.
+type MockrevocationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockrevocationStoreMockRecorder
+}
+
+// MockrevocationStoreMockRecorder is the mock recorder for MockrevocationStore.
+type MockrevocationStoreMockRecorder struct {
+	mock *MockrevocationStore
+}
+
+// NewMockrevocationStore creates a new mock instance.
+func NewMockrevocationStore(ctrl *gomock.Controller) *MockrevocationStore {
+	mock := &MockrevocationStore{ctrl: ctrl}
+	mock.recorder = &MockrevocationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockrevocationStore) EXPECT() *MockrevocationStoreMockRecorder {
+	return m.recorder
+}
+
+// BumpTokenVersion mocks base method.
+func (m *MockrevocationStore) BumpTokenVersion(ctx context.Context, userID uint) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BumpTokenVersion", ctx, userID)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BumpTokenVersion indicates an expected call of BumpTokenVersion.
+func (mr *MockrevocationStoreMockRecorder) BumpTokenVersion(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BumpTokenVersion", reflect.TypeOf((*MockrevocationStore)(nil).BumpTokenVersion), ctx, userID)
+}
+
+// Revoke mocks base method.
+func (m *MockrevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", ctx, jti, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockrevocationStoreMockRecorder) Revoke(ctx, jti, expiresAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockrevocationStore)(nil).Revoke), ctx, jti, expiresAt)
+}
+
+// TokenVersion mocks base method.
+func (m *MockrevocationStore) TokenVersion(ctx context.Context, userID uint) (uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenVersion", ctx, userID)
+	ret0, _ := ret[0].(uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenVersion indicates an expected call of TokenVersion.
+func (mr *MockrevocationStoreMockRecorder) TokenVersion(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenVersion", reflect.TypeOf((*MockrevocationStore)(nil).TokenVersion), ctx, userID)
+}
+
+// Mockmailer is a mock of mailer interface.
+type Mockmailer struct {
+	ctrl     *gomock.Controller
+	recorder *MockmailerMockRecorder
+}
+
+// MockmailerMockRecorder is the mock recorder for Mockmailer.
+type MockmailerMockRecorder struct {
+	mock *Mockmailer
+}
+
+// NewMockmailer creates a new mock instance.
+func NewMockmailer(ctrl *gomock.Controller) *Mockmailer {
+	mock := &Mockmailer{ctrl: ctrl}
+	mock.recorder = &MockmailerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockmailer) EXPECT() *MockmailerMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *Mockmailer) Send(ctx context.Context, message mail.Message) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockmailerMockRecorder) Send(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*Mockmailer)(nil).Send), ctx, message)
+}
+
+// MockloginGuard is a mock of loginGuard interface.
+type MockloginGuard struct {
+	ctrl     *gomock.Controller
+	recorder *MockloginGuardMockRecorder
+}
+
+// MockloginGuardMockRecorder is the mock recorder for MockloginGuard.
+type MockloginGuardMockRecorder struct {
+	mock *MockloginGuard
+}
+
+// NewMockloginGuard creates a new mock instance.
+func NewMockloginGuard(ctrl *gomock.Controller) *MockloginGuard {
+	mock := &MockloginGuard{ctrl: ctrl}
+	mock.recorder = &MockloginGuardMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockloginGuard) EXPECT() *MockloginGuardMockRecorder {
+	return m.recorder
+}
+
+// Attempt mocks base method.
+func (m *MockloginGuard) Attempt(ctx context.Context, key string, policy loginguard.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attempt", ctx, key, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attempt indicates an expected call of Attempt.
+func (mr *MockloginGuardMockRecorder) Attempt(ctx, key, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attempt", reflect.TypeOf((*MockloginGuard)(nil).Attempt), ctx, key, policy)
+}
+
+// Reset mocks base method.
+func (m *MockloginGuard) Reset(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockloginGuardMockRecorder) Reset(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockloginGuard)(nil).Reset), ctx, key)
+}
+
+// Succeed mocks base method.
+func (m *MockloginGuard) Succeed(ctx context.Context, key string, policy loginguard.Policy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Succeed", ctx, key, policy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Succeed indicates an expected call of Succeed.
+func (mr *MockloginGuardMockRecorder) Succeed(ctx, key, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Succeed", reflect.TypeOf((*MockloginGuard)(nil).Succeed), ctx, key, policy)
+}
+
+// MockspotifyAuth is a mock of spotifyAuth interface.
+type MockspotifyAuth struct {
+	ctrl     *gomock.Controller
+	recorder *MockspotifyAuthMockRecorder
+}
+
+// MockspotifyAuthMockRecorder is the mock recorder for MockspotifyAuth.
+type MockspotifyAuthMockRecorder struct {
+	mock *MockspotifyAuth
+}
+
+// NewMockspotifyAuth creates a new mock instance.
+func NewMockspotifyAuth(ctrl *gomock.Controller) *MockspotifyAuth {
+	mock := &MockspotifyAuth{ctrl: ctrl}
+	mock.recorder = &MockspotifyAuthMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockspotifyAuth) EXPECT() *MockspotifyAuthMockRecorder {
+	return m.recorder
+}
+
+// AuthorizeURL mocks base method.
+func (m *MockspotifyAuth) AuthorizeURL(state, codeChallenge, redirectURI string, scopes []string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthorizeURL", state, codeChallenge, redirectURI, scopes)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// AuthorizeURL indicates an expected call of AuthorizeURL.
+func (mr *MockspotifyAuthMockRecorder) AuthorizeURL(state, codeChallenge, redirectURI, scopes any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthorizeURL", reflect.TypeOf((*MockspotifyAuth)(nil).AuthorizeURL), state, codeChallenge, redirectURI, scopes)
+}
+
+// ExchangeCode mocks base method.
+func (m *MockspotifyAuth) ExchangeCode(ctx context.Context, code, codeVerifier, redirectURI string) (*spotify.SpotifyUserTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExchangeCode", ctx, code, codeVerifier, redirectURI)
+	ret0, _ := ret[0].(*spotify.SpotifyUserTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExchangeCode indicates an expected call of ExchangeCode.
+func (mr *MockspotifyAuthMockRecorder) ExchangeCode(ctx, code, codeVerifier, redirectURI any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExchangeCode", reflect.TypeOf((*MockspotifyAuth)(nil).ExchangeCode), ctx, code, codeVerifier, redirectURI)
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockspotifyAuth) GetCurrentUser(ctx context.Context, accessToken string) (*spotify.SpotifyUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", ctx, accessToken)
+	ret0, _ := ret[0].(*spotify.SpotifyUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockspotifyAuthMockRecorder) GetCurrentUser(ctx, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockspotifyAuth)(nil).GetCurrentUser), ctx, accessToken)
+}
+
+// RefreshUserToken mocks base method.
+func (m *MockspotifyAuth) RefreshUserToken(ctx context.Context, refreshToken string) (*spotify.SpotifyUserTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshUserToken", ctx, refreshToken)
+	ret0, _ := ret[0].(*spotify.SpotifyUserTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshUserToken indicates an expected call of RefreshUserToken.
+func (mr *MockspotifyAuthMockRecorder) RefreshUserToken(ctx, refreshToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshUserToken", reflect.TypeOf((*MockspotifyAuth)(nil).RefreshUserToken), ctx, refreshToken)
+}
+
+// Mockkeyring is a mock of keyring interface.
+type Mockkeyring struct {
+	ctrl     *gomock.Controller
+	recorder *MockkeyringMockRecorder
+}
+
+// MockkeyringMockRecorder is the mock recorder for Mockkeyring.
+type MockkeyringMockRecorder struct {
+	mock *Mockkeyring
+}
+
+// NewMockkeyring creates a new mock instance.
+func NewMockkeyring(ctrl *gomock.Controller) *Mockkeyring {
+	mock := &Mockkeyring{ctrl: ctrl}
+	mock.recorder = &MockkeyringMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockkeyring) EXPECT() *MockkeyringMockRecorder {
+	return m.recorder
+}
+
+// Decrypt mocks base method.
+func (m *Mockkeyring) Decrypt(value string, associatedData []byte) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decrypt", value, associatedData)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrypt indicates an expected call of Decrypt.
+func (mr *MockkeyringMockRecorder) Decrypt(value, associatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrypt", reflect.TypeOf((*Mockkeyring)(nil).Decrypt), value, associatedData)
+}
+
+// Encrypt mocks base method.
+func (m *Mockkeyring) Encrypt(plaintext, associatedData []byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Encrypt", plaintext, associatedData)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Encrypt indicates an expected call of Encrypt.
+func (mr *MockkeyringMockRecorder) Encrypt(plaintext, associatedData any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Encrypt", reflect.TypeOf((*Mockkeyring)(nil).Encrypt), plaintext, associatedData)
+}
@@ -0,0 +1,197 @@
+package memberships
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/jwt"
+	"gorm.io/gorm"
+)
+
+const defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused means an already rotated token came back, the
+	// whole family is revoked since either copy may be stolen.
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+func (s *service) Refresh(request memberships.RefreshRequest) (*memberships.LoginResponse, error) {
+	session, err := s.getSession(request.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || now.After(session.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if session.RotatedAt != nil {
+		return nil, s.revokeReusedFamily(session, now)
+	}
+
+	rotated, err := s.repository.RotateSession(session.ID, now)
+	if err != nil {
+		log.Error().Err(err).Msg("error rotate session")
+		return nil, err
+	}
+	if !rotated {
+		// a concurrent refresh used the same token first
+		return nil, s.revokeReusedFamily(session, now)
+	}
+
+	userDetail, err := s.repository.GetUser("", "", session.UserID)
+	if err == gorm.ErrRecordNotFound {
+		// the user was deleted, the login ends with them
+		err = s.repository.RevokeSessionFamily(session.FamilyID, now)
+		if err != nil {
+			log.Error().Err(err).Msg("error revoke session family")
+			return nil, err
+		}
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return nil, err
+	}
+	return s.issueTokens(userDetail, session.FamilyID)
+}
+
+// Logout revokes the session family of the refresh token, ending that login
+// on every token it was rotated into, and the access token of the request.
+func (s *service) Logout(request memberships.LogoutRequest) error {
+	session, err := s.getSession(request.RefreshToken)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.RevokeSessionFamily(session.FamilyID, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("error revoke session family")
+		return err
+	}
+
+	if request.AccessTokenID != "" {
+		err = s.revocationStore.Revoke(context.Background(), request.AccessTokenID, request.AccessTokenExpiresAt)
+		if err != nil {
+			log.Error().Err(err).Msg("error revoke access token")
+			return err
+		}
+	}
+	return nil
+}
+
+// LogoutAll ends every login of the user. Bumping the token version rejects
+// all access tokens issued so far, revoking the sessions stops the refresh
+// tokens from minting new ones.
+func (s *service) LogoutAll(userID uint) error {
+	_, err := s.revocationStore.BumpTokenVersion(context.Background(), userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error bump token version")
+		return err
+	}
+
+	err = s.repository.RevokeUserSessions(userID, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("error revoke user sessions")
+		return err
+	}
+	return nil
+}
+
+func (s *service) getSession(refreshToken string) (*memberships.Session, error) {
+	session, err := s.repository.GetSession(hashToken(refreshToken))
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get session from database")
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *service) revokeReusedFamily(session *memberships.Session, now time.Time) error {
+	log.Warn().Uint("userID", session.UserID).Str("familyID", session.FamilyID).Msg("refresh token reuse detected, revoking family")
+	err := s.repository.RevokeSessionFamily(session.FamilyID, now)
+	if err != nil {
+		log.Error().Err(err).Msg("error revoke session family")
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// issueTokens creates an access token and a refresh token stored as a new
+// session of familyID. An empty familyID starts a new family.
+func (s *service) issueTokens(userDetail *memberships.User, familyID string) (*memberships.LoginResponse, error) {
+	tokenVersion, err := s.revocationStore.TokenVersion(context.Background(), userDetail.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get token version")
+		return nil, err
+	}
+
+	accessToken, err := s.signingKeys().CreateToken(jwt.Claims{
+		UserID:        userDetail.ID,
+		Username:      userDetail.Username,
+		TokenVersion:  tokenVersion,
+		EmailVerified: userDetail.EmailVerifiedAt != nil,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create JWT Token")
+		return nil, err
+	}
+
+	if familyID == "" {
+		familyID, err = randomToken(16)
+		if err != nil {
+			return nil, err
+		}
+	}
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.cfg.Service.RefreshTokenTTL
+	if ttl <= 0 {
+		ttl = defaultRefreshTokenTTL
+	}
+	err = s.repository.CreateSession(memberships.Session{
+		UserID:    userDetail.ID,
+		FamilyID:  familyID,
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error create session to database")
+		return nil, err
+	}
+
+	return &memberships.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func randomToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		log.Error().Err(err).Msg("error generate random token")
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken is what gets stored, a leaked sessions table can't be replayed.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,268 @@
+package memberships
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_Refresh(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+
+	refreshToken := "refreshToken"
+	tokenHash := hashToken(refreshToken)
+	now := time.Now()
+	newSession := func() *memberships.Session {
+		return &memberships.Session{
+			Model:     gorm.Model{ID: 7},
+			UserID:    1,
+			FamilyID:  "familyID",
+			TokenHash: tokenHash,
+			ExpiresAt: now.Add(time.Hour),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: rotate into the same family",
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(newSession(), nil)
+				mockRepo.EXPECT().RotateSession(uint(7), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(&memberships.User{
+					Model:    gorm.Model{ID: 1},
+					Username: "yeremia",
+				}, nil)
+				mockStore.EXPECT().TokenVersion(gomock.Any(), uint(1)).Return(uint(2), nil)
+				mockRepo.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(model memberships.Session) error {
+					assert.Equal(t, "familyID", model.FamilyID)
+					assert.NotEqual(t, tokenHash, model.TokenHash)
+					return nil
+				})
+			},
+		},
+		{
+			name:    "failed: unknown token",
+			wantErr: ErrInvalidRefreshToken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: expired",
+			wantErr: ErrInvalidRefreshToken,
+			mockFn: func() {
+				session := newSession()
+				session.ExpiresAt = now.Add(-time.Minute)
+				mockRepo.EXPECT().GetSession(tokenHash).Return(session, nil)
+			},
+		},
+		{
+			name:    "failed: revoked",
+			wantErr: ErrInvalidRefreshToken,
+			mockFn: func() {
+				session := newSession()
+				session.RevokedAt = &now
+				mockRepo.EXPECT().GetSession(tokenHash).Return(session, nil)
+			},
+		},
+		{
+			name:    "failed: reused token revokes the family",
+			wantErr: ErrRefreshTokenReused,
+			mockFn: func() {
+				session := newSession()
+				session.RotatedAt = &now
+				mockRepo.EXPECT().GetSession(tokenHash).Return(session, nil)
+				mockRepo.EXPECT().RevokeSessionFamily("familyID", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: concurrent refresh won the rotation",
+			wantErr: ErrRefreshTokenReused,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(newSession(), nil)
+				mockRepo.EXPECT().RotateSession(uint(7), gomock.Any()).Return(false, nil)
+				mockRepo.EXPECT().RevokeSessionFamily("familyID", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: deleted user revokes the family",
+			wantErr: ErrInvalidRefreshToken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(newSession(), nil)
+				mockRepo.EXPECT().RotateSession(uint(7), gomock.Any()).Return(true, nil)
+				mockRepo.EXPECT().GetUser("", "", uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().RevokeSessionFamily("familyID", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: when get session",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg: &configs.Config{
+					Service: configs.Service{
+						SecretKey: "abc",
+					},
+				},
+				repository:      mockRepo,
+				revocationStore: mockStore,
+			}
+			got, err := s.Refresh(memberships.RefreshRequest{RefreshToken: refreshToken})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotEmpty(t, got.AccessToken)
+			assert.NotEmpty(t, got.RefreshToken)
+			assert.NotEqual(t, refreshToken, got.RefreshToken)
+		})
+	}
+}
+
+func Test_service_Logout(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+
+	tokenHash := hashToken("refreshToken")
+	expiresAt := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		request memberships.LogoutRequest
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success",
+			request: memberships.LogoutRequest{RefreshToken: "refreshToken"},
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(&memberships.Session{FamilyID: "familyID"}, nil)
+				mockRepo.EXPECT().RevokeSessionFamily("familyID", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success: revokes the access token",
+			request: memberships.LogoutRequest{
+				RefreshToken:         "refreshToken",
+				AccessTokenID:        "jti",
+				AccessTokenExpiresAt: expiresAt,
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(&memberships.Session{FamilyID: "familyID"}, nil)
+				mockRepo.EXPECT().RevokeSessionFamily("familyID", gomock.Any()).Return(nil)
+				mockStore.EXPECT().Revoke(gomock.Any(), "jti", expiresAt).Return(nil)
+			},
+		},
+		{
+			name:    "failed: unknown token",
+			request: memberships.LogoutRequest{RefreshToken: "refreshToken"},
+			wantErr: ErrInvalidRefreshToken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: when revoke",
+			request: memberships.LogoutRequest{RefreshToken: "refreshToken"},
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSession(tokenHash).Return(&memberships.Session{FamilyID: "familyID"}, nil)
+				mockRepo.EXPECT().RevokeSessionFamily("familyID", gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:             &configs.Config{},
+				repository:      mockRepo,
+				revocationStore: mockStore,
+			}
+			err := s.Logout(tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_service_LogoutAll(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockStore := NewMockrevocationStore(ctrlMock)
+
+	tests := []struct {
+		name    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(3), nil)
+				mockRepo.EXPECT().RevokeUserSessions(uint(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: when bump token version",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(0), assert.AnError)
+			},
+		},
+		{
+			name:    "failed: when revoke sessions",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockStore.EXPECT().BumpTokenVersion(gomock.Any(), uint(1)).Return(uint(3), nil)
+				mockRepo.EXPECT().RevokeUserSessions(uint(1), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg:             &configs.Config{},
+				repository:      mockRepo,
+				revocationStore: mockStore,
+			}
+			err := s.LogoutAll(1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
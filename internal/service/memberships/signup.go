@@ -2,6 +2,7 @@ package memberships
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
@@ -10,6 +11,7 @@ import (
 )
 
 func (s *service) SignUp(request memberships.SignUpRequest) error {
+	request.Email = normalizeEmail(request.Email)
 	existingUser, err := s.repository.GetUser(request.Email, request.Username, 0)
 	if err != nil && err != gorm.ErrRecordNotFound {
 		log.Error().Err(err).Msg("error get user from database")
@@ -33,5 +35,18 @@ func (s *service) SignUp(request memberships.SignUpRequest) error {
 		CreatedBy: request.Email,
 		UpdatedBy: request.Email,
 	}
-	return s.repository.CreateUser(model)
+	err = s.repository.CreateUser(model)
+	if err != nil {
+		return err
+	}
+
+	// the account exists at this point, a failed email can be resent
+	_ = s.sendVerification(request.Email, request.Username)
+	return nil
+}
+
+// normalizeEmail stores and looks up emails in one form, so the case a user
+// types doesn't matter.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
 }
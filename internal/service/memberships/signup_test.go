@@ -1,11 +1,13 @@
 package memberships
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xprasetio/go-spotify/internal/configs"
 	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/mail"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -15,6 +17,7 @@ func Test_service_SignUp(t *testing.T) {
 	defer ctrlMock.Finish()
 
 	mockRepo := NewMockrepository(ctrlMock)
+	mockMailer := NewMockmailer(ctrlMock)
 
 	type args struct {
 		request memberships.SignUpRequest
@@ -35,9 +38,33 @@ func Test_service_SignUp(t *testing.T) {
 				},
 			},
 			wantErr: false,
+			mockFn: func(args args) {
+				mockRepo.EXPECT().GetUser(args.request.Email, args.request.Username, uint(0)).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(model memberships.User) error {
+					assert.Nil(t, model.EmailVerifiedAt)
+					return nil
+				})
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, message mail.Message) error {
+					assert.Equal(t, "test@gmail.com", message.To)
+					assert.Contains(t, message.Body, "http://localhost/verify-email?token=")
+					return nil
+				})
+			},
+		},
+		{
+			name: "success even when the verification email fails",
+			args: args{
+				request: memberships.SignUpRequest{
+					Email:    "test@gmail.com",
+					Username: "testusername",
+					Password: "password",
+				},
+			},
+			wantErr: false,
 			mockFn: func(args args) {
 				mockRepo.EXPECT().GetUser(args.request.Email, args.request.Username, uint(0)).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().CreateUser(gomock.Any()).Return(nil)
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(assert.AnError)
 			},
 		},
 		{
@@ -74,8 +101,14 @@ func Test_service_SignUp(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFn(tt.args)
 			s := &service{
-				cfg:        &configs.Config{},
+				cfg: &configs.Config{
+					Service: configs.Service{
+						SecretKey:       "abc",
+						VerificationURL: "http://localhost/verify-email",
+					},
+				},
 				repository: mockRepo,
+				mailer:     mockMailer,
 			}
 			if err := s.SignUp(tt.args.request); (err != nil) != tt.wantErr {
 				t.Errorf("service.SignUp() error = %v, wantErr %v", err, tt.wantErr)
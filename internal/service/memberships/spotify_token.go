@@ -0,0 +1,136 @@
+package memberships
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"golang.org/x/sync/singleflight"
+	"gorm.io/gorm"
+)
+
+// spotifyTokenRefreshSkew refreshes user access tokens this long before
+// they expire, so a long call doesn't run out of token halfway.
+const spotifyTokenRefreshSkew = time.Minute
+
+// spotifyTokenCache keeps the access tokens of linked users in memory, only
+// the refresh tokens are stored.
+type spotifyTokenCache struct {
+	mu      sync.Mutex
+	tokens  map[uint]cachedSpotifyToken
+	refresh singleflight.Group
+}
+
+type cachedSpotifyToken struct {
+	accessToken string
+	expiresAt   time.Time
+}
+
+func (c *spotifyTokenCache) get(userID uint, validUntil time.Time) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	token, ok := c.tokens[userID]
+	if !ok || !validUntil.Before(token.expiresAt) {
+		return "", false
+	}
+	return token.accessToken, true
+}
+
+func (c *spotifyTokenCache) set(userID uint, accessToken string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tokens == nil {
+		c.tokens = make(map[uint]cachedSpotifyToken)
+	}
+	c.tokens[userID] = cachedSpotifyToken{accessToken: accessToken, expiresAt: expiresAt}
+}
+
+func (c *spotifyTokenCache) drop(userID uint) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tokens, userID)
+}
+
+// SpotifyAccessToken returns an access token acting for the user on
+// spotify, refreshed with their stored refresh token when needed.
+func (s *service) SpotifyAccessToken(ctx context.Context, userID uint) (string, error) {
+	if accessToken, ok := s.spotifyTokens.get(userID, time.Now().Add(spotifyTokenRefreshSkew)); ok {
+		return accessToken, nil
+	}
+
+	// concurrent callers share one refresh, a rotated refresh token must
+	// not be raced by a second refresh with the old one. It must not be
+	// cancelled by whichever request happened to start it either.
+	refreshCtx := context.WithoutCancel(ctx)
+	result := s.spotifyTokens.refresh.DoChan(strconv.FormatUint(uint64(userID), 10), func() (interface{}, error) {
+		return s.refreshSpotifyToken(refreshCtx, userID)
+	})
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return "", res.Err
+		}
+		return res.Val.(string), nil
+	}
+}
+
+// SpotifyLinked reports whether the user has linked a spotify account,
+// without refreshing any token.
+func (s *service) SpotifyLinked(ctx context.Context, userID uint) (bool, error) {
+	if _, ok := s.spotifyTokens.get(userID, time.Now()); ok {
+		return true, nil
+	}
+	_, err := s.repository.GetSpotifyAccountByUser(userID)
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get spotify account from database")
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *service) refreshSpotifyToken(ctx context.Context, userID uint) (string, error) {
+	account, err := s.repository.GetSpotifyAccountByUser(userID)
+	if err == gorm.ErrRecordNotFound {
+		return "", spotify.ErrNotLinked
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get spotify account from database")
+		return "", err
+	}
+
+	refreshToken, err := s.keyring.Decrypt(account.RefreshToken, spotifyTokenAAD(account.SpotifyUserID))
+	if err != nil {
+		log.Error().Err(err).Uint("userID", userID).Msg("error decrypt spotify refresh token")
+		return "", err
+	}
+	token, err := s.spotifyAuth.RefreshUserToken(ctx, string(refreshToken))
+	if err != nil {
+		log.Error().Err(err).Uint("userID", userID).Msg("error refresh spotify user token")
+		return "", err
+	}
+
+	if token.RefreshToken != "" && token.RefreshToken != string(refreshToken) {
+		sealed, err := s.keyring.Encrypt([]byte(token.RefreshToken), spotifyTokenAAD(account.SpotifyUserID))
+		if err != nil {
+			log.Error().Err(err).Msg("error encrypt spotify refresh token")
+			return "", err
+		}
+		err = s.repository.UpdateSpotifyRefreshToken(userID, sealed)
+		if err != nil {
+			log.Error().Err(err).Msg("error update spotify refresh token in database")
+			return "", err
+		}
+	}
+
+	s.spotifyTokens.set(userID, token.AccessToken, time.Now().Add(time.Duration(token.ExpiresIn)*time.Second))
+	return token.AccessToken, nil
+}
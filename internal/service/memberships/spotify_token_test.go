@@ -0,0 +1,202 @@
+package memberships
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/encryption"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_SpotifyAccessToken(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockSpotify := NewMockspotifyAuth(ctrlMock)
+	keyring := testKeyring(t)
+
+	sealed, err := keyring.Encrypt([]byte("refresh"), spotifyTokenAAD("spotifyuser"))
+	assert.NoError(t, err)
+	account := &memberships.SpotifyAccount{UserID: 1, SpotifyUserID: "spotifyuser", RefreshToken: sealed}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: "access",
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(account, nil)
+				mockSpotify.EXPECT().RefreshUserToken(gomock.Any(), "refresh").
+					Return(&spotify.SpotifyUserTokenResponse{AccessToken: "access", ExpiresIn: 3600}, nil)
+			},
+		},
+		{
+			name: "success: stores a rotated refresh token",
+			want: "access",
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(account, nil)
+				mockSpotify.EXPECT().RefreshUserToken(gomock.Any(), "refresh").
+					Return(&spotify.SpotifyUserTokenResponse{AccessToken: "access", ExpiresIn: 3600, RefreshToken: "rotated"}, nil)
+				mockRepo.EXPECT().UpdateSpotifyRefreshToken(uint(1), gomock.Any()).DoAndReturn(func(_ uint, refreshToken string) error {
+					rotated, err := keyring.Decrypt(refreshToken, spotifyTokenAAD("spotifyuser"))
+					assert.NoError(t, err)
+					assert.Equal(t, "rotated", string(rotated))
+					return nil
+				})
+			},
+		},
+		{
+			name:    "failed: not linked",
+			wantErr: spotify.ErrNotLinked,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: refresh token sealed for another account",
+			wantErr: encryption.ErrDecrypt,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(&memberships.SpotifyAccount{
+					UserID:        1,
+					SpotifyUserID: "otheruser",
+					RefreshToken:  sealed,
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: refresh",
+			wantErr: spotify.ErrBadRequest,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(account, nil)
+				mockSpotify.EXPECT().RefreshUserToken(gomock.Any(), "refresh").
+					Return(nil, &spotify.APIError{StatusCode: 400})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository:  mockRepo,
+				spotifyAuth: mockSpotify,
+				keyring:     keyring,
+			}
+			got, err := s.SpotifyAccessToken(context.Background(), 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// the token is reused until shortly before it expires
+			got, err = s.SpotifyAccessToken(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_SpotifyAccessToken_cancelledCaller(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockSpotify := NewMockspotifyAuth(ctrlMock)
+	keyring := testKeyring(t)
+
+	sealed, err := keyring.Encrypt([]byte("refresh"), spotifyTokenAAD("spotifyuser"))
+	assert.NoError(t, err)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).
+		Return(&memberships.SpotifyAccount{UserID: 1, SpotifyUserID: "spotifyuser", RefreshToken: sealed}, nil)
+	mockSpotify.EXPECT().RefreshUserToken(gomock.Any(), "refresh").
+		DoAndReturn(func(ctx context.Context, _ string) (*spotify.SpotifyUserTokenResponse, error) {
+			close(started)
+			<-release
+			assert.NoError(t, ctx.Err())
+			return &spotify.SpotifyUserTokenResponse{AccessToken: "access", ExpiresIn: 3600}, nil
+		})
+
+	s := &service{
+		repository:  mockRepo,
+		spotifyAuth: mockSpotify,
+		keyring:     keyring,
+	}
+
+	// the caller that started the refresh goes away
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := s.SpotifyAccessToken(ctx, 1)
+		errs <- err
+	}()
+	<-started
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	// the refresh carries on for everyone else
+	close(release)
+	assert.Eventually(t, func() bool {
+		got, ok := s.spotifyTokens.get(1, time.Now())
+		return ok && got == "access"
+	}, time.Second, time.Millisecond)
+}
+
+func Test_service_SpotifyLinked(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: linked",
+			want: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(&memberships.SpotifyAccount{UserID: 1}, nil)
+			},
+		},
+		{
+			name: "success: not linked",
+			want: false,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetSpotifyAccountByUser(uint(1)).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			got, err := s.SpotifyLinked(context.Background(), 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
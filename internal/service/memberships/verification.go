@@ -0,0 +1,115 @@
+package memberships
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/pkg/mail"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultVerificationTokenTTL = 24 * time.Hour
+
+	unverifiedLoginBlock = "block"
+
+	verificationPurpose = "email-verification"
+)
+
+var (
+	ErrEmailNotVerified         = errors.New("email is not verified")
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+)
+
+func (s *service) VerifyEmail(request memberships.VerifyEmailRequest) error {
+	email, err := s.parseVerificationToken(request.Token)
+	if err != nil {
+		return err
+	}
+
+	userDetail, err := s.repository.GetUser(email, "", 0)
+	if err == gorm.ErrRecordNotFound {
+		// the email was changed since the token was sent
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if userDetail.EmailVerifiedAt != nil {
+		return nil
+	}
+
+	err = s.repository.VerifyEmail(userDetail.ID, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("error verify email in database")
+		return err
+	}
+	return nil
+}
+
+// ResendVerification sends a new verification email. Unknown and already
+// verified emails are ignored so the answer doesn't reveal who signed up.
+func (s *service) ResendVerification(request memberships.ResendVerificationRequest) error {
+	userDetail, err := s.repository.GetUser(normalizeEmail(request.Email), "", 0)
+	if err == gorm.ErrRecordNotFound {
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return err
+	}
+	if userDetail.EmailVerifiedAt != nil {
+		return nil
+	}
+	return s.sendVerification(userDetail.Email, userDetail.Username)
+}
+
+func (s *service) sendVerification(email, username string) error {
+	ttl := s.verificationTokenTTL()
+	token, err := s.signVerificationToken(email, time.Now().Add(ttl))
+	if err != nil {
+		return err
+	}
+
+	link := token
+	if s.cfg.Service.VerificationURL != "" {
+		link = s.cfg.Service.VerificationURL + "?token=" + url.QueryEscape(token)
+	}
+	err = s.mailer.Send(context.Background(), mail.Message{
+		To:      email,
+		Subject: "Verify your email",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address with the link below, it expires in %s.\n\n%s\n",
+			username, ttl, link),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error send verification email")
+		return err
+	}
+	return nil
+}
+
+func (s *service) verificationTokenTTL() time.Duration {
+	if s.cfg.Service.VerificationTokenTTL > 0 {
+		return s.cfg.Service.VerificationTokenTTL
+	}
+	return defaultVerificationTokenTTL
+}
+
+func (s *service) signVerificationToken(email string, expiresAt time.Time) (string, error) {
+	return s.signToken(verificationPurpose, email, expiresAt)
+}
+
+// parseVerificationToken returns the email of a valid, unexpired token.
+func (s *service) parseVerificationToken(token string) (string, error) {
+	email, ok := s.parseToken(verificationPurpose, token)
+	if !ok {
+		return "", ErrInvalidVerificationToken
+	}
+	return email, nil
+}
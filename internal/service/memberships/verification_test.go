@@ -0,0 +1,183 @@
+package memberships
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_VerifyEmail(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+
+	s := &service{
+		cfg: &configs.Config{
+			Service: configs.Service{
+				SecretKey: "abc",
+			},
+		},
+		repository: mockRepo,
+	}
+	now := time.Now()
+	token, err := s.signVerificationToken("test@gmail.com", now.Add(time.Hour))
+	assert.NoError(t, err)
+	expiredToken, err := s.signVerificationToken("test@gmail.com", now.Add(-time.Second))
+	assert.NoError(t, err)
+	otherKey := &service{cfg: &configs.Config{Service: configs.Service{SecretKey: "other"}}}
+	forgedToken, err := otherKey.signVerificationToken("test@gmail.com", now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:  "success",
+			token: token,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model: gorm.Model{ID: 1},
+					Email: "test@gmail.com",
+				}, nil)
+				mockRepo.EXPECT().VerifyEmail(uint(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "success: already verified",
+			token: token,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model:           gorm.Model{ID: 1},
+					Email:           "test@gmail.com",
+					EmailVerifiedAt: &now,
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: expired",
+			token:   expiredToken,
+			wantErr: ErrInvalidVerificationToken,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: signed with another key",
+			token:   forgedToken,
+			wantErr: ErrInvalidVerificationToken,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: malformed",
+			token:   "not-a-token",
+			wantErr: ErrInvalidVerificationToken,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: email no longer exists",
+			token:   token,
+			wantErr: ErrInvalidVerificationToken,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: when verify",
+			token:   token,
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Model: gorm.Model{ID: 1},
+					Email: "test@gmail.com",
+				}, nil)
+				mockRepo.EXPECT().VerifyEmail(uint(1), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			err := s.VerifyEmail(memberships.VerifyEmailRequest{Token: tt.token})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_service_ResendVerification(t *testing.T) {
+	ctrlMock := gomock.NewController(t)
+	defer ctrlMock.Finish()
+
+	mockRepo := NewMockrepository(ctrlMock)
+	mockMailer := NewMockmailer(ctrlMock)
+
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Email:    "test@gmail.com",
+					Username: "yeremia",
+				}, nil)
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success: unknown email sends nothing",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "success: verified email sends nothing",
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Email:           "test@gmail.com",
+					EmailVerifiedAt: &now,
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: when send",
+			wantErr: true,
+			mockFn: func() {
+				mockRepo.EXPECT().GetUser("test@gmail.com", "", uint(0)).Return(&memberships.User{
+					Email: "test@gmail.com",
+				}, nil)
+				mockMailer.EXPECT().Send(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				cfg: &configs.Config{
+					Service: configs.Service{
+						SecretKey: "abc",
+					},
+				},
+				repository: mockRepo,
+				mailer:     mockMailer,
+			}
+			err := s.ResendVerification(memberships.ResendVerificationRequest{Email: "test@gmail.com"})
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
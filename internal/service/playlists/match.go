@@ -0,0 +1,128 @@
+package playlists
+
+import (
+	"strings"
+	"unicode"
+
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+)
+
+const (
+	// matchThreshold is the score a search result needs to stand in for an
+	// imported entry, entries without one are reported unmatched.
+	matchThreshold = 0.8
+	// matchDurationSlack is how far the lengths of the same recording drift
+	// apart between releases and players.
+	matchDurationSlack = 10000
+	matchDurationCost  = 0.1
+	matchTitleWeight   = 0.7
+)
+
+// bestMatch picks the search result closest to the entry, false when none
+// is close enough.
+func bestMatch(entry playlistfile.Track, candidates []spotifyRepo.SpotifyTrackObject) (string, bool) {
+	bestID, bestScore := "", 0.0
+	for _, candidate := range candidates {
+		if score := matchScore(entry, candidate); score > bestScore {
+			bestID, bestScore = candidate.ID, score
+		}
+	}
+	return bestID, bestScore >= matchThreshold
+}
+
+// matchScore rates a search result from 0 to 1 by how much its title and
+// artists look like the ones of the entry. Versions in brackets and the
+// like are left out, a different length costs a little.
+func matchScore(entry playlistfile.Track, candidate spotifyRepo.SpotifyTrackObject) float64 {
+	score := similarity(normalizeTitle(entry.Title), normalizeTitle(candidate.Name))
+
+	if len(entry.Artists) > 0 {
+		want := normalizeName(strings.Join(entry.Artists, " "))
+		names := make([]string, len(candidate.Artists))
+		for idx, artist := range candidate.Artists {
+			names[idx] = artist.Name
+		}
+		artistScore := similarity(want, normalizeName(strings.Join(names, " ")))
+		if len(names) > 0 {
+			artistScore = max(artistScore, similarity(want, normalizeName(names[0])))
+		}
+		score = matchTitleWeight*score + (1-matchTitleWeight)*artistScore
+	}
+
+	if entry.DurationMs > 0 && candidate.DurationMs > 0 {
+		diff := entry.DurationMs - candidate.DurationMs
+		if diff > matchDurationSlack || diff < -matchDurationSlack {
+			score -= matchDurationCost
+		}
+	}
+	return score
+}
+
+// normalizeTitle drops what tells versions of a song apart, as in
+// "Song (Remastered 2011)", "Song - Live" or "Song feat. Someone".
+func normalizeTitle(title string) string {
+	title = strings.ToLower(title)
+	title = dropBracketed(title)
+	if head, _, found := strings.Cut(title, " - "); found && strings.TrimSpace(head) != "" {
+		title = head
+	}
+	for _, marker := range []string{" feat. ", " ft. ", " featuring "} {
+		title, _, _ = strings.Cut(title, marker)
+	}
+	return normalizeName(title)
+}
+
+// normalizeName keeps the letters and digits of a name, lower case and
+// single spaced.
+func normalizeName(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "&", " and ")
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+func dropBracketed(value string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range value {
+		switch r {
+		case '(', '[':
+			depth++
+		case ')', ']':
+			depth = max(depth-1, 0)
+		default:
+			if depth == 0 {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// similarity is 1 minus the edit distance of a and b relative to the
+// longer one, 0 when either is empty.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) == 0 || len(rb) == 0 {
+		return 0
+	}
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return 1 - float64(previous[len(rb)])/float64(max(len(ra), len(rb)))
+}
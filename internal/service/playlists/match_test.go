@@ -0,0 +1,70 @@
+package playlists
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+)
+
+func Test_bestMatch(t *testing.T) {
+	queen := []spotifyRepo.SpotifyArtistObject{{Name: "Queen"}}
+	tests := []struct {
+		name       string
+		entry      playlistfile.Track
+		candidates []spotifyRepo.SpotifyTrackObject
+		want       string
+		wantOK     bool
+	}{
+		{
+			name:  "remaster of the same song",
+			entry: playlistfile.Track{Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354000},
+			candidates: []spotifyRepo.SpotifyTrackObject{
+				{ID: "live", Name: "Bohemian Rhapsody - Live Aid", Artists: queen, DurationMs: 150000},
+				{ID: "remaster", Name: "Bohemian Rhapsody - Remastered 2011", Artists: queen, DurationMs: 354320},
+			},
+			want:   "remaster",
+			wantOK: true,
+		},
+		{
+			name:  "typo and featured artists",
+			entry: playlistfile.Track{Title: "Under Presure", Artists: []string{"Queen & David Bowie"}},
+			candidates: []spotifyRepo.SpotifyTrackObject{
+				{ID: "pressure", Name: "Under Pressure (feat. David Bowie)", Artists: []spotifyRepo.SpotifyArtistObject{{Name: "Queen"}, {Name: "David Bowie"}}},
+			},
+			want:   "pressure",
+			wantOK: true,
+		},
+		{
+			name:  "title only",
+			entry: playlistfile.Track{Title: "Somebody to Love"},
+			candidates: []spotifyRepo.SpotifyTrackObject{
+				{ID: "love", Name: "Somebody To Love - Remastered 2011", Artists: queen},
+			},
+			want:   "love",
+			wantOK: true,
+		},
+		{
+			name:  "same title by someone else",
+			entry: playlistfile.Track{Title: "Hurt", Artists: []string{"Johnny Cash"}},
+			candidates: []spotifyRepo.SpotifyTrackObject{
+				{ID: "nin", Name: "Hurt", Artists: []spotifyRepo.SpotifyArtistObject{{Name: "Nine Inch Nails"}}},
+			},
+			want:   "nin",
+			wantOK: false,
+		},
+		{
+			name:   "nothing found",
+			entry:  playlistfile.Track{Title: "Hurt", Artists: []string{"Johnny Cash"}},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := bestMatch(tt.entry, tt.candidates)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
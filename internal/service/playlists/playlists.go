@@ -0,0 +1,234 @@
+package playlists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrPlaylistNotFound = errors.New("playlist not found")
+	ErrEmptyName        = errors.New("playlist name must not be empty")
+	ErrForbidden        = errors.New("your role on this playlist doesn't allow that")
+)
+
+func (s *service) CreatePlaylist(ctx context.Context, userID uint, request playlists.PlaylistRequest) (*playlists.PlaylistResponse, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	playlist := &playlists.Playlist{
+		UserID:    userID,
+		Name:      name,
+		CreatedBy: fmt.Sprintf("%d", userID),
+		UpdatedBy: fmt.Sprintf("%d", userID),
+	}
+	err := s.repository.CreatePlaylist(ctx, playlist)
+	if err != nil {
+		log.Error().Err(err).Msg("error create playlist to database")
+		return nil, err
+	}
+	response := playlistToResponse(*playlist, playlists.RoleOwner)
+	return &response, nil
+}
+
+// ListPlaylists returns the playlists of the user and the ones shared with
+// them.
+func (s *service) ListPlaylists(ctx context.Context, userID uint) (*playlists.ListResponse, error) {
+	result, err := s.repository.ListPlaylists(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Msg("error list playlists from database")
+		return nil, err
+	}
+
+	response := &playlists.ListResponse{
+		Items: make([]playlists.PlaylistResponse, 0, len(result)),
+	}
+	for _, playlist := range result {
+		response.Items = append(response.Items, playlistToResponse(playlist.Playlist, playlist.Role))
+	}
+	return response, nil
+}
+
+// GetPlaylist returns the playlist with its tracks in order, hydrated with
+// spotify metadata.
+func (s *service) GetPlaylist(ctx context.Context, userID, playlistID uint) (*playlists.PlaylistDetailResponse, error) {
+	playlist, role, err := s.authorize(ctx, userID, playlistID, playlists.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return s.playlistDetail(ctx, userID, playlist, role)
+}
+
+// playlistDetail hydrates the tracks of the playlist with the likes of the
+// user, there are none for userID 0.
+func (s *service) playlistDetail(ctx context.Context, userID uint, playlist *playlists.Playlist, role string) (*playlists.PlaylistDetailResponse, error) {
+	tracks, err := s.repository.ListPlaylistTracks(ctx, playlist.ID)
+	if err != nil {
+		log.Error().Err(err).Msg("error list playlist tracks from database")
+		return nil, err
+	}
+
+	response := &playlists.PlaylistDetailResponse{
+		PlaylistResponse: playlistToResponse(*playlist, role),
+		Tracks:           make([]playlists.PlaylistTrackItem, 0, len(tracks)),
+	}
+	if len(tracks) == 0 {
+		return response, nil
+	}
+
+	trackIDs := make([]string, len(tracks))
+	for idx, track := range tracks {
+		trackIDs[idx] = track.SpotifyID
+	}
+	trackDetails, err := s.trackCatalog.GetTracks(ctx, userID, trackIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("error get playlist tracks from spotify")
+		return nil, err
+	}
+
+	for idx, track := range tracks {
+		response.Tracks = append(response.Tracks, playlists.PlaylistTrackItem{
+			SpotifyTrackObject: trackDetails.Items[idx],
+			Position:           track.Position,
+			AddedAt:            track.CreatedAt,
+		})
+	}
+	return response, nil
+}
+
+func (s *service) RenamePlaylist(ctx context.Context, userID, playlistID uint, request playlists.PlaylistRequest) (*playlists.PlaylistResponse, error) {
+	name := strings.TrimSpace(request.Name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	_, role, err := s.authorize(ctx, userID, playlistID, playlists.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.repository.RenamePlaylist(ctx, playlistID, name, fmt.Sprintf("%d", userID))
+	return editResult(playlist, role, err)
+}
+
+func (s *service) DeletePlaylist(ctx context.Context, userID, playlistID uint) error {
+	if _, _, err := s.authorize(ctx, userID, playlistID, playlists.RoleOwner); err != nil {
+		return err
+	}
+
+	err := s.repository.DeletePlaylist(ctx, playlistID)
+	if err != nil {
+		log.Error().Err(err).Msg("error delete playlist from database")
+		return err
+	}
+	return nil
+}
+
+func (s *service) AddTracks(ctx context.Context, userID, playlistID uint, request playlists.AddTracksRequest) (*playlists.PlaylistResponse, error) {
+	_, role, err := s.authorize(ctx, userID, playlistID, playlists.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.repository.AddPlaylistTracks(ctx, playlistID, request.Version, request.Position, request.IDs, fmt.Sprintf("%d", userID))
+	return editResult(playlist, role, err)
+}
+
+func (s *service) RemoveTracks(ctx context.Context, userID, playlistID uint, request playlists.RemoveTracksRequest) (*playlists.PlaylistResponse, error) {
+	_, role, err := s.authorize(ctx, userID, playlistID, playlists.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	playlist, err := s.repository.RemovePlaylistTracks(ctx, playlistID, request.Version, request.Positions, fmt.Sprintf("%d", userID))
+	return editResult(playlist, role, err)
+}
+
+// ReorderTracks moves a range of tracks, a missing range length moves a
+// single track.
+func (s *service) ReorderTracks(ctx context.Context, userID, playlistID uint, request playlists.ReorderTracksRequest) (*playlists.PlaylistResponse, error) {
+	_, role, err := s.authorize(ctx, userID, playlistID, playlists.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	rangeLength := request.RangeLength
+	if rangeLength == 0 {
+		rangeLength = 1
+	}
+	playlist, err := s.repository.MovePlaylistTracks(ctx, playlistID, request.Version, request.RangeStart, rangeLength, request.InsertBefore, fmt.Sprintf("%d", userID))
+	return editResult(playlist, role, err)
+}
+
+// authorize loads the playlist with the role of the user on it, and checks
+// the role allows what needs the given one. Playlists the user has no role
+// on look the same as missing ones.
+func (s *service) authorize(ctx context.Context, userID, playlistID uint, needs string) (*playlists.Playlist, string, error) {
+	playlist, err := s.repository.GetPlaylist(ctx, playlistID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, "", ErrPlaylistNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get playlist from database")
+		return nil, "", err
+	}
+
+	role := playlists.RoleOwner
+	if playlist.UserID != userID {
+		member, err := s.repository.GetPlaylistMember(ctx, playlistID, userID)
+		if err == gorm.ErrRecordNotFound {
+			return nil, "", ErrPlaylistNotFound
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("error get playlist member from database")
+			return nil, "", err
+		}
+		role = member.Role
+	}
+
+	if !playlists.RoleAllows(role, needs) {
+		return nil, "", ErrForbidden
+	}
+	return playlist, role, nil
+}
+
+// editResult turns the outcome of a playlist edit into a response. The
+// playlist may have been deleted since it was loaded.
+func editResult(playlist *playlists.Playlist, role string, err error) (*playlists.PlaylistResponse, error) {
+	switch {
+	case err == nil:
+		response := playlistToResponse(*playlist, role)
+		return &response, nil
+	case err == gorm.ErrRecordNotFound:
+		return nil, ErrPlaylistNotFound
+	case errors.Is(err, playlistsRepo.ErrInvalidPosition), errors.Is(err, playlistsRepo.ErrPlaylistFull),
+		errors.Is(err, playlistsRepo.ErrVersionConflict):
+		return nil, err
+	default:
+		log.Error().Err(err).Msg("error edit playlist in database")
+		return nil, err
+	}
+}
+
+func playlistToResponse(playlist playlists.Playlist, role string) playlists.PlaylistResponse {
+	response := playlists.PlaylistResponse{
+		ID:         playlist.ID,
+		Name:       playlist.Name,
+		TrackCount: playlist.TrackCount,
+		Version:    playlist.Version,
+		Role:       role,
+		CreatedAt:  playlist.CreatedAt,
+		UpdatedAt:  playlist.UpdatedAt,
+	}
+	if role == playlists.RoleOwner && playlist.ShareSlug != nil {
+		response.ShareSlug = *playlist.ShareSlug
+	}
+	return response
+}
@@ -0,0 +1,378 @@
+package playlists
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_CreatePlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request playlists.PlaylistRequest
+		want    *playlists.PlaylistResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success",
+			request: playlists.PlaylistRequest{Name: "  road trip "},
+			want:    &playlists.PlaylistResponse{ID: 3, Name: "road trip", Version: 1, Role: "owner", CreatedAt: now, UpdatedAt: now},
+			mockFn: func() {
+				mockRepo.EXPECT().CreatePlaylist(gomock.Any(), &playlists.Playlist{
+					UserID:    1,
+					Name:      "road trip",
+					CreatedBy: "1",
+					UpdatedBy: "1",
+				}).DoAndReturn(func(_ context.Context, model *playlists.Playlist) error {
+					model.ID = 3
+					model.Version = 1
+					model.CreatedAt = now
+					model.UpdatedAt = now
+					return nil
+				})
+			},
+		},
+		{
+			name:    "failed: blank name",
+			request: playlists.PlaylistRequest{Name: "   "},
+			wantErr: ErrEmptyName,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed",
+			request: playlists.PlaylistRequest{Name: "road trip"},
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockRepo.EXPECT().CreatePlaylist(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			got, err := s.CreatePlaylist(context.Background(), 1, tt.request)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_GetPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+	mockCatalog := NewMocktrackCatalog(mockCtrl)
+
+	isLikedTrue := true
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	slug := "c2hhcmVk"
+	playlist := &playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip", TrackCount: 3, Version: 7, ShareSlug: &slug}
+	queen := spotify.SpotifyTrackObject{ID: "3z8h0TU7ReDPLIbEnYhWZb", Name: "Bohemian Rhapsody", ArtistsName: []string{"Queen"}, IsLiked: &isLikedTrue}
+	removed := spotify.SpotifyTrackObject{ID: "removed", NotFound: true}
+
+	tests := []struct {
+		name    string
+		userID  uint
+		want    *playlists.PlaylistDetailResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:   "success: tracks in order, repeats included",
+			userID: 1,
+			want: &playlists.PlaylistDetailResponse{
+				PlaylistResponse: playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 3, Version: 7, Role: "owner", ShareSlug: slug, CreatedAt: now, UpdatedAt: now},
+				Tracks: []playlists.PlaylistTrackItem{
+					{SpotifyTrackObject: queen, Position: 0, AddedAt: now},
+					{SpotifyTrackObject: removed, Position: 1, AddedAt: now},
+					{SpotifyTrackObject: queen, Position: 2, AddedAt: now},
+				},
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().ListPlaylistTracks(gomock.Any(), uint(3)).Return([]playlists.PlaylistTrack{
+					{Position: 0, SpotifyID: "3z8h0TU7ReDPLIbEnYhWZb", CreatedAt: now},
+					{Position: 1, SpotifyID: "removed", CreatedAt: now},
+					{Position: 2, SpotifyID: "3z8h0TU7ReDPLIbEnYhWZb", CreatedAt: now},
+				}, nil)
+				mockCatalog.EXPECT().GetTracks(gomock.Any(), uint(1), []string{"3z8h0TU7ReDPLIbEnYhWZb", "removed", "3z8h0TU7ReDPLIbEnYhWZb"}).
+					Return(&spotify.TracksResponse{Items: []spotify.SpotifyTrackObject{queen, removed, queen}}, nil)
+			},
+		},
+		{
+			name:   "success: empty playlist",
+			userID: 1,
+			want: &playlists.PlaylistDetailResponse{
+				PlaylistResponse: playlists.PlaylistResponse{ID: 3, Name: "road trip", Role: "owner", CreatedAt: now, UpdatedAt: now},
+				Tracks:           []playlists.PlaylistTrackItem{},
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(&playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip"}, nil)
+				mockRepo.EXPECT().ListPlaylistTracks(gomock.Any(), uint(3)).Return([]playlists.PlaylistTrack{}, nil)
+			},
+		},
+		{
+			name:   "success: shared with a viewer, no share slug",
+			userID: 2,
+			want: &playlists.PlaylistDetailResponse{
+				PlaylistResponse: playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 3, Version: 7, Role: "viewer", CreatedAt: now, UpdatedAt: now},
+				Tracks:           []playlists.PlaylistTrackItem{},
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "viewer"}, nil)
+				mockRepo.EXPECT().ListPlaylistTracks(gomock.Any(), uint(3)).Return([]playlists.PlaylistTrack{}, nil)
+			},
+		},
+		{
+			name:    "failed: playlist of another user",
+			userID:  2,
+			wantErr: ErrPlaylistNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: not found",
+			userID:  1,
+			wantErr: ErrPlaylistNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: spotify unavailable",
+			userID:  1,
+			wantErr: spotifyRepo.ErrUnavailable,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().ListPlaylistTracks(gomock.Any(), uint(3)).Return([]playlists.PlaylistTrack{{SpotifyID: "removed"}}, nil)
+				mockCatalog.EXPECT().GetTracks(gomock.Any(), uint(1), []string{"removed"}).Return(nil, spotifyRepo.ErrUnavailable)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository:   mockRepo,
+				trackCatalog: mockCatalog,
+			}
+			got, err := s.GetPlaylist(context.Background(), tt.userID, 3)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_AddTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	playlist := &playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip", TrackCount: 1}
+	position := 0
+
+	tests := []struct {
+		name    string
+		userID  uint
+		request playlists.AddTracksRequest
+		want    *playlists.PlaylistResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success",
+			userID:  1,
+			request: playlists.AddTracksRequest{IDs: []string{"track1", "track2"}, Position: &position, Version: 4},
+			want:    &playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 3, Version: 5, Role: "owner", CreatedAt: now, UpdatedAt: now},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().AddPlaylistTracks(gomock.Any(), uint(3), 4, &position, []string{"track1", "track2"}, "1").
+					Return(&playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip", TrackCount: 3, Version: 5}, nil)
+			},
+		},
+		{
+			name:    "success: editor",
+			userID:  2,
+			request: playlists.AddTracksRequest{IDs: []string{"track1"}, Version: 4},
+			want:    &playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 2, Version: 5, Role: "editor", CreatedAt: now, UpdatedAt: now},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "editor"}, nil)
+				mockRepo.EXPECT().AddPlaylistTracks(gomock.Any(), uint(3), 4, nil, []string{"track1"}, "2").
+					Return(&playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip", TrackCount: 2, Version: 5}, nil)
+			},
+		},
+		{
+			name:    "failed: viewer",
+			userID:  2,
+			request: playlists.AddTracksRequest{IDs: []string{"track1"}, Version: 4},
+			wantErr: ErrForbidden,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "viewer"}, nil)
+			},
+		},
+		{
+			name:    "failed: changed in the meantime",
+			userID:  1,
+			request: playlists.AddTracksRequest{IDs: []string{"track1"}, Version: 3},
+			wantErr: playlistsRepo.ErrVersionConflict,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().AddPlaylistTracks(gomock.Any(), uint(3), 3, nil, []string{"track1"}, "1").Return(nil, playlistsRepo.ErrVersionConflict)
+			},
+		},
+		{
+			name:    "failed: playlist full",
+			userID:  1,
+			request: playlists.AddTracksRequest{IDs: []string{"track1"}, Version: 4},
+			wantErr: playlistsRepo.ErrPlaylistFull,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().AddPlaylistTracks(gomock.Any(), uint(3), 4, nil, []string{"track1"}, "1").Return(nil, playlistsRepo.ErrPlaylistFull)
+			},
+		},
+		{
+			name:    "failed: deleted in the meantime",
+			userID:  1,
+			request: playlists.AddTracksRequest{IDs: []string{"track1"}, Version: 4},
+			wantErr: ErrPlaylistNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().AddPlaylistTracks(gomock.Any(), uint(3), 4, nil, []string{"track1"}, "1").Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			got, err := s.AddTracks(context.Background(), tt.userID, 3, tt.request)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_ReorderTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+
+	playlist := &playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1, Name: "road trip", TrackCount: 4}
+
+	tests := []struct {
+		name    string
+		request playlists.ReorderTracksRequest
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success: single track by default",
+			request: playlists.ReorderTracksRequest{RangeStart: 3, InsertBefore: 0, Version: 2},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().MovePlaylistTracks(gomock.Any(), uint(3), 2, 3, 1, 0, "1").Return(playlist, nil)
+			},
+		},
+		{
+			name:    "failed: invalid position",
+			request: playlists.ReorderTracksRequest{RangeStart: 3, RangeLength: 2, InsertBefore: 0, Version: 2},
+			wantErr: playlistsRepo.ErrInvalidPosition,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().MovePlaylistTracks(gomock.Any(), uint(3), 2, 3, 2, 0, "1").Return(nil, playlistsRepo.ErrInvalidPosition)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			_, err := s.ReorderTracks(context.Background(), 1, 3, tt.request)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_service_DeletePlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+
+	playlist := &playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1, Name: "road trip"}
+
+	tests := []struct {
+		name    string
+		userID  uint
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:   "success",
+			userID: 1,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().DeletePlaylist(gomock.Any(), uint(3)).Return(nil)
+			},
+		},
+		{
+			name:    "failed: editor",
+			userID:  2,
+			wantErr: ErrForbidden,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "editor"}, nil)
+			},
+		},
+		{
+			name:    "failed: playlist of another user",
+			userID:  2,
+			wantErr: ErrPlaylistNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			err := s.DeletePlaylist(context.Background(), tt.userID, 3)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package playlists
+
+import (
+	"context"
+
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+)
+
+//go:generate mockgen -source=service.go -destination=service_mock_test.go -package=playlists
+type repository interface {
+	CreatePlaylist(ctx context.Context, model *playlists.Playlist) error
+	CreatePlaylistWithTracks(ctx context.Context, model *playlists.Playlist, spotifyIDs []string) error
+	GetPlaylist(ctx context.Context, id uint) (*playlists.Playlist, error)
+	GetPlaylistBySlug(ctx context.Context, slug string) (*playlists.Playlist, error)
+	ListPlaylists(ctx context.Context, userID uint) ([]playlists.PlaylistWithRole, error)
+	RenamePlaylist(ctx context.Context, id uint, name, updatedBy string) (*playlists.Playlist, error)
+	SetShareSlug(ctx context.Context, id uint, slug *string, updatedBy string) (*playlists.Playlist, error)
+	DeletePlaylist(ctx context.Context, id uint) error
+	ListPlaylistTracks(ctx context.Context, playlistID uint) ([]playlists.PlaylistTrack, error)
+	AddPlaylistTracks(ctx context.Context, playlistID uint, version int, position *int, spotifyIDs []string, updatedBy string) (*playlists.Playlist, error)
+	RemovePlaylistTracks(ctx context.Context, playlistID uint, version int, positions []int, updatedBy string) (*playlists.Playlist, error)
+	MovePlaylistTracks(ctx context.Context, playlistID uint, version, rangeStart, rangeLength, insertBefore int, updatedBy string) (*playlists.Playlist, error)
+
+	GetPlaylistMember(ctx context.Context, playlistID, userID uint) (*playlists.PlaylistMember, error)
+	ListPlaylistMembers(ctx context.Context, playlistID uint) ([]playlists.PlaylistMember, error)
+	SavePlaylistMember(ctx context.Context, model *playlists.PlaylistMember) error
+	DeletePlaylistMember(ctx context.Context, playlistID, userID uint) error
+}
+
+// trackCatalog hydrates spotify ids with track metadata and the like of the
+// user, the tracks service does that for its own endpoints too.
+type trackCatalog interface {
+	GetTracks(ctx context.Context, userID uint, trackIDs []string) (*spotify.TracksResponse, error)
+}
+
+// userDirectory finds the users playlists are shared with, the memberships
+// repository does.
+type userDirectory interface {
+	GetUser(email, username string, id uint) (*memberships.User, error)
+}
+
+// spotifyOutbound searches spotify for the imported entries that come
+// without a spotify uri.
+type spotifyOutbound interface {
+	Search(ctx context.Context, query string, limit, offset int, market string) (*spotifyRepo.SpotifySearchResponse, error)
+}
+
+type service struct {
+	repository      repository
+	trackCatalog    trackCatalog
+	userDirectory   userDirectory
+	spotifyOutbound spotifyOutbound
+}
+
+func NewService(repository repository, trackCatalog trackCatalog, userDirectory userDirectory, spotifyOutbound spotifyOutbound) *service {
+	return &service{
+		repository:      repository,
+		trackCatalog:    trackCatalog,
+		userDirectory:   userDirectory,
+		spotifyOutbound: spotifyOutbound,
+	}
+}
@@ -0,0 +1,393 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: service.go
+//
+// Generated by this command:
+//
+//	mockgen -source=service.go -destination=service_mock_test.go -package=playlists
+//
+
+// Package playlists is a generated GoMock package.
+package playlists
+
+import (
+	context "context"
+	reflect "reflect"
+
+	memberships "github.com/xprasetio/go-spotify/internal/models/memberships"
+	playlists "github.com/xprasetio/go-spotify/internal/models/playlists"
+	spotify "github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotify0 "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// Mockrepository is a mock of repository interface.
+type Mockrepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockrepositoryMockRecorder
+}
+
+// MockrepositoryMockRecorder is the mock recorder for Mockrepository.
+type MockrepositoryMockRecorder struct {
+	mock *Mockrepository
+}
+
+// NewMockrepository creates a new mock instance.
+func NewMockrepository(ctrl *gomock.Controller) *Mockrepository {
+	mock := &Mockrepository{ctrl: ctrl}
+	mock.recorder = &MockrepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockrepository) EXPECT() *MockrepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddPlaylistTracks mocks base method.
+func (m *Mockrepository) AddPlaylistTracks(ctx context.Context, playlistID uint, version int, position *int, spotifyIDs []string, updatedBy string) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPlaylistTracks", ctx, playlistID, version, position, spotifyIDs, updatedBy)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPlaylistTracks indicates an expected call of AddPlaylistTracks.
+func (mr *MockrepositoryMockRecorder) AddPlaylistTracks(ctx, playlistID, version, position, spotifyIDs, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPlaylistTracks", reflect.TypeOf((*Mockrepository)(nil).AddPlaylistTracks), ctx, playlistID, version, position, spotifyIDs, updatedBy)
+}
+
+// CreatePlaylist mocks base method.
+func (m *Mockrepository) CreatePlaylist(ctx context.Context, model *playlists.Playlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylist", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlaylist indicates an expected call of CreatePlaylist.
+func (mr *MockrepositoryMockRecorder) CreatePlaylist(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylist", reflect.TypeOf((*Mockrepository)(nil).CreatePlaylist), ctx, model)
+}
+
+// CreatePlaylistWithTracks mocks base method.
+func (m *Mockrepository) CreatePlaylistWithTracks(ctx context.Context, model *playlists.Playlist, spotifyIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlaylistWithTracks", ctx, model, spotifyIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePlaylistWithTracks indicates an expected call of CreatePlaylistWithTracks.
+func (mr *MockrepositoryMockRecorder) CreatePlaylistWithTracks(ctx, model, spotifyIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlaylistWithTracks", reflect.TypeOf((*Mockrepository)(nil).CreatePlaylistWithTracks), ctx, model, spotifyIDs)
+}
+
+// DeletePlaylist mocks base method.
+func (m *Mockrepository) DeletePlaylist(ctx context.Context, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylist indicates an expected call of DeletePlaylist.
+func (mr *MockrepositoryMockRecorder) DeletePlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylist", reflect.TypeOf((*Mockrepository)(nil).DeletePlaylist), ctx, id)
+}
+
+// DeletePlaylistMember mocks base method.
+func (m *Mockrepository) DeletePlaylistMember(ctx context.Context, playlistID, userID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlaylistMember", ctx, playlistID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlaylistMember indicates an expected call of DeletePlaylistMember.
+func (mr *MockrepositoryMockRecorder) DeletePlaylistMember(ctx, playlistID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlaylistMember", reflect.TypeOf((*Mockrepository)(nil).DeletePlaylistMember), ctx, playlistID, userID)
+}
+
+// GetPlaylist mocks base method.
+func (m *Mockrepository) GetPlaylist(ctx context.Context, id uint) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", ctx, id)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist.
+func (mr *MockrepositoryMockRecorder) GetPlaylist(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*Mockrepository)(nil).GetPlaylist), ctx, id)
+}
+
+// GetPlaylistBySlug mocks base method.
+func (m *Mockrepository) GetPlaylistBySlug(ctx context.Context, slug string) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistBySlug", ctx, slug)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistBySlug indicates an expected call of GetPlaylistBySlug.
+func (mr *MockrepositoryMockRecorder) GetPlaylistBySlug(ctx, slug any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistBySlug", reflect.TypeOf((*Mockrepository)(nil).GetPlaylistBySlug), ctx, slug)
+}
+
+// GetPlaylistMember mocks base method.
+func (m *Mockrepository) GetPlaylistMember(ctx context.Context, playlistID, userID uint) (*playlists.PlaylistMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylistMember", ctx, playlistID, userID)
+	ret0, _ := ret[0].(*playlists.PlaylistMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylistMember indicates an expected call of GetPlaylistMember.
+func (mr *MockrepositoryMockRecorder) GetPlaylistMember(ctx, playlistID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylistMember", reflect.TypeOf((*Mockrepository)(nil).GetPlaylistMember), ctx, playlistID, userID)
+}
+
+// ListPlaylistMembers mocks base method.
+func (m *Mockrepository) ListPlaylistMembers(ctx context.Context, playlistID uint) ([]playlists.PlaylistMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylistMembers", ctx, playlistID)
+	ret0, _ := ret[0].([]playlists.PlaylistMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylistMembers indicates an expected call of ListPlaylistMembers.
+func (mr *MockrepositoryMockRecorder) ListPlaylistMembers(ctx, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylistMembers", reflect.TypeOf((*Mockrepository)(nil).ListPlaylistMembers), ctx, playlistID)
+}
+
+// ListPlaylistTracks mocks base method.
+func (m *Mockrepository) ListPlaylistTracks(ctx context.Context, playlistID uint) ([]playlists.PlaylistTrack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylistTracks", ctx, playlistID)
+	ret0, _ := ret[0].([]playlists.PlaylistTrack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylistTracks indicates an expected call of ListPlaylistTracks.
+func (mr *MockrepositoryMockRecorder) ListPlaylistTracks(ctx, playlistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylistTracks", reflect.TypeOf((*Mockrepository)(nil).ListPlaylistTracks), ctx, playlistID)
+}
+
+// ListPlaylists mocks base method.
+func (m *Mockrepository) ListPlaylists(ctx context.Context, userID uint) ([]playlists.PlaylistWithRole, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", ctx, userID)
+	ret0, _ := ret[0].([]playlists.PlaylistWithRole)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists.
+func (mr *MockrepositoryMockRecorder) ListPlaylists(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*Mockrepository)(nil).ListPlaylists), ctx, userID)
+}
+
+// MovePlaylistTracks mocks base method.
+func (m *Mockrepository) MovePlaylistTracks(ctx context.Context, playlistID uint, version, rangeStart, rangeLength, insertBefore int, updatedBy string) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MovePlaylistTracks", ctx, playlistID, version, rangeStart, rangeLength, insertBefore, updatedBy)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MovePlaylistTracks indicates an expected call of MovePlaylistTracks.
+func (mr *MockrepositoryMockRecorder) MovePlaylistTracks(ctx, playlistID, version, rangeStart, rangeLength, insertBefore, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MovePlaylistTracks", reflect.TypeOf((*Mockrepository)(nil).MovePlaylistTracks), ctx, playlistID, version, rangeStart, rangeLength, insertBefore, updatedBy)
+}
+
+// RemovePlaylistTracks mocks base method.
+func (m *Mockrepository) RemovePlaylistTracks(ctx context.Context, playlistID uint, version int, positions []int, updatedBy string) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePlaylistTracks", ctx, playlistID, version, positions, updatedBy)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePlaylistTracks indicates an expected call of RemovePlaylistTracks.
+func (mr *MockrepositoryMockRecorder) RemovePlaylistTracks(ctx, playlistID, version, positions, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePlaylistTracks", reflect.TypeOf((*Mockrepository)(nil).RemovePlaylistTracks), ctx, playlistID, version, positions, updatedBy)
+}
+
+// RenamePlaylist mocks base method.
+func (m *Mockrepository) RenamePlaylist(ctx context.Context, id uint, name, updatedBy string) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenamePlaylist", ctx, id, name, updatedBy)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenamePlaylist indicates an expected call of RenamePlaylist.
+func (mr *MockrepositoryMockRecorder) RenamePlaylist(ctx, id, name, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenamePlaylist", reflect.TypeOf((*Mockrepository)(nil).RenamePlaylist), ctx, id, name, updatedBy)
+}
+
+// SavePlaylistMember mocks base method.
+func (m *Mockrepository) SavePlaylistMember(ctx context.Context, model *playlists.PlaylistMember) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePlaylistMember", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePlaylistMember indicates an expected call of SavePlaylistMember.
+func (mr *MockrepositoryMockRecorder) SavePlaylistMember(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlaylistMember", reflect.TypeOf((*Mockrepository)(nil).SavePlaylistMember), ctx, model)
+}
+
+// SetShareSlug mocks base method.
+func (m *Mockrepository) SetShareSlug(ctx context.Context, id uint, slug *string, updatedBy string) (*playlists.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetShareSlug", ctx, id, slug, updatedBy)
+	ret0, _ := ret[0].(*playlists.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetShareSlug indicates an expected call of SetShareSlug.
+func (mr *MockrepositoryMockRecorder) SetShareSlug(ctx, id, slug, updatedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetShareSlug", reflect.TypeOf((*Mockrepository)(nil).SetShareSlug), ctx, id, slug, updatedBy)
+}
+
+// MocktrackCatalog is a mock of trackCatalog interface.
+type MocktrackCatalog struct {
+	ctrl     *gomock.Controller
+	recorder *MocktrackCatalogMockRecorder
+}
+
+// MocktrackCatalogMockRecorder is the mock recorder for MocktrackCatalog.
+type MocktrackCatalogMockRecorder struct {
+	mock *MocktrackCatalog
+}
+
+// NewMocktrackCatalog creates a new mock instance.
+func NewMocktrackCatalog(ctrl *gomock.Controller) *MocktrackCatalog {
+	mock := &MocktrackCatalog{ctrl: ctrl}
+	mock.recorder = &MocktrackCatalogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktrackCatalog) EXPECT() *MocktrackCatalogMockRecorder {
+	return m.recorder
+}
+
+// GetTracks mocks base method.
+func (m *MocktrackCatalog) GetTracks(ctx context.Context, userID uint, trackIDs []string) (*spotify.TracksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, userID, trackIDs)
+	ret0, _ := ret[0].(*spotify.TracksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MocktrackCatalogMockRecorder) GetTracks(ctx, userID, trackIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MocktrackCatalog)(nil).GetTracks), ctx, userID, trackIDs)
+}
+
+// MockuserDirectory is a mock of userDirectory interface.
+type MockuserDirectory struct {
+	ctrl     *gomock.Controller
+	recorder *MockuserDirectoryMockRecorder
+}
+
+// MockuserDirectoryMockRecorder is the mock recorder for MockuserDirectory.
+type MockuserDirectoryMockRecorder struct {
+	mock *MockuserDirectory
+}
+
+// NewMockuserDirectory creates a new mock instance.
+func NewMockuserDirectory(ctrl *gomock.Controller) *MockuserDirectory {
+	mock := &MockuserDirectory{ctrl: ctrl}
+	mock.recorder = &MockuserDirectoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockuserDirectory) EXPECT() *MockuserDirectoryMockRecorder {
+	return m.recorder
+}
+
+// GetUser mocks base method.
+func (m *MockuserDirectory) GetUser(email, username string, id uint) (*memberships.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", email, username, id)
+	ret0, _ := ret[0].(*memberships.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockuserDirectoryMockRecorder) GetUser(email, username, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockuserDirectory)(nil).GetUser), email, username, id)
+}
+
+// MockspotifyOutbound is a mock of spotifyOutbound interface.
+type MockspotifyOutbound struct {
+	ctrl     *gomock.Controller
+	recorder *MockspotifyOutboundMockRecorder
+}
+
+// MockspotifyOutboundMockRecorder is the mock recorder for MockspotifyOutbound.
+type MockspotifyOutboundMockRecorder struct {
+	mock *MockspotifyOutbound
+}
+
+// NewMockspotifyOutbound creates a new mock instance.
+func NewMockspotifyOutbound(ctrl *gomock.Controller) *MockspotifyOutbound {
+	mock := &MockspotifyOutbound{ctrl: ctrl}
+	mock.recorder = &MockspotifyOutboundMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockspotifyOutbound) EXPECT() *MockspotifyOutboundMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockspotifyOutbound) Search(ctx context.Context, query string, limit, offset int, market string) (*spotify0.SpotifySearchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset, market)
+	ret0, _ := ret[0].(*spotify0.SpotifySearchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockspotifyOutboundMockRecorder) Search(ctx, query, limit, offset, market any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockspotifyOutbound)(nil).Search), ctx, query, limit, offset, market)
+}
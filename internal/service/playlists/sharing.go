@@ -0,0 +1,189 @@
+package playlists
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"gorm.io/gorm"
+)
+
+// shareSlugSize is the random bytes of a share slug, too many to guess a
+// link.
+const shareSlugSize = 24
+
+var (
+	ErrUserNotFound   = errors.New("user not found")
+	ErrMemberNotFound = errors.New("user is not a member of this playlist")
+	ErrOwnerMember    = errors.New("the owner of a playlist can't be one of its members")
+	ErrTooManyMembers = fmt.Errorf("a playlist is shared with at most %d users", playlists.MaxMembers)
+)
+
+// ListMembers returns the owner of the playlist followed by its members.
+func (s *service) ListMembers(ctx context.Context, userID, playlistID uint) (*playlists.MembersResponse, error) {
+	playlist, _, err := s.authorize(ctx, userID, playlistID, playlists.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := s.repository.ListPlaylistMembers(ctx, playlistID)
+	if err != nil {
+		log.Error().Err(err).Msg("error list playlist members from database")
+		return nil, err
+	}
+
+	roles := append([]playlists.PlaylistMember{{UserID: playlist.UserID, Role: playlists.RoleOwner}}, members...)
+	response := &playlists.MembersResponse{
+		Items: make([]playlists.MemberResponse, 0, len(roles)),
+	}
+	for _, member := range roles {
+		user, err := s.userDirectory.GetUser("", "", member.UserID)
+		if err != nil {
+			log.Error().Err(err).Msg("error get playlist member user from database")
+			return nil, err
+		}
+		response.Items = append(response.Items, playlists.MemberResponse{
+			UserID:   member.UserID,
+			Username: user.Username,
+			Role:     member.Role,
+		})
+	}
+	return response, nil
+}
+
+// InviteMember shares the playlist with the user of the username, or
+// changes the role of a user it's shared with already.
+func (s *service) InviteMember(ctx context.Context, userID, playlistID uint, request playlists.InviteMemberRequest) (*playlists.MemberResponse, error) {
+	playlist, _, err := s.authorize(ctx, userID, playlistID, playlists.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userDirectory.GetUser("", request.Username, 0)
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get user from database")
+		return nil, err
+	}
+	if user.ID == playlist.UserID {
+		return nil, ErrOwnerMember
+	}
+
+	members, err := s.repository.ListPlaylistMembers(ctx, playlistID)
+	if err != nil {
+		log.Error().Err(err).Msg("error list playlist members from database")
+		return nil, err
+	}
+	isMember := false
+	for _, member := range members {
+		isMember = isMember || member.UserID == user.ID
+	}
+	if !isMember && len(members) >= playlists.MaxMembers {
+		return nil, ErrTooManyMembers
+	}
+
+	err = s.repository.SavePlaylistMember(ctx, &playlists.PlaylistMember{
+		PlaylistID: playlistID,
+		UserID:     user.ID,
+		Role:       request.Role,
+		CreatedBy:  fmt.Sprintf("%d", userID),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("error save playlist member to database")
+		return nil, err
+	}
+	return &playlists.MemberResponse{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     request.Role,
+	}, nil
+}
+
+// RemoveMember revokes the access of a member, the owner removes anyone and
+// members remove themselves.
+func (s *service) RemoveMember(ctx context.Context, userID, playlistID, memberUserID uint) error {
+	needs := playlists.RoleOwner
+	if memberUserID == userID {
+		needs = playlists.RoleViewer
+	}
+	playlist, _, err := s.authorize(ctx, userID, playlistID, needs)
+	if err != nil {
+		return err
+	}
+	if memberUserID == playlist.UserID {
+		return ErrOwnerMember
+	}
+
+	err = s.repository.DeletePlaylistMember(ctx, playlistID, memberUserID)
+	if err == gorm.ErrRecordNotFound {
+		return ErrMemberNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error delete playlist member from database")
+		return err
+	}
+	return nil
+}
+
+// ShareLink opens the playlist read-only to anyone with the returned slug.
+// A shared playlist keeps its slug until the link is revoked.
+func (s *service) ShareLink(ctx context.Context, userID, playlistID uint) (*playlists.ShareLinkResponse, error) {
+	playlist, _, err := s.authorize(ctx, userID, playlistID, playlists.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.ShareSlug != nil {
+		return &playlists.ShareLinkResponse{Slug: *playlist.ShareSlug}, nil
+	}
+
+	slug, err := newShareSlug()
+	if err != nil {
+		return nil, err
+	}
+	playlist, err = s.repository.SetShareSlug(ctx, playlistID, &slug, fmt.Sprintf("%d", userID))
+	if _, err := editResult(playlist, playlists.RoleOwner, err); err != nil {
+		return nil, err
+	}
+	return &playlists.ShareLinkResponse{Slug: *playlist.ShareSlug}, nil
+}
+
+// RevokeShareLink stops sharing the playlist by link, members keep their
+// access.
+func (s *service) RevokeShareLink(ctx context.Context, userID, playlistID uint) error {
+	if _, _, err := s.authorize(ctx, userID, playlistID, playlists.RoleOwner); err != nil {
+		return err
+	}
+
+	playlist, err := s.repository.SetShareSlug(ctx, playlistID, nil, fmt.Sprintf("%d", userID))
+	_, err = editResult(playlist, playlists.RoleOwner, err)
+	return err
+}
+
+// GetSharedPlaylist returns the playlist shared under the slug, without the
+// likes of anyone.
+func (s *service) GetSharedPlaylist(ctx context.Context, slug string) (*playlists.PlaylistDetailResponse, error) {
+	playlist, err := s.repository.GetPlaylistBySlug(ctx, slug)
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrPlaylistNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get shared playlist from database")
+		return nil, err
+	}
+	return s.playlistDetail(ctx, 0, playlist, "")
+}
+
+func newShareSlug() (string, error) {
+	buf := make([]byte, shareSlugSize)
+	if _, err := rand.Read(buf); err != nil {
+		log.Error().Err(err).Msg("error generate share slug")
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,312 @@
+package playlists
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/memberships"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_InviteMember(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+	mockUsers := NewMockuserDirectory(mockCtrl)
+
+	playlist := &playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1, Name: "road trip"}
+	full := make([]playlists.PlaylistMember, playlists.MaxMembers)
+	for idx := range full {
+		full[idx] = playlists.PlaylistMember{PlaylistID: 3, UserID: uint(idx + 10), Role: "viewer"}
+	}
+
+	tests := []struct {
+		name    string
+		userID  uint
+		request playlists.InviteMemberRequest
+		want    *playlists.MemberResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success",
+			userID:  1,
+			request: playlists.InviteMemberRequest{Username: "friend", Role: "editor"},
+			want:    &playlists.MemberResponse{UserID: 2, Username: "friend", Role: "editor"},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockUsers.EXPECT().GetUser("", "friend", uint(0)).Return(&memberships.User{Model: gorm.Model{ID: 2}, Username: "friend"}, nil)
+				mockRepo.EXPECT().ListPlaylistMembers(gomock.Any(), uint(3)).Return([]playlists.PlaylistMember{}, nil)
+				mockRepo.EXPECT().SavePlaylistMember(gomock.Any(), &playlists.PlaylistMember{
+					PlaylistID: 3,
+					UserID:     2,
+					Role:       "editor",
+					CreatedBy:  "1",
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "success: change the role of a member of a full playlist",
+			userID:  1,
+			request: playlists.InviteMemberRequest{Username: "friend", Role: "editor"},
+			want:    &playlists.MemberResponse{UserID: 10, Username: "friend", Role: "editor"},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockUsers.EXPECT().GetUser("", "friend", uint(0)).Return(&memberships.User{Model: gorm.Model{ID: 10}, Username: "friend"}, nil)
+				mockRepo.EXPECT().ListPlaylistMembers(gomock.Any(), uint(3)).Return(full, nil)
+				mockRepo.EXPECT().SavePlaylistMember(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "failed: playlist full",
+			userID:  1,
+			request: playlists.InviteMemberRequest{Username: "friend", Role: "viewer"},
+			wantErr: ErrTooManyMembers,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockUsers.EXPECT().GetUser("", "friend", uint(0)).Return(&memberships.User{Model: gorm.Model{ID: 2}, Username: "friend"}, nil)
+				mockRepo.EXPECT().ListPlaylistMembers(gomock.Any(), uint(3)).Return(full, nil)
+			},
+		},
+		{
+			name:    "failed: unknown username",
+			userID:  1,
+			request: playlists.InviteMemberRequest{Username: "nobody", Role: "viewer"},
+			wantErr: ErrUserNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockUsers.EXPECT().GetUser("", "nobody", uint(0)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name:    "failed: inviting the owner",
+			userID:  1,
+			request: playlists.InviteMemberRequest{Username: "username", Role: "viewer"},
+			wantErr: ErrOwnerMember,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockUsers.EXPECT().GetUser("", "username", uint(0)).Return(&memberships.User{Model: gorm.Model{ID: 1}, Username: "username"}, nil)
+			},
+		},
+		{
+			name:    "failed: editors don't invite",
+			userID:  2,
+			request: playlists.InviteMemberRequest{Username: "friend", Role: "editor"},
+			wantErr: ErrForbidden,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "editor"}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository:    mockRepo,
+				userDirectory: mockUsers,
+			}
+			got, err := s.InviteMember(context.Background(), tt.userID, 3, tt.request)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_RemoveMember(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+
+	playlist := &playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1, Name: "road trip"}
+
+	tests := []struct {
+		name         string
+		userID       uint
+		memberUserID uint
+		wantErr      error
+		mockFn       func()
+	}{
+		{
+			name:         "success: owner revokes",
+			userID:       1,
+			memberUserID: 2,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().DeletePlaylistMember(gomock.Any(), uint(3), uint(2)).Return(nil)
+			},
+		},
+		{
+			name:         "success: viewer leaves",
+			userID:       2,
+			memberUserID: 2,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "viewer"}, nil)
+				mockRepo.EXPECT().DeletePlaylistMember(gomock.Any(), uint(3), uint(2)).Return(nil)
+			},
+		},
+		{
+			name:         "failed: editor removes someone else",
+			userID:       2,
+			memberUserID: 4,
+			wantErr:      ErrForbidden,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().GetPlaylistMember(gomock.Any(), uint(3), uint(2)).Return(&playlists.PlaylistMember{PlaylistID: 3, UserID: 2, Role: "editor"}, nil)
+			},
+		},
+		{
+			name:         "failed: owner leaves",
+			userID:       1,
+			memberUserID: 1,
+			wantErr:      ErrOwnerMember,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+			},
+		},
+		{
+			name:         "failed: not a member",
+			userID:       1,
+			memberUserID: 4,
+			wantErr:      ErrMemberNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(playlist, nil)
+				mockRepo.EXPECT().DeletePlaylistMember(gomock.Any(), uint(3), uint(4)).Return(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			err := s.RemoveMember(context.Background(), tt.userID, 3, tt.memberUserID)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_service_ShareLink(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+
+	slug := "c2hhcmVk"
+
+	tests := []struct {
+		name     string
+		wantSlug string
+		wantErr  error
+		mockFn   func()
+	}{
+		{
+			name: "success: new slug",
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(&playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1}, nil)
+				mockRepo.EXPECT().SetShareSlug(gomock.Any(), uint(3), gomock.Not(gomock.Nil()), "1").
+					DoAndReturn(func(_ context.Context, _ uint, slug *string, _ string) (*playlists.Playlist, error) {
+						return &playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1, ShareSlug: slug}, nil
+					})
+			},
+		},
+		{
+			name:     "success: shared already",
+			wantSlug: slug,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(&playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1, ShareSlug: &slug}, nil)
+			},
+		},
+		{
+			name:    "failed: deleted in the meantime",
+			wantErr: ErrPlaylistNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(&playlists.Playlist{Model: gorm.Model{ID: 3}, UserID: 1}, nil)
+				mockRepo.EXPECT().SetShareSlug(gomock.Any(), uint(3), gomock.Any(), "1").Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository: mockRepo,
+			}
+			got, err := s.ShareLink(context.Background(), 1, 3)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				assert.Nil(t, got)
+				return
+			}
+			if tt.wantSlug != "" {
+				assert.Equal(t, tt.wantSlug, got.Slug)
+			} else {
+				assert.Len(t, got.Slug, 32)
+			}
+		})
+	}
+}
+
+func Test_service_GetSharedPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+	mockCatalog := NewMocktrackCatalog(mockCtrl)
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	slug := "c2hhcmVk"
+	queen := spotify.SpotifyTrackObject{ID: "3z8h0TU7ReDPLIbEnYhWZb", Name: "Bohemian Rhapsody", ArtistsName: []string{"Queen"}}
+
+	tests := []struct {
+		name    string
+		want    *playlists.PlaylistDetailResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success: no role, no likes, no slug",
+			want: &playlists.PlaylistDetailResponse{
+				PlaylistResponse: playlists.PlaylistResponse{ID: 3, Name: "road trip", TrackCount: 1, Version: 2, CreatedAt: now, UpdatedAt: now},
+				Tracks: []playlists.PlaylistTrackItem{
+					{SpotifyTrackObject: queen, Position: 0, AddedAt: now},
+				},
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylistBySlug(gomock.Any(), slug).
+					Return(&playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip", TrackCount: 1, Version: 2, ShareSlug: &slug}, nil)
+				mockRepo.EXPECT().ListPlaylistTracks(gomock.Any(), uint(3)).Return([]playlists.PlaylistTrack{{Position: 0, SpotifyID: queen.ID, CreatedAt: now}}, nil)
+				mockCatalog.EXPECT().GetTracks(gomock.Any(), uint(0), []string{queen.ID}).
+					Return(&spotify.TracksResponse{Items: []spotify.SpotifyTrackObject{queen}}, nil)
+			},
+		},
+		{
+			name:    "failed: revoked",
+			wantErr: ErrPlaylistNotFound,
+			mockFn: func() {
+				mockRepo.EXPECT().GetPlaylistBySlug(gomock.Any(), slug).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository:   mockRepo,
+				trackCatalog: mockCatalog,
+			}
+			got, err := s.GetSharedPlaylist(context.Background(), slug)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
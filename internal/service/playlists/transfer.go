@@ -0,0 +1,190 @@
+package playlists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	importSearchLimit       = 5
+	importSearchConcurrency = 4
+	importDefaultName       = "Imported playlist"
+	maxNameLength           = 100
+)
+
+const (
+	reasonUnknownURI = "no spotify track with this uri"
+	reasonNoMatch    = "no close enough match on spotify"
+	reasonNoTitle    = "no spotify uri and no title to search for"
+)
+
+var ErrEmptyImport = errors.New("the file has no tracks")
+
+// ExportPlaylist returns the playlist as the entries of a playlist file,
+// tracks spotify no longer has keep their uri only.
+func (s *service) ExportPlaylist(ctx context.Context, userID, playlistID uint) (*playlistfile.Playlist, error) {
+	detail, err := s.GetPlaylist(ctx, userID, playlistID)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &playlistfile.Playlist{
+		Name:   detail.Name,
+		Tracks: make([]playlistfile.Track, 0, len(detail.Tracks)),
+	}
+	for _, track := range detail.Tracks {
+		file.Tracks = append(file.Tracks, playlistfile.Track{
+			Title:      track.Name,
+			Artists:    track.ArtistsName,
+			DurationMs: track.DurationMs,
+			Location:   playlistfile.TrackURI(track.ID),
+		})
+	}
+	return file, nil
+}
+
+// ImportPlaylist creates a playlist of the user from a playlist file. Each
+// entry resolves to a spotify track by its uri, or else by searching for
+// its artist and title. Entries that resolve to nothing are left out and
+// reported.
+func (s *service) ImportPlaylist(ctx context.Context, userID uint, format string, r io.Reader) (*playlists.ImportResponse, error) {
+	file, err := playlistfile.Read(r, format)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Tracks) == 0 {
+		return nil, ErrEmptyImport
+	}
+	if len(file.Tracks) > playlists.MaxTracks {
+		return nil, playlistsRepo.ErrPlaylistFull
+	}
+
+	trackIDs, err := s.resolveImport(ctx, userID, file.Tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &playlists.ImportResponse{
+		Unmatched: make([]playlists.UnmatchedEntry, 0),
+	}
+	matched := make([]string, 0, len(trackIDs))
+	for idx, entry := range file.Tracks {
+		if trackIDs[idx].id != "" {
+			matched = append(matched, trackIDs[idx].id)
+			continue
+		}
+		response.Unmatched = append(response.Unmatched, playlists.UnmatchedEntry{
+			Line:   entry.Line,
+			Entry:  describeEntry(entry),
+			Reason: trackIDs[idx].reason,
+		})
+	}
+
+	playlist := &playlists.Playlist{
+		UserID:    userID,
+		Name:      importName(file.Name),
+		CreatedBy: fmt.Sprintf("%d", userID),
+		UpdatedBy: fmt.Sprintf("%d", userID),
+	}
+	err = s.repository.CreatePlaylistWithTracks(ctx, playlist, matched)
+	if err != nil {
+		log.Error().Err(err).Msg("error create imported playlist to database")
+		return nil, err
+	}
+
+	response.Playlist = playlistToResponse(*playlist, playlists.RoleOwner)
+	response.Matched = len(matched)
+	return response, nil
+}
+
+// resolvedEntry is the spotify id an entry resolved to, or why it didn't.
+type resolvedEntry struct {
+	id     string
+	reason string
+}
+
+func (s *service) resolveImport(ctx context.Context, userID uint, entries []playlistfile.Track) ([]resolvedEntry, error) {
+	resolved := make([]resolvedEntry, len(entries))
+
+	byURI := make([]int, 0, len(entries))
+	byURIIDs := make([]string, 0, len(entries))
+	g, searchCtx := errgroup.WithContext(ctx)
+	g.SetLimit(importSearchConcurrency)
+	for idx, entry := range entries {
+		if trackID, ok := playlistfile.SpotifyTrackID(entry.Location); ok {
+			byURI = append(byURI, idx)
+			byURIIDs = append(byURIIDs, trackID)
+			continue
+		}
+		if entry.Title == "" {
+			resolved[idx].reason = reasonNoTitle
+			continue
+		}
+
+		g.Go(func() error {
+			query := strings.TrimSpace(strings.Join(entry.Artists, " ") + " " + entry.Title)
+			result, err := s.spotifyOutbound.Search(searchCtx, query, importSearchLimit, 0, "")
+			if err != nil {
+				return err
+			}
+			if trackID, ok := bestMatch(entry, result.Tracks.Items); ok {
+				resolved[idx].id = trackID
+			} else {
+				resolved[idx].reason = reasonNoMatch
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		log.Error().Err(err).Msg("error search imported tracks on spotify")
+		return nil, err
+	}
+
+	if len(byURIIDs) > 0 {
+		tracks, err := s.trackCatalog.GetTracks(ctx, userID, byURIIDs)
+		if err != nil {
+			log.Error().Err(err).Msg("error get imported tracks from spotify")
+			return nil, err
+		}
+		for idx, track := range tracks.Items {
+			if track.NotFound {
+				resolved[byURI[idx]].reason = reasonUnknownURI
+			} else {
+				resolved[byURI[idx]].id = track.ID
+			}
+		}
+	}
+	return resolved, nil
+}
+
+// describeEntry names an unmatched entry the way the user knows it.
+func describeEntry(entry playlistfile.Track) string {
+	if entry.Title == "" {
+		return entry.Location
+	}
+	if len(entry.Artists) == 0 {
+		return entry.Title
+	}
+	return strings.Join(entry.Artists, ", ") + " - " + entry.Title
+}
+
+func importName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return importDefaultName
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		name = strings.TrimSpace(string([]rune(name)[:maxNameLength]))
+	}
+	return name
+}
@@ -0,0 +1,183 @@
+package playlists
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/playlists"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	playlistsRepo "github.com/xprasetio/go-spotify/internal/repository/playlists"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"github.com/xprasetio/go-spotify/pkg/playlistfile"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_ExportPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+	mockCatalog := NewMocktrackCatalog(mockCtrl)
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	queen := spotify.SpotifyTrackObject{ID: "3z8h0TU7ReDPLIbEnYhWZb", Name: "Bohemian Rhapsody", ArtistsName: []string{"Queen"}, DurationMs: 354320}
+	removed := spotify.SpotifyTrackObject{ID: "4uLU6hMCjMI75M1A2tKUQC", NotFound: true}
+
+	mockRepo.EXPECT().GetPlaylist(gomock.Any(), uint(3)).Return(&playlists.Playlist{Model: gorm.Model{ID: 3, CreatedAt: now, UpdatedAt: now}, UserID: 1, Name: "road trip", TrackCount: 2}, nil)
+	mockRepo.EXPECT().ListPlaylistTracks(gomock.Any(), uint(3)).Return([]playlists.PlaylistTrack{
+		{Position: 0, SpotifyID: queen.ID, CreatedAt: now},
+		{Position: 1, SpotifyID: removed.ID, CreatedAt: now},
+	}, nil)
+	mockCatalog.EXPECT().GetTracks(gomock.Any(), uint(1), []string{queen.ID, removed.ID}).
+		Return(&spotify.TracksResponse{Items: []spotify.SpotifyTrackObject{queen, removed}}, nil)
+
+	s := &service{
+		repository:   mockRepo,
+		trackCatalog: mockCatalog,
+	}
+	got, err := s.ExportPlaylist(context.Background(), 1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, &playlistfile.Playlist{
+		Name: "road trip",
+		Tracks: []playlistfile.Track{
+			{Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354320, Location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"},
+			{Location: "spotify:track:4uLU6hMCjMI75M1A2tKUQC"},
+		},
+	}, got)
+}
+
+func Test_service_ImportPlaylist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockRepo := NewMockrepository(mockCtrl)
+	mockCatalog := NewMocktrackCatalog(mockCtrl)
+	mockOutbound := NewMockspotifyOutbound(mockCtrl)
+
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	searchResult := func(tracks ...spotifyRepo.SpotifyTrackObject) *spotifyRepo.SpotifySearchResponse {
+		return &spotifyRepo.SpotifySearchResponse{Tracks: spotifyRepo.SpotifyTracks{Items: tracks}}
+	}
+	dontStopMeNow := spotifyRepo.SpotifyTrackObject{ID: "7hQJA50XrCWABAu5v6QZ4i", Name: "Don't Stop Me Now - Remastered 2011", Artists: []spotifyRepo.SpotifyArtistObject{{Name: "Queen"}}, DurationMs: 209413}
+	coverVersion := spotifyRepo.SpotifyTrackObject{ID: "1coverversion000000000", Name: "Don't Stop Me Now", Artists: []spotifyRepo.SpotifyArtistObject{{Name: "Some Tribute Band"}}, DurationMs: 240000}
+
+	tests := []struct {
+		name    string
+		format  string
+		file    string
+		want    *playlists.ImportResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:   "success: by uri and by search, the rest reported",
+			format: playlistfile.FormatM3U,
+			file: "#EXTM3U\n" +
+				"#PLAYLIST:road trip\n" +
+				"spotify:track:3z8h0TU7ReDPLIbEnYhWZb\n" +
+				"#EXTINF:209,Queen - Don't Stop Me Now\n" +
+				"Music/dont_stop.mp3\n" +
+				"https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC\n" +
+				"#EXTINF:200,Nobody - Unknown Song\n" +
+				"Music/unknown.mp3\n" +
+				"#EXTINF:-1,\n" +
+				"http://radio.example.com/stream\n",
+			want: &playlists.ImportResponse{
+				Playlist: playlists.PlaylistResponse{ID: 9, Name: "road trip", TrackCount: 2, Version: 1, Role: "owner", CreatedAt: now, UpdatedAt: now},
+				Matched:  2,
+				Unmatched: []playlists.UnmatchedEntry{
+					{Line: 6, Entry: "https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC", Reason: "no spotify track with this uri"},
+					{Line: 8, Entry: "Nobody - Unknown Song", Reason: "no close enough match on spotify"},
+					{Line: 10, Entry: "http://radio.example.com/stream", Reason: "no spotify uri and no title to search for"},
+				},
+			},
+			mockFn: func() {
+				mockOutbound.EXPECT().Search(gomock.Any(), "Queen Don't Stop Me Now", 5, 0, "").Return(searchResult(coverVersion, dontStopMeNow), nil)
+				mockOutbound.EXPECT().Search(gomock.Any(), "Nobody Unknown Song", 5, 0, "").Return(searchResult(coverVersion), nil)
+				mockCatalog.EXPECT().GetTracks(gomock.Any(), uint(1), []string{"3z8h0TU7ReDPLIbEnYhWZb", "4uLU6hMCjMI75M1A2tKUQC"}).
+					Return(&spotify.TracksResponse{Items: []spotify.SpotifyTrackObject{
+						{ID: "3z8h0TU7ReDPLIbEnYhWZb", Name: "Bohemian Rhapsody"},
+						{ID: "4uLU6hMCjMI75M1A2tKUQC", NotFound: true},
+					}}, nil)
+				mockRepo.EXPECT().CreatePlaylistWithTracks(gomock.Any(), &playlists.Playlist{
+					UserID:    1,
+					Name:      "road trip",
+					CreatedBy: "1",
+					UpdatedBy: "1",
+				}, []string{"3z8h0TU7ReDPLIbEnYhWZb", "7hQJA50XrCWABAu5v6QZ4i"}).
+					DoAndReturn(func(_ context.Context, model *playlists.Playlist, spotifyIDs []string) error {
+						model.ID = 9
+						model.TrackCount = len(spotifyIDs)
+						model.Version = 1
+						model.CreatedAt = now
+						model.UpdatedAt = now
+						return nil
+					})
+			},
+		},
+		{
+			name:   "success: nothing matched, named by default",
+			format: playlistfile.FormatJSON,
+			file:   `{"tracks":[{"name":"","uri":"file:///x"}]}`,
+			want: &playlists.ImportResponse{
+				Playlist:  playlists.PlaylistResponse{ID: 9, Name: "Imported playlist", Version: 1, Role: "owner"},
+				Unmatched: []playlists.UnmatchedEntry{{Line: 1, Entry: "file:///x", Reason: "no spotify uri and no title to search for"}},
+			},
+			mockFn: func() {
+				mockRepo.EXPECT().CreatePlaylistWithTracks(gomock.Any(), gomock.Any(), []string{}).
+					DoAndReturn(func(_ context.Context, model *playlists.Playlist, _ []string) error {
+						model.ID = 9
+						model.Version = 1
+						return nil
+					})
+			},
+		},
+		{
+			name:    "failed: no tracks",
+			format:  playlistfile.FormatM3U,
+			file:    "#EXTM3U\n",
+			wantErr: ErrEmptyImport,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: too many tracks",
+			format:  playlistfile.FormatM3U,
+			file:    strings.Repeat("spotify:track:3z8h0TU7ReDPLIbEnYhWZb\n", playlists.MaxTracks+1),
+			wantErr: playlistsRepo.ErrPlaylistFull,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: invalid file",
+			format:  playlistfile.FormatXSPF,
+			file:    "<playlist><trackList>",
+			wantErr: playlistfile.ErrInvalidFile,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: spotify rate limited",
+			format:  playlistfile.FormatM3U,
+			file:    "#EXTINF:209,Queen - Don't Stop Me Now\nMusic/dont_stop.mp3\n",
+			wantErr: spotifyRepo.ErrRateLimited,
+			mockFn: func() {
+				mockOutbound.EXPECT().Search(gomock.Any(), "Queen Don't Stop Me Now", 5, 0, "").Return(nil, spotifyRepo.ErrRateLimited)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				repository:      mockRepo,
+				trackCatalog:    mockCatalog,
+				spotifyOutbound: mockOutbound,
+			}
+			got, err := s.ImportPlaylist(context.Background(), 1, tt.format, strings.NewReader(tt.file))
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package tracks
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+)
+
+func (s *service) GetAlbum(ctx context.Context, userID uint, albumID string) (*spotify.AlbumResponse, error) {
+	album, err := s.spotifyOutbound.GetAlbum(ctx, albumID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get album from spotify outbound")
+		return nil, err
+	}
+
+	items := withAlbum(album.Tracks.Items, album)
+	trackActivities, err := s.getTrackActivities(ctx, userID, items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spotify.AlbumResponse{
+		ID:          album.ID,
+		Name:        album.Name,
+		AlbumType:   album.AlbumType,
+		Label:       album.Label,
+		ReleaseDate: album.ReleaseDate,
+		TotalTracks: album.TotalTracks,
+		ImagesURL:   imagesURL(album.Images),
+		ArtistsName: artistsName(album.Artists),
+		Items:       tracksToResponse(items, trackActivities),
+	}, nil
+}
+
+func (s *service) GetAlbumTracks(ctx context.Context, userID uint, albumID string, pageSize, pageIndex int) (*spotify.AlbumTracksResponse, error) {
+	limit := pageSize
+	offset := (pageIndex - 1) * pageSize
+
+	albumTracks, err := s.spotifyOutbound.GetAlbumTracks(ctx, albumID, limit, offset)
+	if err != nil {
+		log.Error().Err(err).Msg("error get album tracks from spotify outbound")
+		return nil, err
+	}
+
+	trackActivities, err := s.getTrackActivities(ctx, userID, albumTracks.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spotify.AlbumTracksResponse{
+		Limit:  albumTracks.Limit,
+		Offset: albumTracks.Offset,
+		Items:  tracksToResponse(albumTracks.Items, trackActivities),
+		Total:  albumTracks.Total,
+	}, nil
+}
+
+// withAlbum fills the album of simplified track objects, which spotify
+// leaves empty when the tracks are listed as part of their album.
+func withAlbum(items []spotifyRepo.SpotifyTrackObject, album *spotifyRepo.SpotifyAlbumDetail) []spotifyRepo.SpotifyTrackObject {
+	tracks := make([]spotifyRepo.SpotifyTrackObject, len(items))
+	for idx, item := range items {
+		item.Album = spotifyRepo.SpotifyAlbumObject{
+			AlbumType:   album.AlbumType,
+			TotalTracks: album.TotalTracks,
+			Images:      album.Images,
+			Name:        album.Name,
+		}
+		tracks[idx] = item
+	}
+	return tracks
+}
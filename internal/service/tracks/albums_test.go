@@ -0,0 +1,218 @@
+package tracks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_service_GetAlbum(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivitiesRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	isLikedTrue := true
+	album := &spotifyRepo.SpotifyAlbumDetail{
+		AlbumType:   "album",
+		Artists:     []spotifyRepo.SpotifyArtistObject{{ID: "4tZwfgrHOc3mvqYlEYSvVi", Name: "Daft Punk"}},
+		ID:          "2noRn2Aes5aoNVsU6iWThc",
+		Images:      []spotifyRepo.SpotifyAlbumImage{{URL: "https://i.scdn.co/image/discovery"}},
+		Label:       "Parlophone",
+		Name:        "Discovery",
+		ReleaseDate: "2001-03-12",
+		TotalTracks: 14,
+		Tracks: spotifyRepo.SpotifyTracks{
+			Items: []spotifyRepo.SpotifyTrackObject{
+				{
+					Artists: []spotifyRepo.SpotifyArtistObject{{ID: "4tZwfgrHOc3mvqYlEYSvVi", Name: "Daft Punk"}},
+					ID:      "0DiWol3AO6WpXZgp0goxAV",
+					Name:    "One More Time",
+				},
+			},
+		},
+	}
+
+	type args struct {
+		userID  uint
+		albumID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *spotify.AlbumResponse
+		wantErr bool
+		mockFn  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				userID:  1,
+				albumID: "2noRn2Aes5aoNVsU6iWThc",
+			},
+			want: &spotify.AlbumResponse{
+				ID:          "2noRn2Aes5aoNVsU6iWThc",
+				Name:        "Discovery",
+				AlbumType:   "album",
+				Label:       "Parlophone",
+				ReleaseDate: "2001-03-12",
+				TotalTracks: 14,
+				ImagesURL:   []string{"https://i.scdn.co/image/discovery"},
+				ArtistsName: []string{"Daft Punk"},
+				Items: []spotify.SpotifyTrackObject{
+					{
+						AlbumType:        "album",
+						AlbumTotalTracks: 14,
+						AlbumImagesURL:   []string{"https://i.scdn.co/image/discovery"},
+						AlbumName:        "Discovery",
+						ArtistsName:      []string{"Daft Punk"},
+						ArtistsID:        []string{"4tZwfgrHOc3mvqYlEYSvVi"},
+						ID:               "0DiWol3AO6WpXZgp0goxAV",
+						Name:             "One More Time",
+						IsLiked:          &isLikedTrue,
+					},
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetAlbum(gomock.Any(), args.albumID).Return(album, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{"0DiWol3AO6WpXZgp0goxAV"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"0DiWol3AO6WpXZgp0goxAV": {
+							IsLiked: &isLikedTrue,
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "failed: when get album from spotify outbound",
+			args: args{
+				userID:  1,
+				albumID: "2noRn2Aes5aoNVsU6iWThc",
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetAlbum(gomock.Any(), args.albumID).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.args)
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivitiesRepo,
+			}
+			got, err := s.GetAlbum(context.Background(), tt.args.userID, tt.args.albumID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.GetAlbum() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.GetAlbum() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetAlbumTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivitiesRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	type args struct {
+		userID    uint
+		albumID   string
+		pageSize  int
+		pageIndex int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *spotify.AlbumTracksResponse
+		wantErr bool
+		mockFn  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				userID:    1,
+				albumID:   "2noRn2Aes5aoNVsU6iWThc",
+				pageSize:  10,
+				pageIndex: 2,
+			},
+			want: &spotify.AlbumTracksResponse{
+				Limit:  10,
+				Offset: 10,
+				Items: []spotify.SpotifyTrackObject{
+					{
+						AlbumImagesURL: []string{},
+						ArtistsName:    []string{"Daft Punk"},
+						ArtistsID:      []string{"4tZwfgrHOc3mvqYlEYSvVi"},
+						ID:             "2VEZx7NWsZ1D0eJ4uv5Fym",
+						Name:           "Harder, Better, Faster, Stronger",
+					},
+				},
+				Total: 14,
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetAlbumTracks(gomock.Any(), args.albumID, 10, 10).Return(&spotifyRepo.SpotifyTracks{
+					Limit:  10,
+					Offset: 10,
+					Total:  14,
+					Items: []spotifyRepo.SpotifyTrackObject{
+						{
+							Artists: []spotifyRepo.SpotifyArtistObject{{ID: "4tZwfgrHOc3mvqYlEYSvVi", Name: "Daft Punk"}},
+							ID:      "2VEZx7NWsZ1D0eJ4uv5Fym",
+							Name:    "Harder, Better, Faster, Stronger",
+						},
+					},
+				}, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{"2VEZx7NWsZ1D0eJ4uv5Fym"}).
+					Return(map[string]trackactivities.TrackActivity{}, nil)
+			},
+		},
+		{
+			name: "failed: when get album tracks from spotify outbound",
+			args: args{
+				userID:    1,
+				albumID:   "2noRn2Aes5aoNVsU6iWThc",
+				pageSize:  10,
+				pageIndex: 1,
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetAlbumTracks(gomock.Any(), args.albumID, 10, 0).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.args)
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivitiesRepo,
+			}
+			got, err := s.GetAlbumTracks(context.Background(), tt.args.userID, tt.args.albumID, tt.args.pageSize, tt.args.pageIndex)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.GetAlbumTracks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.GetAlbumTracks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
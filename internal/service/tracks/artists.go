@@ -0,0 +1,42 @@
+package tracks
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+)
+
+func (s *service) GetArtist(ctx context.Context, artistID string) (*spotify.ArtistResponse, error) {
+	artist, err := s.spotifyOutbound.GetArtist(ctx, artistID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get artist from spotify outbound")
+		return nil, err
+	}
+
+	return &spotify.ArtistResponse{
+		ID:         artist.ID,
+		Name:       artist.Name,
+		Genres:     artist.Genres,
+		Followers:  artist.Followers.Total,
+		Popularity: artist.Popularity,
+		ImagesURL:  imagesURL(artist.Images),
+	}, nil
+}
+
+func (s *service) GetArtistTopTracks(ctx context.Context, userID uint, artistID, market string) (*spotify.ArtistTopTracksResponse, error) {
+	topTracks, err := s.spotifyOutbound.GetArtistTopTracks(ctx, artistID, market)
+	if err != nil {
+		log.Error().Err(err).Msg("error get artist top tracks from spotify outbound")
+		return nil, err
+	}
+
+	trackActivities, err := s.getTrackActivities(ctx, userID, topTracks.Tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &spotify.ArtistTopTracksResponse{
+		Items: tracksToResponse(topTracks.Tracks, trackActivities),
+	}, nil
+}
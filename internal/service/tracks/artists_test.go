@@ -0,0 +1,189 @@
+package tracks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_service_GetArtist(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+
+	tests := []struct {
+		name     string
+		artistID string
+		want     *spotify.ArtistResponse
+		wantErr  bool
+		mockFn   func(artistID string)
+	}{
+		{
+			name:     "success",
+			artistID: "1dfeR4HaWDbWqFHLkxsg1d",
+			want: &spotify.ArtistResponse{
+				ID:         "1dfeR4HaWDbWqFHLkxsg1d",
+				Name:       "Queen",
+				Genres:     []string{"classic rock", "glam rock"},
+				Followers:  51000000,
+				Popularity: 86,
+				ImagesURL:  []string{"https://i.scdn.co/image/queen"},
+			},
+			wantErr: false,
+			mockFn: func(artistID string) {
+				mockSpotifyOutbound.EXPECT().GetArtist(gomock.Any(), artistID).Return(&spotifyRepo.SpotifyArtistDetail{
+					Followers:  spotifyRepo.SpotifyFollowers{Total: 51000000},
+					Genres:     []string{"classic rock", "glam rock"},
+					ID:         "1dfeR4HaWDbWqFHLkxsg1d",
+					Images:     []spotifyRepo.SpotifyAlbumImage{{URL: "https://i.scdn.co/image/queen"}},
+					Name:       "Queen",
+					Popularity: 86,
+				}, nil)
+			},
+		},
+		{
+			name:     "failed",
+			artistID: "1dfeR4HaWDbWqFHLkxsg1d",
+			want:     nil,
+			wantErr:  true,
+			mockFn: func(artistID string) {
+				mockSpotifyOutbound.EXPECT().GetArtist(gomock.Any(), artistID).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.artistID)
+			s := &service{
+				spotifyOutbound: mockSpotifyOutbound,
+			}
+			got, err := s.GetArtist(context.Background(), tt.artistID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.GetArtist() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.GetArtist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetArtistTopTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivitiesRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	isLikedFalse := false
+	topTracks := &spotifyRepo.SpotifyArtistTopTracksResponse{
+		Tracks: []spotifyRepo.SpotifyTrackObject{
+			{
+				Album:   spotifyRepo.SpotifyAlbumObject{AlbumType: "album", TotalTracks: 13, Name: "Jazz (2011 Remaster)"},
+				Artists: []spotifyRepo.SpotifyArtistObject{{ID: "1dfeR4HaWDbWqFHLkxsg1d", Name: "Queen"}},
+				ID:      "5T8EDUDqKcs6OSOwEsfqG7",
+				Name:    "Don't Stop Me Now - Remastered 2011",
+			},
+		},
+	}
+
+	type args struct {
+		userID   uint
+		artistID string
+		market   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *spotify.ArtistTopTracksResponse
+		wantErr bool
+		mockFn  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				userID:   1,
+				artistID: "1dfeR4HaWDbWqFHLkxsg1d",
+				market:   "ID",
+			},
+			want: &spotify.ArtistTopTracksResponse{
+				Items: []spotify.SpotifyTrackObject{
+					{
+						AlbumType:        "album",
+						AlbumTotalTracks: 13,
+						AlbumImagesURL:   []string{},
+						AlbumName:        "Jazz (2011 Remaster)",
+						ArtistsName:      []string{"Queen"},
+						ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
+						ID:               "5T8EDUDqKcs6OSOwEsfqG7",
+						Name:             "Don't Stop Me Now - Remastered 2011",
+						IsLiked:          &isLikedFalse,
+					},
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetArtistTopTracks(gomock.Any(), args.artistID, args.market).Return(topTracks, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{"5T8EDUDqKcs6OSOwEsfqG7"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"5T8EDUDqKcs6OSOwEsfqG7": {
+							IsLiked: &isLikedFalse,
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "failed: when get bulk spotify id",
+			args: args{
+				userID:   1,
+				artistID: "1dfeR4HaWDbWqFHLkxsg1d",
+				market:   "ID",
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetArtistTopTracks(gomock.Any(), args.artistID, args.market).Return(topTracks, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{"5T8EDUDqKcs6OSOwEsfqG7"}).
+					Return(nil, assert.AnError)
+			},
+		},
+		{
+			name: "failed: when get top tracks from spotify outbound",
+			args: args{
+				userID:   1,
+				artistID: "1dfeR4HaWDbWqFHLkxsg1d",
+				market:   "ID",
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetArtistTopTracks(gomock.Any(), args.artistID, args.market).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.args)
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivitiesRepo,
+			}
+			got, err := s.GetArtistTopTracks(context.Background(), tt.args.userID, tt.args.artistID, tt.args.market)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.GetArtistTopTracks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.GetArtistTopTracks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package tracks
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+)
+
+// getTrackActivities loads the user activities of the given spotify tracks,
+// keyed by spotify id.
+func (s *service) getTrackActivities(ctx context.Context, userID uint, items []spotifyRepo.SpotifyTrackObject) (map[string]trackactivities.TrackActivity, error) {
+	trackIDs := make([]string, len(items))
+	for idx, item := range items {
+		trackIDs[idx] = item.ID
+	}
+
+	trackActivities, err := s.trackActivitiesRepo.GetBulkSpotifyIDs(ctx, userID, trackIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("error get track activities from database")
+		return nil, err
+	}
+	return trackActivities, nil
+}
+
+func tracksToResponse(items []spotifyRepo.SpotifyTrackObject, mapTrackActivities map[string]trackactivities.TrackActivity) []spotify.SpotifyTrackObject {
+	tracks := make([]spotify.SpotifyTrackObject, 0, len(items))
+	for _, item := range items {
+		tracks = append(tracks, trackToResponse(item, mapTrackActivities[item.ID].IsLiked))
+	}
+	return tracks
+}
+
+func trackToResponse(item spotifyRepo.SpotifyTrackObject, isLiked *bool) spotify.SpotifyTrackObject {
+	return spotify.SpotifyTrackObject{
+		// album related fields
+		AlbumType:        item.Album.AlbumType,
+		AlbumTotalTracks: item.Album.TotalTracks,
+		AlbumImagesURL:   imagesURL(item.Album.Images),
+		AlbumName:        item.Album.Name,
+		AlbumID:          item.Album.ID,
+		AlbumReleaseDate: item.Album.ReleaseDate,
+		// artist related fields
+		ArtistsName: artistsName(item.Artists),
+		ArtistsID:   artistsID(item.Artists),
+		// track related fields
+		Explicit:    item.Explicit,
+		ID:          item.ID,
+		Name:        item.Name,
+		DurationMs:  item.DurationMs,
+		Popularity:  item.Popularity,
+		ISRC:        item.ExternalIDs.ISRC,
+		PreviewURL:  item.PreviewURL,
+		TrackNumber: item.TrackNumber,
+		DiscNumber:  item.DiscNumber,
+		IsLiked:     isLiked,
+	}
+}
+
+func imagesURL(images []spotifyRepo.SpotifyAlbumImage) []string {
+	imageUrls := make([]string, len(images))
+	for idx, image := range images {
+		imageUrls[idx] = image.URL
+	}
+	return imageUrls
+}
+
+func artistsName(artists []spotifyRepo.SpotifyArtistObject) []string {
+	names := make([]string, len(artists))
+	for idx, artist := range artists {
+		names[idx] = artist.Name
+	}
+	return names
+}
+
+func artistsID(artists []spotifyRepo.SpotifyArtistObject) []string {
+	ids := make([]string, len(artists))
+	for idx, artist := range artists {
+		ids[idx] = artist.ID
+	}
+	return ids
+}
@@ -0,0 +1,196 @@
+package tracks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"github.com/xprasetio/go-spotify/internal/repository/spotify"
+	trackactivitiesRepo "github.com/xprasetio/go-spotify/internal/repository/trackactivities"
+	"gorm.io/gorm"
+)
+
+const (
+	// importPageSize is the most saved tracks spotify returns at once.
+	importPageSize = 50
+	// importLease is how long a job stays with a worker without progress.
+	importLease = 2 * time.Minute
+	// importMaxAttempts fails a job that keeps stopping halfway.
+	importMaxAttempts = 5
+)
+
+var ErrImportJobNotFound = errors.New("import job not found")
+
+// StartLibraryImport queues an import of the user's liked songs, or returns the active one.
+func (s *service) StartLibraryImport(ctx context.Context, userID uint) (*trackactivities.ImportJobResponse, error) {
+	// fail right away for users without a linked account
+	_, err := s.spotifyTokens.SpotifyAccessToken(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.trackActivitiesRepo.GetActiveImportJob(ctx, userID)
+	if err == nil {
+		return importJobResponse(job), nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		log.Error().Err(err).Msg("error get active import job from database")
+		return nil, err
+	}
+
+	job = &trackactivities.ImportJob{
+		UserID: userID,
+		Status: trackactivities.ImportStatusPending,
+	}
+	err = s.trackActivitiesRepo.CreateImportJob(ctx, job)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// a concurrent request started one first
+		job, err = s.trackActivitiesRepo.GetActiveImportJob(ctx, userID)
+		if err != nil {
+			log.Error().Err(err).Msg("error get active import job from database")
+			return nil, err
+		}
+		return importJobResponse(job), nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error create import job to database")
+		return nil, err
+	}
+
+	select {
+	case s.importQueued <- struct{}{}:
+	default:
+	}
+	return importJobResponse(job), nil
+}
+
+func (s *service) GetLibraryImport(ctx context.Context, userID, jobID uint) (*trackactivities.ImportJobResponse, error) {
+	job, err := s.trackActivitiesRepo.GetImportJob(ctx, jobID)
+	if err == gorm.ErrRecordNotFound {
+		return nil, ErrImportJobNotFound
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error get import job from database")
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, ErrImportJobNotFound
+	}
+	return importJobResponse(job), nil
+}
+
+// RunLibraryImports works through queued imports until ctx is done.
+func (s *service) RunLibraryImports(ctx context.Context, interval time.Duration) {
+	worker{queued: s.importQueued, next: s.runNextImport}.run(ctx, interval)
+}
+
+// runNextImport runs one claimed job and reports whether there was one.
+func (s *service) runNextImport(ctx context.Context) bool {
+	now := time.Now()
+	job, err := s.trackActivitiesRepo.ClaimImportJob(ctx, now, now.Add(importLease))
+	if err == gorm.ErrRecordNotFound {
+		return false
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("error claim import job from database")
+		return false
+	}
+
+	err = s.runImport(ctx, job)
+	switch {
+	case err == nil:
+	case ctx.Err() != nil:
+		// shutting down, the job resumes once its lease runs out
+	case errors.Is(err, trackactivitiesRepo.ErrImportClaimLost):
+		log.Warn().Uint("jobID", job.ID).Msg("import job was taken over by another worker")
+	case errors.Is(err, spotify.ErrRateLimited), errors.Is(err, spotify.ErrUnavailable), errors.Is(err, spotify.ErrUpstream):
+		log.Warn().Err(err).Uint("jobID", job.ID).Int("attempt", job.Attempt).Msg("import job stopped, will be retried")
+		if job.Attempt >= importMaxAttempts {
+			s.finishImport(ctx, *job, trackactivities.ImportStatusFailed, err)
+		}
+	default:
+		log.Error().Err(err).Uint("jobID", job.ID).Msg("import job failed")
+		s.finishImport(ctx, *job, trackactivities.ImportStatusFailed, err)
+	}
+	return true
+}
+
+// runImport pages through the saved tracks from the job's offset on.
+func (s *service) runImport(ctx context.Context, job *trackactivities.ImportJob) error {
+	for {
+		accessToken, err := s.spotifyTokens.SpotifyAccessToken(ctx, job.UserID)
+		if err != nil {
+			return err
+		}
+		page, err := s.spotifyOutbound.GetSavedTracks(ctx, accessToken, importPageSize, job.Offset)
+		if err != nil {
+			return err
+		}
+
+		// skip local files and tracks repeated on the page
+		activities := make([]trackactivities.TrackActivity, 0, len(page.Items))
+		seen := make(map[string]bool, len(page.Items))
+		for _, item := range page.Items {
+			if item.Track.ID == "" || seen[item.Track.ID] {
+				continue
+			}
+			seen[item.Track.ID] = true
+			activities = append(activities, likedActivity(job.UserID, item))
+		}
+		job.Offset += len(page.Items)
+		job.Total = page.Total
+
+		_, err = s.trackActivitiesRepo.SaveImportPage(ctx, *job, activities, time.Now().Add(importLease))
+		if err != nil {
+			log.Error().Err(err).Uint("jobID", job.ID).Msg("error save import page to database")
+			return err
+		}
+
+		if page.Next == nil || len(page.Items) == 0 {
+			s.finishImport(ctx, *job, trackactivities.ImportStatusCompleted, nil)
+			return nil
+		}
+	}
+}
+
+func (s *service) finishImport(ctx context.Context, job trackactivities.ImportJob, status string, jobErr error) {
+	var errMessage string
+	if jobErr != nil {
+		errMessage = jobErr.Error()
+	}
+	err := s.trackActivitiesRepo.FinishImportJob(ctx, job, status, errMessage, time.Now())
+	if err != nil {
+		log.Error().Err(err).Uint("jobID", job.ID).Msg("error finish import job in database")
+	}
+}
+
+// likedActivity dates the like to when the track was saved on spotify.
+func likedActivity(userID uint, item spotify.SpotifySavedTrack) trackactivities.TrackActivity {
+	isLiked := true
+	activity := trackactivities.TrackActivity{
+		UserID:    userID,
+		SpotifyID: item.Track.ID,
+		IsLiked:   &isLiked,
+		CreatedBy: fmt.Sprintf("%d", userID),
+		UpdatedBy: fmt.Sprintf("%d", userID),
+	}
+	activity.CreatedAt = item.AddedAt
+	activity.UpdatedAt = item.AddedAt
+	return activity
+}
+
+func importJobResponse(job *trackactivities.ImportJob) *trackactivities.ImportJobResponse {
+	return &trackactivities.ImportJobResponse{
+		ID:         job.ID,
+		Status:     job.Status,
+		Processed:  job.Offset,
+		Total:      job.Total,
+		Imported:   job.Imported,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
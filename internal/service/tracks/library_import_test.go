@@ -0,0 +1,328 @@
+package tracks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/configs"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	trackactivitiesRepo "github.com/xprasetio/go-spotify/internal/repository/trackactivities"
+	"github.com/xprasetio/go-spotify/pkg/fakespotify"
+	"github.com/xprasetio/go-spotify/pkg/httpclient"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_StartLibraryImport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	mockTokens := NewMockspotifyTokens(mockCtrl)
+
+	tests := []struct {
+		name    string
+		want    *trackactivities.ImportJobResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: &trackactivities.ImportJobResponse{ID: 3, Status: "pending"},
+			mockFn: func() {
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockTrackActivityRepo.EXPECT().GetActiveImportJob(gomock.Any(), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockTrackActivityRepo.EXPECT().CreateImportJob(gomock.Any(), &trackactivities.ImportJob{
+					UserID: 1,
+					Status: trackactivities.ImportStatusPending,
+				}).DoAndReturn(func(_ context.Context, model *trackactivities.ImportJob) error {
+					model.ID = 3
+					return nil
+				})
+			},
+		},
+		{
+			name: "success: returns the running job",
+			want: &trackactivities.ImportJobResponse{ID: 2, Status: "running", Processed: 50, Total: 120, Imported: 40},
+			mockFn: func() {
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockTrackActivityRepo.EXPECT().GetActiveImportJob(gomock.Any(), uint(1)).Return(&trackactivities.ImportJob{
+					Model:    gorm.Model{ID: 2},
+					UserID:   1,
+					Status:   trackactivities.ImportStatusRunning,
+					Offset:   50,
+					Total:    120,
+					Imported: 40,
+				}, nil)
+			},
+		},
+		{
+			name: "success: returns the job a concurrent request started",
+			want: &trackactivities.ImportJobResponse{ID: 4, Status: "pending"},
+			mockFn: func() {
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockTrackActivityRepo.EXPECT().GetActiveImportJob(gomock.Any(), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockTrackActivityRepo.EXPECT().CreateImportJob(gomock.Any(), gomock.Any()).Return(gorm.ErrDuplicatedKey)
+				mockTrackActivityRepo.EXPECT().GetActiveImportJob(gomock.Any(), uint(1)).Return(&trackactivities.ImportJob{
+					Model:  gorm.Model{ID: 4},
+					UserID: 1,
+					Status: trackactivities.ImportStatusPending,
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: spotify not linked",
+			wantErr: spotifyRepo.ErrNotLinked,
+			mockFn: func() {
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("", spotifyRepo.ErrNotLinked)
+			},
+		},
+		{
+			name:    "failed: create job",
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockTrackActivityRepo.EXPECT().GetActiveImportJob(gomock.Any(), uint(1)).Return(nil, gorm.ErrRecordNotFound)
+				mockTrackActivityRepo.EXPECT().CreateImportJob(gomock.Any(), gomock.Any()).Return(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := NewService(nil, mockTrackActivityRepo, mockTokens)
+			got, err := s.StartLibraryImport(context.Background(), 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_GetLibraryImport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	finishedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		want    *trackactivities.ImportJobResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name: "success",
+			want: &trackactivities.ImportJobResponse{ID: 2, Status: "completed", Processed: 120, Total: 120, Imported: 100, FinishedAt: &finishedAt},
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().GetImportJob(gomock.Any(), uint(2)).Return(&trackactivities.ImportJob{
+					Model:      gorm.Model{ID: 2},
+					UserID:     1,
+					Status:     trackactivities.ImportStatusCompleted,
+					Offset:     120,
+					Total:      120,
+					Imported:   100,
+					FinishedAt: &finishedAt,
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: job of another user",
+			wantErr: ErrImportJobNotFound,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().GetImportJob(gomock.Any(), uint(2)).Return(&trackactivities.ImportJob{
+					Model:  gorm.Model{ID: 2},
+					UserID: 7,
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: unknown job",
+			wantErr: ErrImportJobNotFound,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().GetImportJob(gomock.Any(), uint(2)).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				trackActivitiesRepo: mockTrackActivityRepo,
+			}
+			got, err := s.GetLibraryImport(context.Background(), 1, 2)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_runNextImport(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	mockTokens := NewMockspotifyTokens(mockCtrl)
+
+	addedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	next := "next"
+	savedTracks := func(ids ...string) []spotifyRepo.SpotifySavedTrack {
+		items := make([]spotifyRepo.SpotifySavedTrack, len(ids))
+		for idx, id := range ids {
+			items[idx] = spotifyRepo.SpotifySavedTrack{AddedAt: addedAt, Track: spotifyRepo.SpotifyTrackObject{ID: id}}
+		}
+		return items
+	}
+	claimed := func(offset, attempt int) *trackactivities.ImportJob {
+		return &trackactivities.ImportJob{
+			Model:   gorm.Model{ID: 2},
+			UserID:  1,
+			Status:  trackactivities.ImportStatusRunning,
+			Offset:  offset,
+			Attempt: attempt,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		want   bool
+		mockFn func()
+	}{
+		{
+			name: "success: nothing to do",
+			want: false,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+			},
+		},
+		{
+			name: "success: resumes from the stored offset",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed(50, 2), nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil).Times(2)
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", importPageSize, 50).Return(&spotifyRepo.SpotifySavedTracksResponse{
+					Total: 53,
+					Next:  &next,
+					// local files have no id, duplicates are upserted once
+					Items: savedTracks("track1", "", "track1"),
+				}, nil)
+				mockTrackActivityRepo.EXPECT().SaveImportPage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, job trackactivities.ImportJob, activities []trackactivities.TrackActivity, _ time.Time) (int, error) {
+						assert.Equal(t, 53, job.Offset)
+						assert.Equal(t, 53, job.Total)
+						assert.Len(t, activities, 1)
+						assert.Equal(t, "track1", activities[0].SpotifyID)
+						assert.True(t, *activities[0].IsLiked)
+						assert.Equal(t, addedAt, activities[0].UpdatedAt)
+						return 1, nil
+					})
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", importPageSize, 53).Return(&spotifyRepo.SpotifySavedTracksResponse{
+					Total: 53,
+				}, nil)
+				mockTrackActivityRepo.EXPECT().SaveImportPage(gomock.Any(), gomock.Any(), []trackactivities.TrackActivity{}, gomock.Any()).Return(0, nil)
+				mockTrackActivityRepo.EXPECT().FinishImportJob(gomock.Any(), gomock.Any(), trackactivities.ImportStatusCompleted, "", gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success: spotify unavailable, retried later",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed(0, 1), nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", importPageSize, 0).Return(nil, &spotifyRepo.APIError{StatusCode: 503})
+			},
+		},
+		{
+			name: "success: spotify unavailable for too long",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed(0, importMaxAttempts), nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", importPageSize, 0).Return(nil, &spotifyRepo.APIError{StatusCode: 503})
+				mockTrackActivityRepo.EXPECT().FinishImportJob(gomock.Any(), gomock.Any(), trackactivities.ImportStatusFailed, gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success: account unlinked",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed(0, 1), nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("", spotifyRepo.ErrNotLinked)
+				mockTrackActivityRepo.EXPECT().FinishImportJob(gomock.Any(), gomock.Any(), trackactivities.ImportStatusFailed, spotifyRepo.ErrNotLinked.Error(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "success: taken over by another worker",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(claimed(0, 1), nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", importPageSize, 0).Return(&spotifyRepo.SpotifySavedTracksResponse{
+					Total: 1,
+					Items: savedTracks("track1"),
+				}, nil)
+				mockTrackActivityRepo.EXPECT().SaveImportPage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(0, trackactivitiesRepo.ErrImportClaimLost)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := NewService(mockSpotifyOutbound, mockTrackActivityRepo, mockTokens)
+			assert.Equal(t, tt.want, s.runNextImport(context.Background()))
+		})
+	}
+}
+
+// Test_service_runNextImport_fakeSpotify pages through a library served by
+// the fake spotify server.
+func Test_service_runNextImport_fakeSpotify(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	mockTokens := NewMockspotifyTokens(mockCtrl)
+
+	library := make([]string, 0, importPageSize+10)
+	for idx := 0; idx < importPageSize+10; idx++ {
+		library = append(library, fmt.Sprintf("track%d", idx))
+	}
+	server := fakespotify.NewServer(fakespotify.WithSavedTracks("fakeuser", library...))
+	t.Cleanup(server.Close)
+	outbound := spotifyRepo.NewSpotifyOutbound(&configs.Config{
+		SpotifyConfig: configs.SpotifyConfig{
+			APIBaseURL:      server.APIBaseURL(),
+			AccountsBaseURL: server.AccountsBaseURL(),
+		},
+	}, httpclient.NewClient(&http.Client{}))
+	token, err := outbound.RefreshUserToken(context.Background(), server.AuthorizeUser("fakeuser"))
+	assert.NoError(t, err)
+
+	imported := make([]string, 0, len(library))
+	mockTrackActivityRepo.EXPECT().ClaimImportJob(gomock.Any(), gomock.Any(), gomock.Any()).Return(&trackactivities.ImportJob{
+		Model:  gorm.Model{ID: 2},
+		UserID: 1,
+		Status: trackactivities.ImportStatusRunning,
+	}, nil)
+	mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return(token.AccessToken, nil).AnyTimes()
+	mockTrackActivityRepo.EXPECT().SaveImportPage(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, job trackactivities.ImportJob, activities []trackactivities.TrackActivity, _ time.Time) (int, error) {
+			for _, activity := range activities {
+				imported = append(imported, activity.SpotifyID)
+			}
+			assert.Equal(t, len(imported), job.Offset)
+			return len(activities), nil
+		}).Times(2)
+	mockTrackActivityRepo.EXPECT().FinishImportJob(gomock.Any(), gomock.Any(), trackactivities.ImportStatusCompleted, "", gomock.Any()).Return(nil)
+
+	s := NewService(outbound, mockTrackActivityRepo, mockTokens)
+	assert.True(t, s.runNextImport(context.Background()))
+	assert.Equal(t, library, imported)
+}
@@ -0,0 +1,312 @@
+package tracks
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	"github.com/xprasetio/go-spotify/internal/repository/spotify"
+	trackactivitiesRepo "github.com/xprasetio/go-spotify/internal/repository/trackactivities"
+)
+
+const (
+	// likeSyncBatchSize is the most tracks spotify saves or removes at once.
+	likeSyncBatchSize = 50
+	// likeSyncLease is how long claimed changes stay with a worker.
+	likeSyncLease = time.Minute
+	// likeSyncMaxAttempts moves a change that keeps failing to the dead letter.
+	likeSyncMaxAttempts = 8
+	likeSyncBaseBackoff = 30 * time.Second
+	likeSyncMaxBackoff  = time.Hour
+	// likeSyncLookbackPages bounds the recent likes read back from spotify.
+	likeSyncLookbackPages = 4
+	// likeSyncRetention is how long synced and skipped changes are kept.
+	likeSyncRetention     = 7 * 24 * time.Hour
+	likeSyncPruneInterval = time.Hour
+)
+
+// newLikeSync returns the change to push to spotify, nil when the liked state stays the same.
+func newLikeSync(userID uint, spotifyID string, before, after *bool) *trackactivities.LikeSync {
+	liked := isLiked(after)
+	if isLiked(before) == liked {
+		return nil
+	}
+	now := time.Now()
+	return &trackactivities.LikeSync{
+		UserID:        userID,
+		SpotifyID:     spotifyID,
+		Liked:         liked,
+		ChangedAt:     now,
+		Status:        trackactivities.LikeSyncStatusPending,
+		NextAttemptAt: now,
+	}
+}
+
+// likeSyncFor is newLikeSync, nil for users without a linked account.
+func (s *service) likeSyncFor(ctx context.Context, userID uint, spotifyID string, before, after *bool) (*trackactivities.LikeSync, error) {
+	likeSync := newLikeSync(userID, spotifyID, before, after)
+	if likeSync == nil {
+		return nil, nil
+	}
+	linked, err := s.spotifyTokens.SpotifyLinked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !linked {
+		return nil, nil
+	}
+	return likeSync, nil
+}
+
+func isLiked(value *bool) bool {
+	return value != nil && *value
+}
+
+func (s *service) queueLikeSync(likeSync *trackactivities.LikeSync) {
+	if likeSync == nil {
+		return
+	}
+	select {
+	case s.likeSyncQueued <- struct{}{}:
+	default:
+	}
+}
+
+// RunLikeSync pushes like changes to spotify until ctx is done.
+func (s *service) RunLikeSync(ctx context.Context, interval time.Duration) {
+	worker{
+		queued:        s.likeSyncQueued,
+		next:          s.runNextLikeSync,
+		prune:         s.pruneLikeSyncs,
+		pruneInterval: likeSyncPruneInterval,
+	}.run(ctx, interval)
+}
+
+// pruneLikeSyncs deletes old synced and skipped changes, dead ones stay.
+func (s *service) pruneLikeSyncs(ctx context.Context, now time.Time) {
+	_, err := s.trackActivitiesRepo.PruneLikeSyncs(ctx, now.Add(-likeSyncRetention))
+	if err != nil {
+		log.Error().Err(err).Msg("error prune like syncs from database")
+	}
+}
+
+// runNextLikeSync pushes one claimed batch and reports whether there was one.
+func (s *service) runNextLikeSync(ctx context.Context) bool {
+	now := time.Now()
+	syncs, err := s.trackActivitiesRepo.ClaimLikeSyncs(ctx, now, now.Add(likeSyncLease), likeSyncBatchSize)
+	if err != nil {
+		log.Error().Err(err).Msg("error claim like syncs from database")
+		return false
+	}
+	if len(syncs) == 0 {
+		return false
+	}
+
+	s.syncLikes(ctx, syncs)
+	return true
+}
+
+// syncLikes pushes the claimed changes of one user that still match the activity.
+func (s *service) syncLikes(ctx context.Context, syncs []trackactivities.LikeSync) {
+	userID := syncs[0].UserID
+
+	latest := make(map[string]trackactivities.LikeSync, len(syncs))
+	spotifyIDs := make([]string, 0, len(syncs))
+	for _, likeSync := range syncs {
+		if previous, ok := latest[likeSync.SpotifyID]; ok {
+			s.finishLikeSync(ctx, previous, trackactivities.LikeSyncStatusSkipped, "replaced by a later change")
+		} else {
+			spotifyIDs = append(spotifyIDs, likeSync.SpotifyID)
+		}
+		latest[likeSync.SpotifyID] = likeSync
+	}
+
+	pending := make([]trackactivities.LikeSync, 0, len(spotifyIDs))
+	for _, spotifyID := range spotifyIDs {
+		pending = append(pending, latest[spotifyID])
+	}
+	activities, err := s.trackActivitiesRepo.GetBulkSpotifyIDs(ctx, userID, spotifyIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("error get track activities from database")
+		s.failLikeSyncs(ctx, pending, err)
+		return
+	}
+
+	saves := make([]trackactivities.LikeSync, 0, len(pending))
+	removes := make([]trackactivities.LikeSync, 0, len(pending))
+	for _, likeSync := range pending {
+		switch {
+		case isLiked(activities[likeSync.SpotifyID].IsLiked) != likeSync.Liked:
+			s.finishLikeSync(ctx, likeSync, trackactivities.LikeSyncStatusSkipped, "replaced by a later change")
+		case likeSync.Liked:
+			saves = append(saves, likeSync)
+		default:
+			removes = append(removes, likeSync)
+		}
+	}
+	if len(saves) == 0 && len(removes) == 0 {
+		return
+	}
+
+	accessToken, err := s.spotifyTokens.SpotifyAccessToken(ctx, userID)
+	if errors.Is(err, spotify.ErrNotLinked) {
+		for _, likeSync := range append(saves, removes...) {
+			s.finishLikeSync(ctx, likeSync, trackactivities.LikeSyncStatusSkipped, err.Error())
+		}
+		return
+	}
+	if err != nil {
+		s.failLikeSyncs(ctx, append(saves, removes...), err)
+		return
+	}
+
+	s.pushLikeSyncs(ctx, saves, func(trackIDs []string) error {
+		return s.spotifyOutbound.SaveTracks(ctx, accessToken, trackIDs)
+	})
+
+	removes, err = s.resolveRemoteLikes(ctx, userID, accessToken, removes)
+	if err != nil {
+		s.failLikeSyncs(ctx, removes, err)
+		return
+	}
+	s.pushLikeSyncs(ctx, removes, func(trackIDs []string) error {
+		return s.spotifyOutbound.RemoveSavedTracks(ctx, accessToken, trackIDs)
+	})
+}
+
+// resolveRemoteLikes takes over likes made on spotify after an unlike here
+// and returns the remaining unlikes.
+func (s *service) resolveRemoteLikes(ctx context.Context, userID uint, accessToken string, removes []trackactivities.LikeSync) ([]trackactivities.LikeSync, error) {
+	if len(removes) == 0 {
+		return removes, nil
+	}
+
+	since := removes[0].ChangedAt
+	for _, likeSync := range removes {
+		if likeSync.ChangedAt.Before(since) {
+			since = likeSync.ChangedAt
+		}
+	}
+	addedAt, err := s.recentSavedTracks(ctx, accessToken, since)
+	if err != nil {
+		return removes, err
+	}
+
+	remaining := make([]trackactivities.LikeSync, 0, len(removes))
+	conflicts := make([]trackactivities.LikeSync, 0)
+	activities := make([]trackactivities.TrackActivity, 0)
+	for _, likeSync := range removes {
+		likedAt, ok := addedAt[likeSync.SpotifyID]
+		if !ok || !likedAt.After(likeSync.ChangedAt) {
+			remaining = append(remaining, likeSync)
+			continue
+		}
+		conflicts = append(conflicts, likeSync)
+		activities = append(activities, likedActivity(userID, spotify.SpotifySavedTrack{
+			AddedAt: likedAt,
+			Track:   spotify.SpotifyTrackObject{ID: likeSync.SpotifyID},
+		}))
+	}
+	if len(conflicts) == 0 {
+		return remaining, nil
+	}
+
+	_, err = s.trackActivitiesRepo.SaveRemoteLikes(ctx, activities)
+	if err != nil {
+		log.Error().Err(err).Msg("error save remote likes to database")
+		s.failLikeSyncs(ctx, conflicts, err)
+		return remaining, nil
+	}
+	for _, likeSync := range conflicts {
+		s.finishLikeSync(ctx, likeSync, trackactivities.LikeSyncStatusSkipped, "liked again on spotify later")
+	}
+	return remaining, nil
+}
+
+// recentSavedTracks returns when the tracks liked on spotify after since were liked.
+func (s *service) recentSavedTracks(ctx context.Context, accessToken string, since time.Time) (map[string]time.Time, error) {
+	addedAt := make(map[string]time.Time)
+	for page := 0; page < likeSyncLookbackPages; page++ {
+		response, err := s.spotifyOutbound.GetSavedTracks(ctx, accessToken, likeSyncBatchSize, page*likeSyncBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range response.Items {
+			if !item.AddedAt.After(since) {
+				return addedAt, nil
+			}
+			addedAt[item.Track.ID] = item.AddedAt
+		}
+		if response.Next == nil {
+			break
+		}
+	}
+	return addedAt, nil
+}
+
+func (s *service) pushLikeSyncs(ctx context.Context, syncs []trackactivities.LikeSync, push func(trackIDs []string) error) {
+	if len(syncs) == 0 {
+		return
+	}
+	trackIDs := make([]string, len(syncs))
+	for idx, likeSync := range syncs {
+		trackIDs[idx] = likeSync.SpotifyID
+	}
+	if err := push(trackIDs); err != nil {
+		s.failLikeSyncs(ctx, syncs, err)
+		return
+	}
+	for _, likeSync := range syncs {
+		s.finishLikeSync(ctx, likeSync, trackactivities.LikeSyncStatusSynced, "")
+	}
+}
+
+// failLikeSyncs retries the changes later, or moves them to the dead letter.
+func (s *service) failLikeSyncs(ctx context.Context, syncs []trackactivities.LikeSync, syncErr error) {
+	if ctx.Err() != nil {
+		// shutting down, the changes are due again once their lease runs out
+		return
+	}
+	permanent := errors.Is(syncErr, spotify.ErrBadRequest) || errors.Is(syncErr, spotify.ErrNotFound)
+	now := time.Now()
+	for _, likeSync := range syncs {
+		if permanent || likeSync.Attempts >= likeSyncMaxAttempts {
+			log.Error().Err(syncErr).Uint("likeSyncID", likeSync.ID).Int("attempts", likeSync.Attempts).Msg("like sync moved to dead letter")
+			s.finishLikeSync(ctx, likeSync, trackactivities.LikeSyncStatusDead, syncErr.Error())
+			continue
+		}
+
+		log.Warn().Err(syncErr).Uint("likeSyncID", likeSync.ID).Int("attempts", likeSync.Attempts).Msg("like sync failed, will be retried")
+		err := s.trackActivitiesRepo.RetryLikeSync(ctx, likeSync, now.Add(likeSyncBackoff(likeSync.Attempts, syncErr)), syncErr.Error())
+		if err != nil && !errors.Is(err, trackactivitiesRepo.ErrLikeSyncClaimLost) {
+			log.Error().Err(err).Uint("likeSyncID", likeSync.ID).Msg("error retry like sync in database")
+		}
+	}
+}
+
+// likeSyncBackoff doubles the wait with every attempt, at least Retry-After.
+func likeSyncBackoff(attempts int, err error) time.Duration {
+	delay := likeSyncBaseBackoff
+	for n := 1; n < attempts && delay < likeSyncMaxBackoff; n++ {
+		delay *= 2
+	}
+	delay = min(delay, likeSyncMaxBackoff)
+	var apiErr *spotify.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+	return delay
+}
+
+func (s *service) finishLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, status, errMessage string) {
+	err := s.trackActivitiesRepo.FinishLikeSync(ctx, likeSync, status, errMessage)
+	switch {
+	case err == nil:
+	case errors.Is(err, trackactivitiesRepo.ErrLikeSyncClaimLost):
+		log.Warn().Uint("likeSyncID", likeSync.ID).Msg("like sync was taken over by another worker")
+	default:
+		log.Error().Err(err).Uint("likeSyncID", likeSync.ID).Msg("error finish like sync in database")
+	}
+}
@@ -0,0 +1,194 @@
+package tracks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+func Test_service_runNextLikeSync(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	mockTokens := NewMockspotifyTokens(mockCtrl)
+
+	isLikedTrue := true
+	changedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	save := trackactivities.LikeSync{Model: gorm.Model{ID: 1}, UserID: 1, SpotifyID: "track1", Liked: true, ChangedAt: changedAt, Attempts: 1}
+	remove := trackactivities.LikeSync{Model: gorm.Model{ID: 2}, UserID: 1, SpotifyID: "track2", Liked: false, ChangedAt: changedAt, Attempts: 1}
+	liked := map[string]trackactivities.TrackActivity{
+		"track1": {SpotifyID: "track1", IsLiked: &isLikedTrue},
+	}
+
+	tests := []struct {
+		name   string
+		want   bool
+		mockFn func()
+	}{
+		{
+			name: "success: nothing to do",
+			want: false,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{}, nil)
+			},
+		},
+		{
+			name: "success: pushes likes and unlikes",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{save, remove}, nil)
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track1", "track2"}).Return(liked, nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().SaveTracks(gomock.Any(), "access", []string{"track1"}).Return(nil)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), save, trackactivities.LikeSyncStatusSynced, "").Return(nil)
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", 50, 0).Return(&spotifyRepo.SpotifySavedTracksResponse{
+					Items: []spotifyRepo.SpotifySavedTrack{
+						{AddedAt: changedAt.Add(time.Minute), Track: spotifyRepo.SpotifyTrackObject{ID: "track1"}},
+						{AddedAt: changedAt.Add(-time.Hour), Track: spotifyRepo.SpotifyTrackObject{ID: "track2"}},
+					},
+				}, nil)
+				mockSpotifyOutbound.EXPECT().RemoveSavedTracks(gomock.Any(), "access", []string{"track2"}).Return(nil)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), remove, trackactivities.LikeSyncStatusSynced, "").Return(nil)
+			},
+		},
+		{
+			name: "success: skips changes replaced by a later one",
+			want: true,
+			mockFn: func() {
+				later := trackactivities.LikeSync{Model: gorm.Model{ID: 3}, UserID: 1, SpotifyID: "track2", Liked: true, ChangedAt: changedAt.Add(time.Second), Attempts: 1}
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{save, remove, later}, nil)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), remove, trackactivities.LikeSyncStatusSkipped, "replaced by a later change").Return(nil)
+				// track1 was unliked again since, its own change comes later
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track1", "track2"}).Return(map[string]trackactivities.TrackActivity{
+					"track2": {SpotifyID: "track2", IsLiked: &isLikedTrue},
+				}, nil)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), save, trackactivities.LikeSyncStatusSkipped, "replaced by a later change").Return(nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().SaveTracks(gomock.Any(), "access", []string{"track2"}).Return(nil)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), later, trackactivities.LikeSyncStatusSynced, "").Return(nil)
+			},
+		},
+		{
+			name: "success: a like on spotify after the unlike here wins",
+			want: true,
+			mockFn: func() {
+				likedAt := changedAt.Add(time.Hour)
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{remove}, nil)
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track2"}).Return(map[string]trackactivities.TrackActivity{}, nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().GetSavedTracks(gomock.Any(), "access", 50, 0).Return(&spotifyRepo.SpotifySavedTracksResponse{
+					Items: []spotifyRepo.SpotifySavedTrack{
+						{AddedAt: likedAt, Track: spotifyRepo.SpotifyTrackObject{ID: "track2"}},
+					},
+				}, nil)
+				mockTrackActivityRepo.EXPECT().SaveRemoteLikes(gomock.Any(), []trackactivities.TrackActivity{
+					likedActivity(1, spotifyRepo.SpotifySavedTrack{AddedAt: likedAt, Track: spotifyRepo.SpotifyTrackObject{ID: "track2"}}),
+				}).Return(1, nil)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), remove, trackactivities.LikeSyncStatusSkipped, "liked again on spotify later").Return(nil)
+			},
+		},
+		{
+			name: "success: skips users without a linked account",
+			want: true,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{save}, nil)
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track1"}).Return(liked, nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("", spotifyRepo.ErrNotLinked)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), save, trackactivities.LikeSyncStatusSkipped, spotifyRepo.ErrNotLinked.Error()).Return(nil)
+			},
+		},
+		{
+			name: "failed: rate limited, retried later",
+			want: true,
+			mockFn: func() {
+				rateLimited := &spotifyRepo.APIError{StatusCode: 429, Message: "API rate limit exceeded", RetryAfter: 10 * time.Minute}
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{save}, nil)
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track1"}).Return(liked, nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().SaveTracks(gomock.Any(), "access", []string{"track1"}).Return(rateLimited)
+				mockTrackActivityRepo.EXPECT().RetryLikeSync(gomock.Any(), save, gomock.Cond(func(x any) bool {
+					return time.Until(x.(time.Time)) > 9*time.Minute
+				}), rateLimited.Error()).Return(nil)
+			},
+		},
+		{
+			name: "failed: rejected by spotify, moved to dead letter",
+			want: true,
+			mockFn: func() {
+				badRequest := &spotifyRepo.APIError{StatusCode: 400, Message: "Invalid ids"}
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{save}, nil)
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track1"}).Return(liked, nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().SaveTracks(gomock.Any(), "access", []string{"track1"}).Return(badRequest)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), save, trackactivities.LikeSyncStatusDead, badRequest.Error()).Return(nil)
+			},
+		},
+		{
+			name: "failed: out of attempts, moved to dead letter",
+			want: true,
+			mockFn: func() {
+				lastTry := save
+				lastTry.Attempts = likeSyncMaxAttempts
+				mockTrackActivityRepo.EXPECT().ClaimLikeSyncs(gomock.Any(), gomock.Any(), gomock.Any(), 50).Return([]trackactivities.LikeSync{lastTry}, nil)
+				mockTrackActivityRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"track1"}).Return(liked, nil)
+				mockTokens.EXPECT().SpotifyAccessToken(gomock.Any(), uint(1)).Return("access", nil)
+				mockSpotifyOutbound.EXPECT().SaveTracks(gomock.Any(), "access", []string{"track1"}).Return(spotifyRepo.ErrUnavailable)
+				mockTrackActivityRepo.EXPECT().FinishLikeSync(gomock.Any(), lastTry, trackactivities.LikeSyncStatusDead, spotifyRepo.ErrUnavailable.Error()).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivityRepo,
+				spotifyTokens:       mockTokens,
+			}
+			got := s.runNextLikeSync(context.Background())
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_service_pruneLikeSyncs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	now := time.Date(2026, 10, 8, 12, 0, 0, 0, time.UTC)
+
+	mockTrackActivityRepo.EXPECT().PruneLikeSyncs(gomock.Any(), time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)).Return(int64(3), nil)
+
+	s := &service{
+		trackActivitiesRepo: mockTrackActivityRepo,
+	}
+	s.pruneLikeSyncs(context.Background(), now)
+}
+
+func Test_likeSyncBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		err      error
+		want     time.Duration
+	}{
+		{name: "first retry", attempts: 1, err: spotifyRepo.ErrUnavailable, want: 30 * time.Second},
+		{name: "doubles", attempts: 3, err: spotifyRepo.ErrUnavailable, want: 2 * time.Minute},
+		{name: "capped", attempts: 20, err: spotifyRepo.ErrUnavailable, want: time.Hour},
+		{name: "retry after is longer", attempts: 1, err: &spotifyRepo.APIError{StatusCode: 429, RetryAfter: 5 * time.Minute}, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, likeSyncBackoff(tt.attempts, tt.err))
+		})
+	}
+}
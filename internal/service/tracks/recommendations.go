@@ -2,6 +2,7 @@ package tracks
 
 import (
 	"context"
+	"errors"
 
 	"github.com/rs/zerolog/log"
 	"github.com/xprasetio/go-spotify/internal/models/spotify"
@@ -9,62 +10,120 @@ import (
 	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
 )
 
-func (s *service) GetRecommendation(ctx context.Context, userID uint, limit int, trackID string) (*spotify.RecommendationResponse, error) {
-	trackDetails, err := s.spotifyOutbound.GetRecommendation(ctx, limit, trackID)
+const (
+	// personalizedSeeds is how many recent likes seed a personalized request,
+	// spotify takes at most five seeds.
+	personalizedSeeds = 5
+	// maxRecommendationLimit is spotify's cap on recommendations per request.
+	maxRecommendationLimit = 100
+	overFetchFactor        = 2
+	// maxRecommendationRounds bounds the spotify calls of one personalized
+	// request.
+	maxRecommendationRounds = 5
+)
+
+var ErrNoRecommendationSeeds = errors.New("no liked tracks to seed recommendations, like a track or pass a seed")
+
+// GetRecommendation returns recommendations for the given seeds. Without any
+// seed it runs in personalized mode, see personalizedRecommendation. The
+// limit is expected to be between 1 and maxRecommendationLimit.
+func (s *service) GetRecommendation(ctx context.Context, userID uint, request spotify.RecommendationRequest) (*spotify.RecommendationResponse, error) {
+	params := spotifyRepo.RecommendationParams{
+		Limit:       request.Limit,
+		Market:      request.Market,
+		SeedTracks:  request.SeedTracks,
+		SeedArtists: request.SeedArtists,
+		SeedGenres:  request.SeedGenres,
+		Tunables:    request.Tunables,
+	}
+
+	personalized := len(request.SeedTracks) == 0 && len(request.SeedArtists) == 0 && len(request.SeedGenres) == 0
+	if personalized {
+		return s.personalizedRecommendation(ctx, userID, request.Limit, params)
+	}
+
+	trackDetails, err := s.spotifyOutbound.GetRecommendation(ctx, params)
 	if err != nil {
 		log.Error().Err(err).Msg("error get recommendation from spotify outbound")
 		return nil, err
 	}
 
-	trackIDs := make([]string, len(trackDetails.Tracks))
-	for idx, item := range trackDetails.Tracks {
-		trackIDs[idx] = item.ID
-	}
-
-	trackActivities, err := s.trackActivitiesRepo.GetBulkSpotifyIDs(ctx, userID, trackIDs)
+	trackActivities, err := s.getTrackActivities(ctx, userID, trackDetails.Tracks)
 	if err != nil {
-		log.Error().Err(err).Msg("error get track activities from database")
 		return nil, err
 	}
 
-	return modelToRecommendationResponse(trackDetails, trackActivities), nil
+	return &spotify.RecommendationResponse{
+		Items: tracksToResponse(trackDetails.Tracks, trackActivities),
+	}, nil
 }
 
-func modelToRecommendationResponse(data *spotifyRepo.SpotifyRecommendationResponse, mapTrackActivities map[string]trackactivities.TrackActivity) *spotify.RecommendationResponse {
-	if data == nil {
-		return nil
-	}
+// personalizedRecommendation seeds spotify with the user's most recent likes
+// and leaves out tracks the user disliked. While that leaves fewer than
+// limit tracks it asks again seeded with older likes, until the likes run
+// out or spotify has nothing new.
+func (s *service) personalizedRecommendation(ctx context.Context, userID uint, limit int, params spotifyRepo.RecommendationParams) (*spotify.RecommendationResponse, error) {
+	// dislikes are dropped after the fact, ask for more to still fill limit
+	params.Limit = min(limit*overFetchFactor, maxRecommendationLimit)
 
-	items := make([]spotify.SpotifyTrackObject, 0)
+	items := make([]spotify.SpotifyTrackObject, 0, limit)
+	seen := make(map[string]bool)
+	listParams := trackactivities.ListParams{IsLiked: true, Limit: personalizedSeeds}
+	for round := 0; round < maxRecommendationRounds && len(items) < limit; round++ {
+		liked, err := s.trackActivitiesRepo.List(ctx, userID, listParams)
+		if err != nil {
+			log.Error().Err(err).Msg("error get recent liked tracks from database")
+			return nil, err
+		}
+		if len(liked) == 0 {
+			if round == 0 {
+				return nil, ErrNoRecommendationSeeds
+			}
+			break
+		}
+		params.SeedTracks = make([]string, len(liked))
+		for idx, activity := range liked {
+			params.SeedTracks[idx] = activity.SpotifyID
+		}
 
-	for _, item := range data.Tracks {
-		artistsName := make([]string, len(item.Artists))
-		for idx, artist := range item.Artists {
-			artistsName[idx] = artist.Name
+		trackDetails, err := s.spotifyOutbound.GetRecommendation(ctx, params)
+		if err != nil {
+			log.Error().Err(err).Msg("error get recommendation from spotify outbound")
+			return nil, err
+		}
+		tracks := make([]spotifyRepo.SpotifyTrackObject, 0, len(trackDetails.Tracks))
+		for _, track := range trackDetails.Tracks {
+			if !seen[track.ID] {
+				seen[track.ID] = true
+				tracks = append(tracks, track)
+			}
+		}
+		if len(tracks) == 0 {
+			break
 		}
 
-		imageUrls := make([]string, len(item.Album.Images))
-		for idx, image := range item.Album.Images {
-			imageUrls[idx] = image.URL
+		trackActivities, err := s.getTrackActivities(ctx, userID, tracks)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range tracksToResponse(tracks, trackActivities) {
+			if len(items) == limit {
+				break
+			}
+			if item.IsLiked != nil && !*item.IsLiked {
+				continue
+			}
+			items = append(items, item)
 		}
 
-		items = append(items, spotify.SpotifyTrackObject{
-			// album related fields
-			AlbumType:        item.Album.AlbumType,
-			AlbumTotalTracks: item.Album.TotalTracks,
-			AlbumImagesURL:   imageUrls,
-			AlbumName:        item.Album.Name,
-			// artist related fields
-			ArtistsName: artistsName,
-			// track related fields
-			Explicit: item.Explicit,
-			ID:       item.ID,
-			Name:     item.Name,
-			IsLiked:  mapTrackActivities[item.ID].IsLiked,
-		})
+		if len(liked) < personalizedSeeds {
+			break
+		}
+		last := liked[len(liked)-1]
+		listParams.After = &trackactivities.Cursor{UpdatedAt: last.UpdatedAt, ID: last.ID}
 	}
 
 	return &spotify.RecommendationResponse{
 		Items: items,
-	}
+	}, nil
 }
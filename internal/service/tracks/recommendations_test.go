@@ -2,14 +2,17 @@ package tracks
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/xprasetio/go-spotify/internal/models/spotify"
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
 	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
 )
 
 func Test_service_GetRecommendation(t *testing.T) {
@@ -48,10 +51,18 @@ func Test_service_GetRecommendation(t *testing.T) {
 						AlbumTotalTracks: 22,
 						AlbumImagesURL:   []string{"https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b", "https://i.scdn.co/image/ab67616d00001e02e8b066f70c206551210d902b", "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b"},
 						AlbumName:        "Bohemian Rhapsody (The Original Soundtrack)",
+						AlbumID:          "6i6folBtxKV28WX3msQ4FE",
+						AlbumReleaseDate: "2018-10-19",
 						ArtistsName:      []string{"Queen"},
+						ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
 						Explicit:         false,
 						ID:               "3z8h0TU7ReDPLIbEnYhWZb",
 						Name:             "Bohemian Rhapsody",
+						DurationMs:       354947,
+						Popularity:       72,
+						ISRC:             "GBUM71029604",
+						TrackNumber:      7,
+						DiscNumber:       1,
 						IsLiked:          &isLikedTrue,
 					},
 					{
@@ -59,22 +70,31 @@ func Test_service_GetRecommendation(t *testing.T) {
 						AlbumTotalTracks: 12,
 						AlbumImagesURL:   []string{"https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0", "https://i.scdn.co/image/ab67616d00001e02e319baafd16e84f0408af2a0", "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0"},
 						AlbumName:        "A Night At The Opera (2011 Remaster)",
+						AlbumID:          "1GbtB4zTqAsyfZEsm1RZfx",
+						AlbumReleaseDate: "1975-11-21",
 						ArtistsName:      []string{"Queen"},
+						ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
 						Explicit:         false,
 						ID:               "4u7EnebtmKWzUH433cf5Qv",
 						Name:             "Bohemian Rhapsody - Remastered 2011",
+						DurationMs:       354320,
+						Popularity:       80,
+						ISRC:             "GBUM71029604",
+						TrackNumber:      11,
+						DiscNumber:       1,
 						IsLiked:          &isLikedFalse,
 					},
 				},
 			},
 			wantErr: false,
 			mockFn: func(args args) {
-				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), 10, "trackID").Return(&spotifyRepo.SpotifyRecommendationResponse{
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), spotifyRepo.RecommendationParams{Limit: 10, SeedTracks: []string{"trackID"}}).Return(&spotifyRepo.SpotifyRecommendationResponse{
 					Tracks: []spotifyRepo.SpotifyTrackObject{
 						{
 							Album: spotifyRepo.SpotifyAlbumObject{
 								AlbumType:   "album",
 								TotalTracks: 22,
+								ID:          "6i6folBtxKV28WX3msQ4FE",
 								Images: []spotifyRepo.SpotifyAlbumImage{
 									{
 										URL: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b",
@@ -86,23 +106,31 @@ func Test_service_GetRecommendation(t *testing.T) {
 										URL: "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b",
 									},
 								},
-								Name: "Bohemian Rhapsody (The Original Soundtrack)",
+								Name:        "Bohemian Rhapsody (The Original Soundtrack)",
+								ReleaseDate: "2018-10-19",
 							},
 							Artists: []spotifyRepo.SpotifyArtistObject{
 								{
 									Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+									ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 									Name: "Queen",
 								},
 							},
-							Explicit: false,
-							Href:     "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
-							ID:       "3z8h0TU7ReDPLIbEnYhWZb",
-							Name:     "Bohemian Rhapsody",
+							DiscNumber:  1,
+							DurationMs:  354947,
+							Explicit:    false,
+							ExternalIDs: spotifyRepo.SpotifyExternalIDs{ISRC: "GBUM71029604"},
+							Href:        "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
+							ID:          "3z8h0TU7ReDPLIbEnYhWZb",
+							Name:        "Bohemian Rhapsody",
+							Popularity:  72,
+							TrackNumber: 7,
 						},
 						{
 							Album: spotifyRepo.SpotifyAlbumObject{
 								AlbumType:   "album",
 								TotalTracks: 12,
+								ID:          "1GbtB4zTqAsyfZEsm1RZfx",
 								Images: []spotifyRepo.SpotifyAlbumImage{
 									{
 										URL: "https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0",
@@ -114,18 +142,25 @@ func Test_service_GetRecommendation(t *testing.T) {
 										URL: "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0",
 									},
 								},
-								Name: "A Night At The Opera (2011 Remaster)",
+								Name:        "A Night At The Opera (2011 Remaster)",
+								ReleaseDate: "1975-11-21",
 							},
 							Artists: []spotifyRepo.SpotifyArtistObject{
 								{
 									Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+									ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 									Name: "Queen",
 								},
 							},
-							Explicit: false,
-							Href:     "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
-							ID:       "4u7EnebtmKWzUH433cf5Qv",
-							Name:     "Bohemian Rhapsody - Remastered 2011",
+							DiscNumber:  1,
+							DurationMs:  354320,
+							Explicit:    false,
+							ExternalIDs: spotifyRepo.SpotifyExternalIDs{ISRC: "GBUM71029604"},
+							Href:        "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
+							ID:          "4u7EnebtmKWzUH433cf5Qv",
+							Name:        "Bohemian Rhapsody - Remastered 2011",
+							Popularity:  80,
+							TrackNumber: 11,
 						},
 					},
 				}, nil)
@@ -152,12 +187,13 @@ func Test_service_GetRecommendation(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			mockFn: func(args args) {
-				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), 10, "trackID").Return(&spotifyRepo.SpotifyRecommendationResponse{
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), spotifyRepo.RecommendationParams{Limit: 10, SeedTracks: []string{"trackID"}}).Return(&spotifyRepo.SpotifyRecommendationResponse{
 					Tracks: []spotifyRepo.SpotifyTrackObject{
 						{
 							Album: spotifyRepo.SpotifyAlbumObject{
 								AlbumType:   "album",
 								TotalTracks: 22,
+								ID:          "6i6folBtxKV28WX3msQ4FE",
 								Images: []spotifyRepo.SpotifyAlbumImage{
 									{
 										URL: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b",
@@ -169,23 +205,31 @@ func Test_service_GetRecommendation(t *testing.T) {
 										URL: "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b",
 									},
 								},
-								Name: "Bohemian Rhapsody (The Original Soundtrack)",
+								Name:        "Bohemian Rhapsody (The Original Soundtrack)",
+								ReleaseDate: "2018-10-19",
 							},
 							Artists: []spotifyRepo.SpotifyArtistObject{
 								{
 									Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+									ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 									Name: "Queen",
 								},
 							},
-							Explicit: false,
-							Href:     "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
-							ID:       "3z8h0TU7ReDPLIbEnYhWZb",
-							Name:     "Bohemian Rhapsody",
+							DiscNumber:  1,
+							DurationMs:  354947,
+							Explicit:    false,
+							ExternalIDs: spotifyRepo.SpotifyExternalIDs{ISRC: "GBUM71029604"},
+							Href:        "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
+							ID:          "3z8h0TU7ReDPLIbEnYhWZb",
+							Name:        "Bohemian Rhapsody",
+							Popularity:  72,
+							TrackNumber: 7,
 						},
 						{
 							Album: spotifyRepo.SpotifyAlbumObject{
 								AlbumType:   "album",
 								TotalTracks: 12,
+								ID:          "1GbtB4zTqAsyfZEsm1RZfx",
 								Images: []spotifyRepo.SpotifyAlbumImage{
 									{
 										URL: "https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0",
@@ -197,18 +241,25 @@ func Test_service_GetRecommendation(t *testing.T) {
 										URL: "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0",
 									},
 								},
-								Name: "A Night At The Opera (2011 Remaster)",
+								Name:        "A Night At The Opera (2011 Remaster)",
+								ReleaseDate: "1975-11-21",
 							},
 							Artists: []spotifyRepo.SpotifyArtistObject{
 								{
 									Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+									ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 									Name: "Queen",
 								},
 							},
-							Explicit: false,
-							Href:     "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
-							ID:       "4u7EnebtmKWzUH433cf5Qv",
-							Name:     "Bohemian Rhapsody - Remastered 2011",
+							DiscNumber:  1,
+							DurationMs:  354320,
+							Explicit:    false,
+							ExternalIDs: spotifyRepo.SpotifyExternalIDs{ISRC: "GBUM71029604"},
+							Href:        "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
+							ID:          "4u7EnebtmKWzUH433cf5Qv",
+							Name:        "Bohemian Rhapsody - Remastered 2011",
+							Popularity:  80,
+							TrackNumber: 11,
 						},
 					},
 				}, nil)
@@ -227,7 +278,7 @@ func Test_service_GetRecommendation(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			mockFn: func(args args) {
-				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), 10, "trackID").Return(nil, assert.AnError)
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), spotifyRepo.RecommendationParams{Limit: 10, SeedTracks: []string{"trackID"}}).Return(nil, assert.AnError)
 			},
 		},
 	}
@@ -238,7 +289,10 @@ func Test_service_GetRecommendation(t *testing.T) {
 				spotifyOutbound:     mockSpotifyOutbound,
 				trackActivitiesRepo: mockTrackActivitiesRepo,
 			}
-			got, err := s.GetRecommendation(context.Background(), tt.args.userID, tt.args.limit, tt.args.trackID)
+			got, err := s.GetRecommendation(context.Background(), tt.args.userID, spotify.RecommendationRequest{
+				Limit:      tt.args.limit,
+				SeedTracks: []string{tt.args.trackID},
+			})
 			if (err != nil) != tt.wantErr {
 				t.Errorf("service.GetRecommendation() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -249,3 +303,172 @@ func Test_service_GetRecommendation(t *testing.T) {
 		})
 	}
 }
+
+func Test_service_GetRecommendation_personalized(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivitiesRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	isLikedTrue := true
+	isLikedFalse := false
+	updatedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	newTrack := func(id string) spotifyRepo.SpotifyTrackObject {
+		return spotifyRepo.SpotifyTrackObject{
+			Artists: []spotifyRepo.SpotifyArtistObject{{ID: "1dfeR4HaWDbWqFHLkxsg1d", Name: "Queen"}},
+			ID:      id,
+			Name:    id,
+		}
+	}
+	newItem := func(id string, isLiked *bool) spotify.SpotifyTrackObject {
+		return spotify.SpotifyTrackObject{
+			AlbumImagesURL: []string{},
+			ArtistsName:    []string{"Queen"},
+			ArtistsID:      []string{"1dfeR4HaWDbWqFHLkxsg1d"},
+			ID:             id,
+			Name:           id,
+			IsLiked:        isLiked,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		limit   int
+		want    *spotify.RecommendationResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:  "success: seeds from likes, dislikes filtered",
+			limit: 2,
+			want: &spotify.RecommendationResponse{
+				Items: []spotify.SpotifyTrackObject{
+					newItem("trackB", nil),
+					newItem("trackD", &isLikedTrue),
+				},
+			},
+			mockFn: func() {
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 5}).Return([]trackactivities.TrackActivity{
+					{SpotifyID: "likedA", IsLiked: &isLikedTrue},
+					{SpotifyID: "likedB", IsLiked: &isLikedTrue},
+				}, nil)
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), spotifyRepo.RecommendationParams{
+					Limit:      4,
+					SeedTracks: []string{"likedA", "likedB"},
+				}).Return(&spotifyRepo.SpotifyRecommendationResponse{
+					Tracks: []spotifyRepo.SpotifyTrackObject{newTrack("trackA"), newTrack("trackB"), newTrack("trackC"), newTrack("trackD")},
+				}, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"trackA", "trackB", "trackC", "trackD"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"trackA": {IsLiked: &isLikedFalse},
+						"trackC": {IsLiked: &isLikedFalse},
+						"trackD": {IsLiked: &isLikedTrue},
+					}, nil)
+			},
+		},
+		{
+			name:  "success: seeds from older likes when dislikes leave too few",
+			limit: 2,
+			want: &spotify.RecommendationResponse{
+				Items: []spotify.SpotifyTrackObject{
+					newItem("trackD", nil),
+					newItem("trackF", nil),
+				},
+			},
+			mockFn: func() {
+				recent := make([]trackactivities.TrackActivity, personalizedSeeds)
+				for idx := range recent {
+					recent[idx] = trackactivities.TrackActivity{Model: gorm.Model{ID: uint(10 - idx), UpdatedAt: updatedAt}, SpotifyID: fmt.Sprintf("liked%d", idx)}
+				}
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 5}).Return(recent, nil)
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), spotifyRepo.RecommendationParams{
+					Limit:      4,
+					SeedTracks: []string{"liked0", "liked1", "liked2", "liked3", "liked4"},
+				}).Return(&spotifyRepo.SpotifyRecommendationResponse{
+					Tracks: []spotifyRepo.SpotifyTrackObject{newTrack("trackA"), newTrack("trackB"), newTrack("trackC"), newTrack("trackD")},
+				}, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"trackA", "trackB", "trackC", "trackD"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"trackA": {IsLiked: &isLikedFalse},
+						"trackB": {IsLiked: &isLikedFalse},
+						"trackC": {IsLiked: &isLikedFalse},
+					}, nil)
+
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{
+					IsLiked: true,
+					Limit:   5,
+					After:   &trackactivities.Cursor{UpdatedAt: updatedAt, ID: 6},
+				}).Return([]trackactivities.TrackActivity{{Model: gorm.Model{ID: 5}, SpotifyID: "liked5"}}, nil)
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), spotifyRepo.RecommendationParams{
+					Limit:      4,
+					SeedTracks: []string{"liked5"},
+				}).Return(&spotifyRepo.SpotifyRecommendationResponse{
+					Tracks: []spotifyRepo.SpotifyTrackObject{newTrack("trackA"), newTrack("trackE"), newTrack("trackF")},
+				}, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"trackE", "trackF"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"trackE": {IsLiked: &isLikedFalse},
+					}, nil)
+			},
+		},
+		{
+			name:  "success: short when spotify has nothing new",
+			limit: 2,
+			want: &spotify.RecommendationResponse{
+				Items: []spotify.SpotifyTrackObject{},
+			},
+			mockFn: func() {
+				recent := make([]trackactivities.TrackActivity, personalizedSeeds)
+				for idx := range recent {
+					recent[idx] = trackactivities.TrackActivity{Model: gorm.Model{ID: uint(10 - idx), UpdatedAt: updatedAt}, SpotifyID: fmt.Sprintf("liked%d", idx)}
+				}
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 5}).Return(recent, nil)
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), gomock.Any()).Return(&spotifyRepo.SpotifyRecommendationResponse{
+					Tracks: []spotifyRepo.SpotifyTrackObject{newTrack("trackA")},
+				}, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), uint(1), []string{"trackA"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"trackA": {IsLiked: &isLikedFalse},
+					}, nil)
+
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), gomock.Any()).Return(recent, nil)
+				mockSpotifyOutbound.EXPECT().GetRecommendation(gomock.Any(), gomock.Any()).Return(&spotifyRepo.SpotifyRecommendationResponse{
+					Tracks: []spotifyRepo.SpotifyTrackObject{newTrack("trackA")},
+				}, nil)
+			},
+		},
+		{
+			name:    "failed: no likes to seed from",
+			limit:   10,
+			wantErr: ErrNoRecommendationSeeds,
+			mockFn: func() {
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 5}).Return([]trackactivities.TrackActivity{}, nil)
+			},
+		},
+		{
+			name:    "failed: when get recent liked",
+			limit:   10,
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockTrackActivitiesRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 5}).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivitiesRepo,
+			}
+			got, err := s.GetRecommendation(context.Background(), 1, spotify.RecommendationRequest{Limit: tt.limit})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -5,72 +5,27 @@ import (
 
 	"github.com/rs/zerolog/log"
 	"github.com/xprasetio/go-spotify/internal/models/spotify"
-	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
-	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
 )
 
-func (s *service) Search(ctx context.Context, query string, pageSize, pageIndex int, userID uint) (*spotify.SearchResponse, error) {
+func (s *service) Search(ctx context.Context, query string, pageSize, pageIndex int, market string, userID uint) (*spotify.SearchResponse, error) {
 	limit := pageSize
 	offset := (pageIndex - 1) * pageSize
 
-	trackDetails, err := s.spotifyOutbound.Search(ctx, query, limit, offset)
+	trackDetails, err := s.spotifyOutbound.Search(ctx, query, limit, offset, market)
 	if err != nil {
 		log.Error().Err(err).Msg("error search track to spotify")
 		return nil, err
 	}
 
-	trackIDs := make([]string, len(trackDetails.Tracks.Items))
-	for idx, item := range trackDetails.Tracks.Items {
-		trackIDs[idx] = item.ID
-	}
-
-	trackActivities, err := s.trackActivitiesRepo.GetBulkSpotifyIDs(ctx, userID, trackIDs)
+	trackActivities, err := s.getTrackActivities(ctx, userID, trackDetails.Tracks.Items)
 	if err != nil {
-		log.Error().Err(err).Msg("error get track activities from database")
 		return nil, err
 	}
 
-	return modelToResponse(trackDetails, trackActivities), nil
-}
-
-func modelToResponse(data *spotifyRepo.SpotifySearchResponse, mapTrackActivities map[string]trackactivities.TrackActivity) *spotify.SearchResponse {
-	if data == nil {
-		return nil
-	}
-
-	items := make([]spotify.SpotifyTrackObject, 0)
-
-	for _, item := range data.Tracks.Items {
-		artistsName := make([]string, len(item.Artists))
-		for idx, artist := range item.Artists {
-			artistsName[idx] = artist.Name
-		}
-
-		imageUrls := make([]string, len(item.Album.Images))
-		for idx, image := range item.Album.Images {
-			imageUrls[idx] = image.URL
-		}
-
-		items = append(items, spotify.SpotifyTrackObject{
-			// album related fields
-			AlbumType:        item.Album.AlbumType,
-			AlbumTotalTracks: item.Album.TotalTracks,
-			AlbumImagesURL:   imageUrls,
-			AlbumName:        item.Album.Name,
-			// artist related fields
-			ArtistsName: artistsName,
-			// track related fields
-			Explicit: item.Explicit,
-			ID:       item.ID,
-			Name:     item.Name,
-			IsLiked:  mapTrackActivities[item.ID].IsLiked,
-		})
-	}
-
 	return &spotify.SearchResponse{
-		Limit:  data.Tracks.Limit,
-		Offset: data.Tracks.Offset,
-		Items:  items,
-		Total:  data.Tracks.Total,
-	}
+		Limit:  trackDetails.Tracks.Limit,
+		Offset: trackDetails.Tracks.Offset,
+		Items:  tracksToResponse(trackDetails.Tracks.Items, trackActivities),
+		Total:  trackDetails.Tracks.Total,
+	}, nil
 }
@@ -50,10 +50,18 @@ func Test_service_Search(t *testing.T) {
 						AlbumTotalTracks: 22,
 						AlbumImagesURL:   []string{"https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b", "https://i.scdn.co/image/ab67616d00001e02e8b066f70c206551210d902b", "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b"},
 						AlbumName:        "Bohemian Rhapsody (The Original Soundtrack)",
+						AlbumID:          "6i6folBtxKV28WX3msQ4FE",
+						AlbumReleaseDate: "2018-10-19",
 						ArtistsName:      []string{"Queen"},
+						ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
 						Explicit:         false,
 						ID:               "3z8h0TU7ReDPLIbEnYhWZb",
 						Name:             "Bohemian Rhapsody",
+						DurationMs:       354947,
+						Popularity:       72,
+						ISRC:             "GBUM71029604",
+						TrackNumber:      7,
+						DiscNumber:       1,
 						IsLiked:          &isLikedTrue,
 					},
 					{
@@ -61,10 +69,18 @@ func Test_service_Search(t *testing.T) {
 						AlbumTotalTracks: 12,
 						AlbumImagesURL:   []string{"https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0", "https://i.scdn.co/image/ab67616d00001e02e319baafd16e84f0408af2a0", "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0"},
 						AlbumName:        "A Night At The Opera (2011 Remaster)",
+						AlbumID:          "1GbtB4zTqAsyfZEsm1RZfx",
+						AlbumReleaseDate: "1975-11-21",
 						ArtistsName:      []string{"Queen"},
+						ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
 						Explicit:         false,
 						ID:               "4u7EnebtmKWzUH433cf5Qv",
 						Name:             "Bohemian Rhapsody - Remastered 2011",
+						DurationMs:       354320,
+						Popularity:       80,
+						ISRC:             "GBUM71029604",
+						TrackNumber:      11,
+						DiscNumber:       1,
 						IsLiked:          &isLikedFalse,
 					},
 				},
@@ -72,7 +88,7 @@ func Test_service_Search(t *testing.T) {
 			},
 			wantErr: false,
 			mockFn: func(args args) {
-				mockSpotifyOutbound.EXPECT().Search(gomock.Any(), args.query, 10, 0).Return(&spotifyRepo.SpotifySearchResponse{
+				mockSpotifyOutbound.EXPECT().Search(gomock.Any(), args.query, 10, 0, "").Return(&spotifyRepo.SpotifySearchResponse{
 					Tracks: spotifyRepo.SpotifyTracks{
 						Href:   "https://api.spotify.com/v1/search?query=bohemian+rhapsody&type=track&market=ID&locale=en-US%2Cen%3Bq%3D0.9&offset=0&limit=10",
 						Limit:  10,
@@ -84,6 +100,7 @@ func Test_service_Search(t *testing.T) {
 								Album: spotifyRepo.SpotifyAlbumObject{
 									AlbumType:   "album",
 									TotalTracks: 22,
+									ID:          "6i6folBtxKV28WX3msQ4FE",
 									Images: []spotifyRepo.SpotifyAlbumImage{
 										{
 											URL: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b",
@@ -95,23 +112,31 @@ func Test_service_Search(t *testing.T) {
 											URL: "https://i.scdn.co/image/ab67616d00004851e8b066f70c206551210d902b",
 										},
 									},
-									Name: "Bohemian Rhapsody (The Original Soundtrack)",
+									Name:        "Bohemian Rhapsody (The Original Soundtrack)",
+									ReleaseDate: "2018-10-19",
 								},
 								Artists: []spotifyRepo.SpotifyArtistObject{
 									{
 										Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+										ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 										Name: "Queen",
 									},
 								},
-								Explicit: false,
-								Href:     "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
-								ID:       "3z8h0TU7ReDPLIbEnYhWZb",
-								Name:     "Bohemian Rhapsody",
+								DiscNumber:  1,
+								DurationMs:  354947,
+								Explicit:    false,
+								ExternalIDs: spotifyRepo.SpotifyExternalIDs{ISRC: "GBUM71029604"},
+								Href:        "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
+								ID:          "3z8h0TU7ReDPLIbEnYhWZb",
+								Name:        "Bohemian Rhapsody",
+								Popularity:  72,
+								TrackNumber: 7,
 							},
 							{
 								Album: spotifyRepo.SpotifyAlbumObject{
 									AlbumType:   "album",
 									TotalTracks: 12,
+									ID:          "1GbtB4zTqAsyfZEsm1RZfx",
 									Images: []spotifyRepo.SpotifyAlbumImage{
 										{
 											URL: "https://i.scdn.co/image/ab67616d0000b273e319baafd16e84f0408af2a0",
@@ -123,18 +148,25 @@ func Test_service_Search(t *testing.T) {
 											URL: "https://i.scdn.co/image/ab67616d00004851e319baafd16e84f0408af2a0",
 										},
 									},
-									Name: "A Night At The Opera (2011 Remaster)",
+									Name:        "A Night At The Opera (2011 Remaster)",
+									ReleaseDate: "1975-11-21",
 								},
 								Artists: []spotifyRepo.SpotifyArtistObject{
 									{
 										Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+										ID:   "1dfeR4HaWDbWqFHLkxsg1d",
 										Name: "Queen",
 									},
 								},
-								Explicit: false,
-								Href:     "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
-								ID:       "4u7EnebtmKWzUH433cf5Qv",
-								Name:     "Bohemian Rhapsody - Remastered 2011",
+								DiscNumber:  1,
+								DurationMs:  354320,
+								Explicit:    false,
+								ExternalIDs: spotifyRepo.SpotifyExternalIDs{ISRC: "GBUM71029604"},
+								Href:        "https://api.spotify.com/v1/tracks/4u7EnebtmKWzUH433cf5Qv",
+								ID:          "4u7EnebtmKWzUH433cf5Qv",
+								Name:        "Bohemian Rhapsody - Remastered 2011",
+								Popularity:  80,
+								TrackNumber: 11,
 							},
 						},
 					},
@@ -162,7 +194,7 @@ func Test_service_Search(t *testing.T) {
 			want:    nil,
 			wantErr: true,
 			mockFn: func(args args) {
-				mockSpotifyOutbound.EXPECT().Search(gomock.Any(), args.query, 10, 0).Return(nil, assert.AnError)
+				mockSpotifyOutbound.EXPECT().Search(gomock.Any(), args.query, 10, 0, "").Return(nil, assert.AnError)
 			},
 		},
 	}
@@ -173,7 +205,7 @@ func Test_service_Search(t *testing.T) {
 				spotifyOutbound:     mockSpotifyOutbound,
 				trackActivitiesRepo: mockTrackActivityRepo,
 			}
-			got, err := s.Search(context.Background(), tt.args.query, tt.args.pageSize, tt.args.pageIndex, 1)
+			got, err := s.Search(context.Background(), tt.args.query, tt.args.pageSize, tt.args.pageIndex, "", 1)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("service.Search() error = %v, wantErr %v", err, tt.wantErr)
 				return
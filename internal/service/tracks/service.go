@@ -2,6 +2,7 @@ package tracks
 
 import (
 	"context"
+	"time"
 
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
 	"github.com/xprasetio/go-spotify/internal/repository/spotify"
@@ -9,22 +10,61 @@ import (
 
 //go:generate mockgen -source=service.go -destination=service_mock_test.go -package=tracks
 type spotifyOutbound interface {
-	Search(ctx context.Context, query string, limit, offset int) (*spotify.SpotifySearchResponse, error)
-	GetRecommendation(ctx context.Context, limit int, trackID string) (*spotify.SpotifyRecommendationResponse, error)
+	Search(ctx context.Context, query string, limit, offset int, market string) (*spotify.SpotifySearchResponse, error)
+	GetRecommendation(ctx context.Context, params spotify.RecommendationParams) (*spotify.SpotifyRecommendationResponse, error)
+	GetTrack(ctx context.Context, trackID string) (*spotify.SpotifyTrackObject, error)
+	GetTracks(ctx context.Context, trackIDs []string) ([]*spotify.SpotifyTrackObject, error)
+	GetArtist(ctx context.Context, artistID string) (*spotify.SpotifyArtistDetail, error)
+	GetArtistTopTracks(ctx context.Context, artistID, market string) (*spotify.SpotifyArtistTopTracksResponse, error)
+	GetAlbum(ctx context.Context, albumID string) (*spotify.SpotifyAlbumDetail, error)
+	GetAlbumTracks(ctx context.Context, albumID string, limit, offset int) (*spotify.SpotifyTracks, error)
+	GetSavedTracks(ctx context.Context, accessToken string, limit, offset int) (*spotify.SpotifySavedTracksResponse, error)
+	SaveTracks(ctx context.Context, accessToken string, trackIDs []string) error
+	RemoveSavedTracks(ctx context.Context, accessToken string, trackIDs []string) error
 }
 
 type trackActivitiesRepository interface {
-	Create(ctx context.Context, model trackactivities.TrackActivity) error
-	Update(ctx context.Context, model trackactivities.TrackActivity) error
+	Create(ctx context.Context, model trackactivities.TrackActivity, likeSync *trackactivities.LikeSync) error
+	Update(ctx context.Context, model trackactivities.TrackActivity, likeSync *trackactivities.LikeSync) error
 	Get(ctx context.Context, userID uint, spotifyID string) (*trackactivities.TrackActivity, error)
 	GetBulkSpotifyIDs(ctx context.Context, userID uint, spotifyIDs []string) (map[string]trackactivities.TrackActivity, error)
+	List(ctx context.Context, userID uint, params trackactivities.ListParams) ([]trackactivities.TrackActivity, error)
+	CreateImportJob(ctx context.Context, model *trackactivities.ImportJob) error
+	GetImportJob(ctx context.Context, id uint) (*trackactivities.ImportJob, error)
+	GetActiveImportJob(ctx context.Context, userID uint) (*trackactivities.ImportJob, error)
+	ClaimImportJob(ctx context.Context, now, leaseUntil time.Time) (*trackactivities.ImportJob, error)
+	SaveImportPage(ctx context.Context, job trackactivities.ImportJob, activities []trackactivities.TrackActivity, leaseUntil time.Time) (int, error)
+	FinishImportJob(ctx context.Context, job trackactivities.ImportJob, status, errMessage string, finishedAt time.Time) error
+	ClaimLikeSyncs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]trackactivities.LikeSync, error)
+	FinishLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, status, errMessage string) error
+	RetryLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, nextAttemptAt time.Time, errMessage string) error
+	SaveRemoteLikes(ctx context.Context, activities []trackactivities.TrackActivity) (int, error)
+	PruneLikeSyncs(ctx context.Context, before time.Time) (int64, error)
+}
+
+// spotifyTokens hands out access tokens acting for a user on spotify.
+type spotifyTokens interface {
+	SpotifyAccessToken(ctx context.Context, userID uint) (string, error)
+	SpotifyLinked(ctx context.Context, userID uint) (bool, error)
 }
 
 type service struct {
 	spotifyOutbound     spotifyOutbound
 	trackActivitiesRepo trackActivitiesRepository
+	spotifyTokens       spotifyTokens
+
+	// importQueued wakes the import worker when a job is started.
+	importQueued chan struct{}
+	// likeSyncQueued wakes the like sync worker when a like changes.
+	likeSyncQueued chan struct{}
 }
 
-func NewService(spotifyOutbound spotifyOutbound, trackActivitiesRepo trackActivitiesRepository) *service {
-	return &service{spotifyOutbound: spotifyOutbound, trackActivitiesRepo: trackActivitiesRepo}
+func NewService(spotifyOutbound spotifyOutbound, trackActivitiesRepo trackActivitiesRepository, spotifyTokens spotifyTokens) *service {
+	return &service{
+		spotifyOutbound:     spotifyOutbound,
+		trackActivitiesRepo: trackActivitiesRepo,
+		spotifyTokens:       spotifyTokens,
+		importQueued:        make(chan struct{}, 1),
+		likeSyncQueued:      make(chan struct{}, 1),
+	}
 }
@@ -12,6 +12,7 @@ package tracks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	trackactivities "github.com/xprasetio/go-spotify/internal/models/trackactivities"
 	spotify "github.com/xprasetio/go-spotify/internal/repository/spotify"
@@ -41,34 +42,167 @@ func (m *MockspotifyOutbound) EXPECT() *MockspotifyOutboundMockRecorder {
 	return m.recorder
 }
 
+// GetAlbum mocks base method.
+func (m *MockspotifyOutbound) GetAlbum(ctx context.Context, albumID string) (*spotify.SpotifyAlbumDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, albumID)
+	ret0, _ := ret[0].(*spotify.SpotifyAlbumDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockspotifyOutboundMockRecorder) GetAlbum(ctx, albumID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockspotifyOutbound)(nil).GetAlbum), ctx, albumID)
+}
+
+// GetAlbumTracks mocks base method.
+func (m *MockspotifyOutbound) GetAlbumTracks(ctx context.Context, albumID string, limit, offset int) (*spotify.SpotifyTracks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbumTracks", ctx, albumID, limit, offset)
+	ret0, _ := ret[0].(*spotify.SpotifyTracks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbumTracks indicates an expected call of GetAlbumTracks.
+func (mr *MockspotifyOutboundMockRecorder) GetAlbumTracks(ctx, albumID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbumTracks", reflect.TypeOf((*MockspotifyOutbound)(nil).GetAlbumTracks), ctx, albumID, limit, offset)
+}
+
+// GetArtist mocks base method.
+func (m *MockspotifyOutbound) GetArtist(ctx context.Context, artistID string) (*spotify.SpotifyArtistDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, artistID)
+	ret0, _ := ret[0].(*spotify.SpotifyArtistDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockspotifyOutboundMockRecorder) GetArtist(ctx, artistID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockspotifyOutbound)(nil).GetArtist), ctx, artistID)
+}
+
+// GetArtistTopTracks mocks base method.
+func (m *MockspotifyOutbound) GetArtistTopTracks(ctx context.Context, artistID, market string) (*spotify.SpotifyArtistTopTracksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtistTopTracks", ctx, artistID, market)
+	ret0, _ := ret[0].(*spotify.SpotifyArtistTopTracksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtistTopTracks indicates an expected call of GetArtistTopTracks.
+func (mr *MockspotifyOutboundMockRecorder) GetArtistTopTracks(ctx, artistID, market any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtistTopTracks", reflect.TypeOf((*MockspotifyOutbound)(nil).GetArtistTopTracks), ctx, artistID, market)
+}
+
 // GetRecommendation mocks base method.
-func (m *MockspotifyOutbound) GetRecommendation(ctx context.Context, limit int, trackID string) (*spotify.SpotifyRecommendationResponse, error) {
+func (m *MockspotifyOutbound) GetRecommendation(ctx context.Context, params spotify.RecommendationParams) (*spotify.SpotifyRecommendationResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRecommendation", ctx, limit, trackID)
+	ret := m.ctrl.Call(m, "GetRecommendation", ctx, params)
 	ret0, _ := ret[0].(*spotify.SpotifyRecommendationResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetRecommendation indicates an expected call of GetRecommendation.
-func (mr *MockspotifyOutboundMockRecorder) GetRecommendation(ctx, limit, trackID any) *gomock.Call {
+func (mr *MockspotifyOutboundMockRecorder) GetRecommendation(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendation", reflect.TypeOf((*MockspotifyOutbound)(nil).GetRecommendation), ctx, params)
+}
+
+// GetSavedTracks mocks base method.
+func (m *MockspotifyOutbound) GetSavedTracks(ctx context.Context, accessToken string, limit, offset int) (*spotify.SpotifySavedTracksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedTracks", ctx, accessToken, limit, offset)
+	ret0, _ := ret[0].(*spotify.SpotifySavedTracksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedTracks indicates an expected call of GetSavedTracks.
+func (mr *MockspotifyOutboundMockRecorder) GetSavedTracks(ctx, accessToken, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedTracks", reflect.TypeOf((*MockspotifyOutbound)(nil).GetSavedTracks), ctx, accessToken, limit, offset)
+}
+
+// GetTrack mocks base method.
+func (m *MockspotifyOutbound) GetTrack(ctx context.Context, trackID string) (*spotify.SpotifyTrackObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrack", ctx, trackID)
+	ret0, _ := ret[0].(*spotify.SpotifyTrackObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrack indicates an expected call of GetTrack.
+func (mr *MockspotifyOutboundMockRecorder) GetTrack(ctx, trackID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrack", reflect.TypeOf((*MockspotifyOutbound)(nil).GetTrack), ctx, trackID)
+}
+
+// GetTracks mocks base method.
+func (m *MockspotifyOutbound) GetTracks(ctx context.Context, trackIDs []string) ([]*spotify.SpotifyTrackObject, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracks", ctx, trackIDs)
+	ret0, _ := ret[0].([]*spotify.SpotifyTrackObject)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTracks indicates an expected call of GetTracks.
+func (mr *MockspotifyOutboundMockRecorder) GetTracks(ctx, trackIDs any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendation", reflect.TypeOf((*MockspotifyOutbound)(nil).GetRecommendation), ctx, limit, trackID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracks", reflect.TypeOf((*MockspotifyOutbound)(nil).GetTracks), ctx, trackIDs)
+}
+
+// RemoveSavedTracks mocks base method.
+func (m *MockspotifyOutbound) RemoveSavedTracks(ctx context.Context, accessToken string, trackIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSavedTracks", ctx, accessToken, trackIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSavedTracks indicates an expected call of RemoveSavedTracks.
+func (mr *MockspotifyOutboundMockRecorder) RemoveSavedTracks(ctx, accessToken, trackIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSavedTracks", reflect.TypeOf((*MockspotifyOutbound)(nil).RemoveSavedTracks), ctx, accessToken, trackIDs)
+}
+
+// SaveTracks mocks base method.
+func (m *MockspotifyOutbound) SaveTracks(ctx context.Context, accessToken string, trackIDs []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTracks", ctx, accessToken, trackIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTracks indicates an expected call of SaveTracks.
+func (mr *MockspotifyOutboundMockRecorder) SaveTracks(ctx, accessToken, trackIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTracks", reflect.TypeOf((*MockspotifyOutbound)(nil).SaveTracks), ctx, accessToken, trackIDs)
 }
 
 // Search mocks base method.
-func (m *MockspotifyOutbound) Search(ctx context.Context, query string, limit, offset int) (*spotify.SpotifySearchResponse, error) {
+func (m *MockspotifyOutbound) Search(ctx context.Context, query string, limit, offset int, market string) (*spotify.SpotifySearchResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset)
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit, offset, market)
 	ret0, _ := ret[0].(*spotify.SpotifySearchResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockspotifyOutboundMockRecorder) Search(ctx, query, limit, offset any) *gomock.Call {
+func (mr *MockspotifyOutboundMockRecorder) Search(ctx, query, limit, offset, market any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockspotifyOutbound)(nil).Search), ctx, query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockspotifyOutbound)(nil).Search), ctx, query, limit, offset, market)
 }
 
 // MocktrackActivitiesRepository is a mock of trackActivitiesRepository interface.
@@ -94,18 +228,90 @@ func (m *MocktrackActivitiesRepository) EXPECT() *MocktrackActivitiesRepositoryM
 	return m.recorder
 }
 
+// ClaimImportJob mocks base method.
+func (m *MocktrackActivitiesRepository) ClaimImportJob(ctx context.Context, now, leaseUntil time.Time) (*trackactivities.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimImportJob", ctx, now, leaseUntil)
+	ret0, _ := ret[0].(*trackactivities.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimImportJob indicates an expected call of ClaimImportJob.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) ClaimImportJob(ctx, now, leaseUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimImportJob", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).ClaimImportJob), ctx, now, leaseUntil)
+}
+
+// ClaimLikeSyncs mocks base method.
+func (m *MocktrackActivitiesRepository) ClaimLikeSyncs(ctx context.Context, now, leaseUntil time.Time, limit int) ([]trackactivities.LikeSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimLikeSyncs", ctx, now, leaseUntil, limit)
+	ret0, _ := ret[0].([]trackactivities.LikeSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimLikeSyncs indicates an expected call of ClaimLikeSyncs.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) ClaimLikeSyncs(ctx, now, leaseUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimLikeSyncs", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).ClaimLikeSyncs), ctx, now, leaseUntil, limit)
+}
+
 // Create mocks base method.
-func (m *MocktrackActivitiesRepository) Create(ctx context.Context, model trackactivities.TrackActivity) error {
+func (m *MocktrackActivitiesRepository) Create(ctx context.Context, model trackactivities.TrackActivity, likeSync *trackactivities.LikeSync) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", ctx, model)
+	ret := m.ctrl.Call(m, "Create", ctx, model, likeSync)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MocktrackActivitiesRepositoryMockRecorder) Create(ctx, model any) *gomock.Call {
+func (mr *MocktrackActivitiesRepositoryMockRecorder) Create(ctx, model, likeSync any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).Create), ctx, model, likeSync)
+}
+
+// CreateImportJob mocks base method.
+func (m *MocktrackActivitiesRepository) CreateImportJob(ctx context.Context, model *trackactivities.ImportJob) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateImportJob", ctx, model)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateImportJob indicates an expected call of CreateImportJob.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) CreateImportJob(ctx, model any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateImportJob", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).CreateImportJob), ctx, model)
+}
+
+// FinishImportJob mocks base method.
+func (m *MocktrackActivitiesRepository) FinishImportJob(ctx context.Context, job trackactivities.ImportJob, status, errMessage string, finishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishImportJob", ctx, job, status, errMessage, finishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishImportJob indicates an expected call of FinishImportJob.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) FinishImportJob(ctx, job, status, errMessage, finishedAt any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).Create), ctx, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishImportJob", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).FinishImportJob), ctx, job, status, errMessage, finishedAt)
+}
+
+// FinishLikeSync mocks base method.
+func (m *MocktrackActivitiesRepository) FinishLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, status, errMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishLikeSync", ctx, likeSync, status, errMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishLikeSync indicates an expected call of FinishLikeSync.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) FinishLikeSync(ctx, likeSync, status, errMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishLikeSync", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).FinishLikeSync), ctx, likeSync, status, errMessage)
 }
 
 // Get mocks base method.
@@ -123,6 +329,21 @@ func (mr *MocktrackActivitiesRepositoryMockRecorder) Get(ctx, userID, spotifyID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).Get), ctx, userID, spotifyID)
 }
 
+// GetActiveImportJob mocks base method.
+func (m *MocktrackActivitiesRepository) GetActiveImportJob(ctx context.Context, userID uint) (*trackactivities.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveImportJob", ctx, userID)
+	ret0, _ := ret[0].(*trackactivities.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveImportJob indicates an expected call of GetActiveImportJob.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) GetActiveImportJob(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveImportJob", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).GetActiveImportJob), ctx, userID)
+}
+
 // GetBulkSpotifyIDs mocks base method.
 func (m *MocktrackActivitiesRepository) GetBulkSpotifyIDs(ctx context.Context, userID uint, spotifyIDs []string) (map[string]trackactivities.TrackActivity, error) {
 	m.ctrl.T.Helper()
@@ -138,16 +359,158 @@ func (mr *MocktrackActivitiesRepositoryMockRecorder) GetBulkSpotifyIDs(ctx, user
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBulkSpotifyIDs", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).GetBulkSpotifyIDs), ctx, userID, spotifyIDs)
 }
 
+// GetImportJob mocks base method.
+func (m *MocktrackActivitiesRepository) GetImportJob(ctx context.Context, id uint) (*trackactivities.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", ctx, id)
+	ret0, _ := ret[0].(*trackactivities.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) GetImportJob(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).GetImportJob), ctx, id)
+}
+
+// List mocks base method.
+func (m *MocktrackActivitiesRepository) List(ctx context.Context, userID uint, params trackactivities.ListParams) ([]trackactivities.TrackActivity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID, params)
+	ret0, _ := ret[0].([]trackactivities.TrackActivity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) List(ctx, userID, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).List), ctx, userID, params)
+}
+
+// PruneLikeSyncs mocks base method.
+func (m *MocktrackActivitiesRepository) PruneLikeSyncs(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneLikeSyncs", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneLikeSyncs indicates an expected call of PruneLikeSyncs.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) PruneLikeSyncs(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneLikeSyncs", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).PruneLikeSyncs), ctx, before)
+}
+
+// RetryLikeSync mocks base method.
+func (m *MocktrackActivitiesRepository) RetryLikeSync(ctx context.Context, likeSync trackactivities.LikeSync, nextAttemptAt time.Time, errMessage string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryLikeSync", ctx, likeSync, nextAttemptAt, errMessage)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryLikeSync indicates an expected call of RetryLikeSync.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) RetryLikeSync(ctx, likeSync, nextAttemptAt, errMessage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryLikeSync", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).RetryLikeSync), ctx, likeSync, nextAttemptAt, errMessage)
+}
+
+// SaveImportPage mocks base method.
+func (m *MocktrackActivitiesRepository) SaveImportPage(ctx context.Context, job trackactivities.ImportJob, activities []trackactivities.TrackActivity, leaseUntil time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveImportPage", ctx, job, activities, leaseUntil)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveImportPage indicates an expected call of SaveImportPage.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) SaveImportPage(ctx, job, activities, leaseUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveImportPage", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).SaveImportPage), ctx, job, activities, leaseUntil)
+}
+
+// SaveRemoteLikes mocks base method.
+func (m *MocktrackActivitiesRepository) SaveRemoteLikes(ctx context.Context, activities []trackactivities.TrackActivity) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRemoteLikes", ctx, activities)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveRemoteLikes indicates an expected call of SaveRemoteLikes.
+func (mr *MocktrackActivitiesRepositoryMockRecorder) SaveRemoteLikes(ctx, activities any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRemoteLikes", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).SaveRemoteLikes), ctx, activities)
+}
+
 // Update mocks base method.
-func (m *MocktrackActivitiesRepository) Update(ctx context.Context, model trackactivities.TrackActivity) error {
+func (m *MocktrackActivitiesRepository) Update(ctx context.Context, model trackactivities.TrackActivity, likeSync *trackactivities.LikeSync) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, model)
+	ret := m.ctrl.Call(m, "Update", ctx, model, likeSync)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MocktrackActivitiesRepositoryMockRecorder) Update(ctx, model any) *gomock.Call {
+func (mr *MocktrackActivitiesRepositoryMockRecorder) Update(ctx, model, likeSync any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).Update), ctx, model, likeSync)
+}
+
+// MockspotifyTokens is a mock of spotifyTokens interface.
+type MockspotifyTokens struct {
+	ctrl     *gomock.Controller
+	recorder *MockspotifyTokensMockRecorder
+}
+
+// MockspotifyTokensMockRecorder is the mock recorder for MockspotifyTokens.
+type MockspotifyTokensMockRecorder struct {
+	mock *MockspotifyTokens
+}
+
+// NewMockspotifyTokens creates a new mock instance.
+func NewMockspotifyTokens(ctrl *gomock.Controller) *MockspotifyTokens {
+	mock := &MockspotifyTokens{ctrl: ctrl}
+	mock.recorder = &MockspotifyTokensMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockspotifyTokens) EXPECT() *MockspotifyTokensMockRecorder {
+	return m.recorder
+}
+
+// SpotifyAccessToken mocks base method.
+func (m *MockspotifyTokens) SpotifyAccessToken(ctx context.Context, userID uint) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpotifyAccessToken", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpotifyAccessToken indicates an expected call of SpotifyAccessToken.
+func (mr *MockspotifyTokensMockRecorder) SpotifyAccessToken(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpotifyAccessToken", reflect.TypeOf((*MockspotifyTokens)(nil).SpotifyAccessToken), ctx, userID)
+}
+
+// SpotifyLinked mocks base method.
+func (m *MockspotifyTokens) SpotifyLinked(ctx context.Context, userID uint) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpotifyLinked", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SpotifyLinked indicates an expected call of SpotifyLinked.
+func (mr *MockspotifyTokensMockRecorder) SpotifyLinked(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MocktrackActivitiesRepository)(nil).Update), ctx, model)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpotifyLinked", reflect.TypeOf((*MockspotifyTokens)(nil).SpotifyLinked), ctx, userID)
 }
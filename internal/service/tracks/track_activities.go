@@ -2,9 +2,15 @@ package tracks
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
 	"gorm.io/gorm"
 )
@@ -18,24 +24,122 @@ func (s *service) UpsertTrackActivities(ctx context.Context, userID uint, reques
 
 	if err == gorm.ErrRecordNotFound || activity == nil {
 		// create user activity
+		likeSync, err := s.likeSyncFor(ctx, userID, request.SpotifyID, nil, request.IsLiked)
+		if err != nil {
+			return err
+		}
 		err = s.trackActivitiesRepo.Create(ctx, trackactivities.TrackActivity{
 			UserID:    userID,
 			SpotifyID: request.SpotifyID,
 			IsLiked:   request.IsLiked,
 			CreatedBy: fmt.Sprintf("%d", userID),
 			UpdatedBy: fmt.Sprintf("%d", userID),
-		})
+		}, likeSync)
 		if err != nil {
 			log.Error().Err(err).Msg("error create record to database")
 			return err
 		}
+		s.queueLikeSync(likeSync)
 		return nil
 	}
+	likeSync, err := s.likeSyncFor(ctx, userID, request.SpotifyID, activity.IsLiked, request.IsLiked)
+	if err != nil {
+		return err
+	}
 	activity.IsLiked = request.IsLiked
-	err = s.trackActivitiesRepo.Update(ctx, *activity)
+	err = s.trackActivitiesRepo.Update(ctx, *activity, likeSync)
 	if err != nil {
 		log.Error().Err(err).Msg("error update record to database")
 		return err
 	}
+	s.queueLikeSync(likeSync)
 	return nil
 }
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ListTrackActivities returns a page of the user's liked or disliked tracks
+// hydrated with spotify metadata.
+func (s *service) ListTrackActivities(ctx context.Context, userID uint, request trackactivities.ListRequest) (*spotify.TrackActivitiesResponse, error) {
+	params := trackactivities.ListParams{
+		IsLiked: request.IsLiked,
+		// one extra row tells whether there is a next page
+		Limit:     request.PageSize + 1,
+		Ascending: request.Ascending,
+	}
+	if request.Cursor != "" {
+		cursor, err := decodeCursor(request.Cursor)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		params.After = cursor
+	}
+
+	activities, err := s.trackActivitiesRepo.List(ctx, userID, params)
+	if err != nil {
+		log.Error().Err(err).Msg("error list track activities from database")
+		return nil, err
+	}
+
+	response := &spotify.TrackActivitiesResponse{
+		Items: make([]spotify.TrackActivityItem, 0, len(activities)),
+	}
+	if len(activities) > request.PageSize {
+		activities = activities[:request.PageSize]
+		response.NextCursor = encodeCursor(activities[len(activities)-1])
+	}
+	if len(activities) == 0 {
+		return response, nil
+	}
+
+	trackIDs := make([]string, len(activities))
+	for idx, activity := range activities {
+		trackIDs[idx] = activity.SpotifyID
+	}
+	trackDetails, err := s.spotifyOutbound.GetTracks(ctx, trackIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("error get tracks from spotify outbound")
+		return nil, err
+	}
+
+	for idx, activity := range activities {
+		item := spotify.SpotifyTrackObject{ID: activity.SpotifyID, IsLiked: activity.IsLiked, NotFound: true}
+		if trackDetails[idx] != nil {
+			item = trackToResponse(*trackDetails[idx], activity.IsLiked)
+		}
+		response.Items = append(response.Items, spotify.TrackActivityItem{
+			SpotifyTrackObject: item,
+			UpdatedAt:          activity.UpdatedAt,
+		})
+	}
+	return response, nil
+}
+
+// encodeCursor makes an opaque cursor pointing after activity.
+func encodeCursor(activity trackactivities.TrackActivity) string {
+	raw := fmt.Sprintf("%d.%d", activity.UpdatedAt.UnixNano(), activity.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(value string) (*trackactivities.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+	nanos, err := strconv.ParseInt(updatedAt, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	activityID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &trackactivities.Cursor{
+		UpdatedAt: time.Unix(0, nanos).UTC(),
+		ID:        uint(activityID),
+	}, nil
+}
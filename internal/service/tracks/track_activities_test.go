@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
 	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
@@ -16,6 +19,7 @@ func Test_service_UpsertTrackActivities(t *testing.T) {
 	defer mockCtrl.Finish()
 
 	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+	mockTokens := NewMockspotifyTokens(mockCtrl)
 
 	isLikedTrue := true
 	isLikedFalse := false
@@ -41,6 +45,7 @@ func Test_service_UpsertTrackActivities(t *testing.T) {
 			wantErr: false,
 			mockFn: func(args args) {
 				mockTrackActivityRepo.EXPECT().Get(gomock.Any(), args.userID, args.request.SpotifyID).Return(nil, gorm.ErrRecordNotFound)
+				mockTokens.EXPECT().SpotifyLinked(gomock.Any(), args.userID).Return(true, nil)
 
 				mockTrackActivityRepo.EXPECT().Create(gomock.Any(), trackactivities.TrackActivity{
 					UserID:    args.userID,
@@ -48,7 +53,52 @@ func Test_service_UpsertTrackActivities(t *testing.T) {
 					IsLiked:   args.request.IsLiked,
 					CreatedBy: fmt.Sprintf("%d", args.userID),
 					UpdatedBy: fmt.Sprintf("%d", args.userID),
-				}).Return(nil)
+				}, likeSyncMatcher(args.userID, args.request.SpotifyID, true)).Return(nil)
+			},
+		},
+		{
+			name: "success: create without linked spotify, nothing to sync",
+			args: args{
+				userID: 1,
+				request: trackactivities.TrackActivityRequest{
+					SpotifyID: "spotifyID",
+					IsLiked:   &isLikedTrue,
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockTrackActivityRepo.EXPECT().Get(gomock.Any(), args.userID, args.request.SpotifyID).Return(nil, gorm.ErrRecordNotFound)
+				mockTokens.EXPECT().SpotifyLinked(gomock.Any(), args.userID).Return(false, nil)
+
+				mockTrackActivityRepo.EXPECT().Create(gomock.Any(), trackactivities.TrackActivity{
+					UserID:    args.userID,
+					SpotifyID: args.request.SpotifyID,
+					IsLiked:   args.request.IsLiked,
+					CreatedBy: fmt.Sprintf("%d", args.userID),
+					UpdatedBy: fmt.Sprintf("%d", args.userID),
+				}, gomock.Nil()).Return(nil)
+			},
+		},
+		{
+			name: "success: create dislike, nothing to sync",
+			args: args{
+				userID: 1,
+				request: trackactivities.TrackActivityRequest{
+					SpotifyID: "spotifyID",
+					IsLiked:   &isLikedFalse,
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockTrackActivityRepo.EXPECT().Get(gomock.Any(), args.userID, args.request.SpotifyID).Return(nil, gorm.ErrRecordNotFound)
+
+				mockTrackActivityRepo.EXPECT().Create(gomock.Any(), trackactivities.TrackActivity{
+					UserID:    args.userID,
+					SpotifyID: args.request.SpotifyID,
+					IsLiked:   args.request.IsLiked,
+					CreatedBy: fmt.Sprintf("%d", args.userID),
+					UpdatedBy: fmt.Sprintf("%d", args.userID),
+				}, gomock.Nil()).Return(nil)
 			},
 		},
 		{
@@ -65,10 +115,52 @@ func Test_service_UpsertTrackActivities(t *testing.T) {
 				mockTrackActivityRepo.EXPECT().Get(gomock.Any(), args.userID, args.request.SpotifyID).Return(&trackactivities.TrackActivity{
 					IsLiked: &isLikedFalse,
 				}, nil)
+				mockTokens.EXPECT().SpotifyLinked(gomock.Any(), args.userID).Return(true, nil)
 
 				mockTrackActivityRepo.EXPECT().Update(gomock.Any(), trackactivities.TrackActivity{
 					IsLiked: args.request.IsLiked,
-				}).Return(nil)
+				}, likeSyncMatcher(args.userID, args.request.SpotifyID, true)).Return(nil)
+			},
+		},
+		{
+			name: "success: update from like to neutral",
+			args: args{
+				userID: 1,
+				request: trackactivities.TrackActivityRequest{
+					SpotifyID: "spotifyID",
+					IsLiked:   nil,
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockTrackActivityRepo.EXPECT().Get(gomock.Any(), args.userID, args.request.SpotifyID).Return(&trackactivities.TrackActivity{
+					IsLiked: &isLikedTrue,
+				}, nil)
+				mockTokens.EXPECT().SpotifyLinked(gomock.Any(), args.userID).Return(true, nil)
+
+				mockTrackActivityRepo.EXPECT().Update(gomock.Any(), trackactivities.TrackActivity{
+					IsLiked: nil,
+				}, likeSyncMatcher(args.userID, args.request.SpotifyID, false)).Return(nil)
+			},
+		},
+		{
+			name: "success: update from dislike to neutral, nothing to sync",
+			args: args{
+				userID: 1,
+				request: trackactivities.TrackActivityRequest{
+					SpotifyID: "spotifyID",
+					IsLiked:   nil,
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockTrackActivityRepo.EXPECT().Get(gomock.Any(), args.userID, args.request.SpotifyID).Return(&trackactivities.TrackActivity{
+					IsLiked: &isLikedFalse,
+				}, nil)
+
+				mockTrackActivityRepo.EXPECT().Update(gomock.Any(), trackactivities.TrackActivity{
+					IsLiked: nil,
+				}, gomock.Nil()).Return(nil)
 			},
 		},
 
@@ -92,6 +184,7 @@ func Test_service_UpsertTrackActivities(t *testing.T) {
 			tt.mockFn(tt.args)
 			s := &service{
 				trackActivitiesRepo: mockTrackActivityRepo,
+				spotifyTokens:       mockTokens,
 			}
 			if err := s.UpsertTrackActivities(context.Background(), tt.args.userID, tt.args.request); (err != nil) != tt.wantErr {
 				t.Errorf("service.UpsertTrackActivities() error = %v, wantErr %v", err, tt.wantErr)
@@ -99,3 +192,125 @@ func Test_service_UpsertTrackActivities(t *testing.T) {
 		})
 	}
 }
+
+// likeSyncMatcher matches a pending like sync queued just now.
+func likeSyncMatcher(userID uint, spotifyID string, liked bool) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		likeSync, ok := x.(*trackactivities.LikeSync)
+		return ok && likeSync != nil &&
+			likeSync.UserID == userID && likeSync.SpotifyID == spotifyID && likeSync.Liked == liked &&
+			likeSync.Status == trackactivities.LikeSyncStatusPending &&
+			time.Since(likeSync.ChangedAt) < time.Minute && likeSync.NextAttemptAt.Equal(likeSync.ChangedAt)
+	})
+}
+
+func Test_service_ListTrackActivities(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivityRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	isLikedTrue := true
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	activities := []trackactivities.TrackActivity{
+		{Model: gorm.Model{ID: 3, UpdatedAt: now}, SpotifyID: "3z8h0TU7ReDPLIbEnYhWZb", IsLiked: &isLikedTrue},
+		{Model: gorm.Model{ID: 2, UpdatedAt: now.Add(-time.Hour)}, SpotifyID: "removed", IsLiked: &isLikedTrue},
+		{Model: gorm.Model{ID: 1, UpdatedAt: now.Add(-2 * time.Hour)}, SpotifyID: "0DiWol3AO6WpXZgp0goxAV", IsLiked: &isLikedTrue},
+	}
+	queen := spotifyRepo.SpotifyTrackObject{
+		Artists: []spotifyRepo.SpotifyArtistObject{{ID: "1dfeR4HaWDbWqFHLkxsg1d", Name: "Queen"}},
+		ID:      "3z8h0TU7ReDPLIbEnYhWZb",
+		Name:    "Bohemian Rhapsody",
+	}
+	cursor := encodeCursor(activities[1])
+
+	tests := []struct {
+		name    string
+		request trackactivities.ListRequest
+		want    *spotify.TrackActivitiesResponse
+		wantErr error
+		mockFn  func()
+	}{
+		{
+			name:    "success: first page with next cursor",
+			request: trackactivities.ListRequest{IsLiked: true, PageSize: 2},
+			want: &spotify.TrackActivitiesResponse{
+				Items: []spotify.TrackActivityItem{
+					{
+						SpotifyTrackObject: spotify.SpotifyTrackObject{
+							AlbumImagesURL: []string{},
+							ArtistsName:    []string{"Queen"},
+							ArtistsID:      []string{"1dfeR4HaWDbWqFHLkxsg1d"},
+							ID:             "3z8h0TU7ReDPLIbEnYhWZb",
+							Name:           "Bohemian Rhapsody",
+							IsLiked:        &isLikedTrue,
+						},
+						UpdatedAt: now,
+					},
+					{
+						SpotifyTrackObject: spotify.SpotifyTrackObject{
+							ID:       "removed",
+							IsLiked:  &isLikedTrue,
+							NotFound: true,
+						},
+						UpdatedAt: now.Add(-time.Hour),
+					},
+				},
+				NextCursor: cursor,
+			},
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 3}).
+					Return(activities, nil)
+				mockSpotifyOutbound.EXPECT().GetTracks(gomock.Any(), []string{"3z8h0TU7ReDPLIbEnYhWZb", "removed"}).
+					Return([]*spotifyRepo.SpotifyTrackObject{&queen, nil}, nil)
+			},
+		},
+		{
+			name:    "success: last page after cursor",
+			request: trackactivities.ListRequest{IsLiked: true, PageSize: 2, Cursor: cursor},
+			want: &spotify.TrackActivitiesResponse{
+				Items: []spotify.TrackActivityItem{},
+			},
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{
+					IsLiked: true,
+					Limit:   3,
+					After:   &trackactivities.Cursor{UpdatedAt: now.Add(-time.Hour), ID: 2},
+				}).Return([]trackactivities.TrackActivity{}, nil)
+			},
+		},
+		{
+			name:    "failed: invalid cursor",
+			request: trackactivities.ListRequest{IsLiked: true, PageSize: 2, Cursor: "not a cursor"},
+			wantErr: ErrInvalidCursor,
+			mockFn:  func() {},
+		},
+		{
+			name:    "failed: when get tracks from spotify outbound",
+			request: trackactivities.ListRequest{IsLiked: true, PageSize: 5},
+			wantErr: assert.AnError,
+			mockFn: func() {
+				mockTrackActivityRepo.EXPECT().List(gomock.Any(), uint(1), trackactivities.ListParams{IsLiked: true, Limit: 6}).
+					Return(activities, nil)
+				mockSpotifyOutbound.EXPECT().GetTracks(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivityRepo,
+			}
+			got, err := s.ListTrackActivities(context.Background(), 1, tt.request)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
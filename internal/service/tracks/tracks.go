@@ -0,0 +1,70 @@
+package tracks
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+)
+
+func (s *service) GetTrack(ctx context.Context, userID uint, trackID string) (*spotify.SpotifyTrackObject, error) {
+	trackDetail, err := s.spotifyOutbound.GetTrack(ctx, trackID)
+	if err != nil {
+		log.Error().Err(err).Msg("error get track from spotify outbound")
+		return nil, err
+	}
+
+	trackActivities, err := s.getTrackActivities(ctx, userID, []spotifyRepo.SpotifyTrackObject{*trackDetail})
+	if err != nil {
+		return nil, err
+	}
+
+	response := trackToResponse(*trackDetail, trackActivities[trackDetail.ID].IsLiked)
+	return &response, nil
+}
+
+// GetTracks hydrates trackIDs in the requested order. IDs spotify doesn't know
+// are returned with only the id and the not found marker set.
+func (s *service) GetTracks(ctx context.Context, userID uint, trackIDs []string) (*spotify.TracksResponse, error) {
+	uniqueIDs := make([]string, 0, len(trackIDs))
+	seen := make(map[string]bool, len(trackIDs))
+	for _, trackID := range trackIDs {
+		if !seen[trackID] {
+			seen[trackID] = true
+			uniqueIDs = append(uniqueIDs, trackID)
+		}
+	}
+
+	trackDetails, err := s.spotifyOutbound.GetTracks(ctx, uniqueIDs)
+	if err != nil {
+		log.Error().Err(err).Msg("error get tracks from spotify outbound")
+		return nil, err
+	}
+
+	found := make([]spotifyRepo.SpotifyTrackObject, 0, len(trackDetails))
+	mapTracks := make(map[string]spotifyRepo.SpotifyTrackObject, len(trackDetails))
+	for idx, trackDetail := range trackDetails {
+		if trackDetail == nil {
+			continue
+		}
+		found = append(found, *trackDetail)
+		mapTracks[uniqueIDs[idx]] = *trackDetail
+	}
+
+	trackActivities, err := s.getTrackActivities(ctx, userID, found)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]spotify.SpotifyTrackObject, 0, len(trackIDs))
+	for _, trackID := range trackIDs {
+		trackDetail, ok := mapTracks[trackID]
+		if !ok {
+			items = append(items, spotify.SpotifyTrackObject{ID: trackID, NotFound: true})
+			continue
+		}
+		items = append(items, trackToResponse(trackDetail, trackActivities[trackDetail.ID].IsLiked))
+	}
+	return &spotify.TracksResponse{Items: items}, nil
+}
@@ -0,0 +1,252 @@
+package tracks
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xprasetio/go-spotify/internal/models/spotify"
+	"github.com/xprasetio/go-spotify/internal/models/trackactivities"
+	spotifyRepo "github.com/xprasetio/go-spotify/internal/repository/spotify"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_service_GetTrack(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivitiesRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	isLikedTrue := true
+	track := spotifyRepo.SpotifyTrackObject{
+		Album: spotifyRepo.SpotifyAlbumObject{
+			AlbumType:   "album",
+			TotalTracks: 22,
+			Images: []spotifyRepo.SpotifyAlbumImage{
+				{
+					URL: "https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b",
+				},
+			},
+			Name: "Bohemian Rhapsody (The Original Soundtrack)",
+		},
+		Artists: []spotifyRepo.SpotifyArtistObject{
+			{
+				Href: "https://api.spotify.com/v1/artists/1dfeR4HaWDbWqFHLkxsg1d",
+				ID:   "1dfeR4HaWDbWqFHLkxsg1d",
+				Name: "Queen",
+			},
+		},
+		Explicit: false,
+		Href:     "https://api.spotify.com/v1/tracks/3z8h0TU7ReDPLIbEnYhWZb",
+		ID:       "3z8h0TU7ReDPLIbEnYhWZb",
+		Name:     "Bohemian Rhapsody",
+	}
+
+	type args struct {
+		userID  uint
+		trackID string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *spotify.SpotifyTrackObject
+		wantErr bool
+		mockFn  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				userID:  1,
+				trackID: "3z8h0TU7ReDPLIbEnYhWZb",
+			},
+			want: &spotify.SpotifyTrackObject{
+				AlbumType:        "album",
+				AlbumTotalTracks: 22,
+				AlbumImagesURL:   []string{"https://i.scdn.co/image/ab67616d0000b273e8b066f70c206551210d902b"},
+				AlbumName:        "Bohemian Rhapsody (The Original Soundtrack)",
+				ArtistsName:      []string{"Queen"},
+				ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
+				Explicit:         false,
+				ID:               "3z8h0TU7ReDPLIbEnYhWZb",
+				Name:             "Bohemian Rhapsody",
+				IsLiked:          &isLikedTrue,
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetTrack(gomock.Any(), args.trackID).Return(&track, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{args.trackID}).
+					Return(map[string]trackactivities.TrackActivity{
+						args.trackID: {
+							IsLiked: &isLikedTrue,
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "failed: when get bulk spotify id",
+			args: args{
+				userID:  1,
+				trackID: "3z8h0TU7ReDPLIbEnYhWZb",
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetTrack(gomock.Any(), args.trackID).Return(&track, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{args.trackID}).
+					Return(nil, assert.AnError)
+			},
+		},
+		{
+			name: "failed: when get track from spotify outbound",
+			args: args{
+				userID:  1,
+				trackID: "3z8h0TU7ReDPLIbEnYhWZb",
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetTrack(gomock.Any(), args.trackID).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.args)
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivitiesRepo,
+			}
+			got, err := s.GetTrack(context.Background(), tt.args.userID, tt.args.trackID)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.GetTrack() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.GetTrack() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_service_GetTracks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockSpotifyOutbound := NewMockspotifyOutbound(mockCtrl)
+	mockTrackActivitiesRepo := NewMocktrackActivitiesRepository(mockCtrl)
+
+	isLikedTrue := true
+	queen := spotifyRepo.SpotifyTrackObject{
+		Album:   spotifyRepo.SpotifyAlbumObject{AlbumType: "album", TotalTracks: 22, Name: "Bohemian Rhapsody (The Original Soundtrack)"},
+		Artists: []spotifyRepo.SpotifyArtistObject{{ID: "1dfeR4HaWDbWqFHLkxsg1d", Name: "Queen"}},
+		ID:      "3z8h0TU7ReDPLIbEnYhWZb",
+		Name:    "Bohemian Rhapsody",
+	}
+	daftPunk := spotifyRepo.SpotifyTrackObject{
+		Album:   spotifyRepo.SpotifyAlbumObject{AlbumType: "album", TotalTracks: 14, Name: "Discovery"},
+		Artists: []spotifyRepo.SpotifyArtistObject{{ID: "4tZwfgrHOc3mvqYlEYSvVi", Name: "Daft Punk"}},
+		ID:      "0DiWol3AO6WpXZgp0goxAV",
+		Name:    "One More Time",
+	}
+
+	type args struct {
+		userID   uint
+		trackIDs []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *spotify.TracksResponse
+		wantErr bool
+		mockFn  func(args args)
+	}{
+		{
+			name: "success",
+			args: args{
+				userID:   1,
+				trackIDs: []string{"0DiWol3AO6WpXZgp0goxAV", "unknown", "3z8h0TU7ReDPLIbEnYhWZb", "0DiWol3AO6WpXZgp0goxAV"},
+			},
+			want: &spotify.TracksResponse{
+				Items: []spotify.SpotifyTrackObject{
+					{
+						AlbumType:        "album",
+						AlbumTotalTracks: 14,
+						AlbumImagesURL:   []string{},
+						AlbumName:        "Discovery",
+						ArtistsName:      []string{"Daft Punk"},
+						ArtistsID:        []string{"4tZwfgrHOc3mvqYlEYSvVi"},
+						ID:               "0DiWol3AO6WpXZgp0goxAV",
+						Name:             "One More Time",
+					},
+					{
+						ID:       "unknown",
+						NotFound: true,
+					},
+					{
+						AlbumType:        "album",
+						AlbumTotalTracks: 22,
+						AlbumImagesURL:   []string{},
+						AlbumName:        "Bohemian Rhapsody (The Original Soundtrack)",
+						ArtistsName:      []string{"Queen"},
+						ArtistsID:        []string{"1dfeR4HaWDbWqFHLkxsg1d"},
+						ID:               "3z8h0TU7ReDPLIbEnYhWZb",
+						Name:             "Bohemian Rhapsody",
+						IsLiked:          &isLikedTrue,
+					},
+					{
+						AlbumType:        "album",
+						AlbumTotalTracks: 14,
+						AlbumImagesURL:   []string{},
+						AlbumName:        "Discovery",
+						ArtistsName:      []string{"Daft Punk"},
+						ArtistsID:        []string{"4tZwfgrHOc3mvqYlEYSvVi"},
+						ID:               "0DiWol3AO6WpXZgp0goxAV",
+						Name:             "One More Time",
+					},
+				},
+			},
+			wantErr: false,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetTracks(gomock.Any(), []string{"0DiWol3AO6WpXZgp0goxAV", "unknown", "3z8h0TU7ReDPLIbEnYhWZb"}).
+					Return([]*spotifyRepo.SpotifyTrackObject{&daftPunk, nil, &queen}, nil)
+				mockTrackActivitiesRepo.EXPECT().GetBulkSpotifyIDs(gomock.Any(), args.userID, []string{"0DiWol3AO6WpXZgp0goxAV", "3z8h0TU7ReDPLIbEnYhWZb"}).
+					Return(map[string]trackactivities.TrackActivity{
+						"3z8h0TU7ReDPLIbEnYhWZb": {
+							IsLiked: &isLikedTrue,
+						},
+					}, nil)
+			},
+		},
+		{
+			name: "failed: when get tracks from spotify outbound",
+			args: args{
+				userID:   1,
+				trackIDs: []string{"3z8h0TU7ReDPLIbEnYhWZb"},
+			},
+			want:    nil,
+			wantErr: true,
+			mockFn: func(args args) {
+				mockSpotifyOutbound.EXPECT().GetTracks(gomock.Any(), args.trackIDs).Return(nil, assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn(tt.args)
+			s := &service{
+				spotifyOutbound:     mockSpotifyOutbound,
+				trackActivitiesRepo: mockTrackActivitiesRepo,
+			}
+			got, err := s.GetTracks(context.Background(), tt.args.userID, tt.args.trackIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("service.GetTracks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("service.GetTracks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
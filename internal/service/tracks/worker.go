@@ -0,0 +1,38 @@
+package tracks
+
+import (
+	"context"
+	"time"
+)
+
+// worker runs the jobs claimed from a table, side by side with other workers.
+type worker struct {
+	// queued wakes the worker before the interval is up.
+	queued <-chan struct{}
+	// next runs one claimed job and reports whether there was one.
+	next func(ctx context.Context) bool
+	// prune, when set, cleans up finished jobs every pruneInterval.
+	prune         func(ctx context.Context, now time.Time)
+	pruneInterval time.Duration
+}
+
+func (w worker) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var nextPrune time.Time
+	for {
+		for ctx.Err() == nil && w.next(ctx) {
+		}
+		if now := time.Now(); w.prune != nil && ctx.Err() == nil && !now.Before(nextPrune) {
+			w.prune(ctx, now)
+			nextPrune = now.Add(w.pruneInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.queued:
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package tracks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_worker_run(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	queued := make(chan struct{}, 1)
+	claims := make(chan bool, 4)
+	jobs, prunes := 2, 0
+	w := worker{
+		queued: queued,
+		next: func(ctx context.Context) bool {
+			found := jobs > 0
+			if found {
+				jobs--
+			}
+			claims <- found
+			return found
+		},
+		prune: func(ctx context.Context, now time.Time) {
+			prunes++
+		},
+		pruneInterval: time.Hour,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		w.run(ctx, time.Hour)
+		close(done)
+	}()
+
+	// runs jobs until there are none left
+	assert.Equal(t, []bool{true, true, false}, []bool{<-claims, <-claims, <-claims})
+
+	// a wake up looks again without waiting for the interval
+	queued <- struct{}{}
+	assert.False(t, <-claims)
+
+	cancel()
+	<-done
+	assert.Equal(t, 1, prunes)
+}
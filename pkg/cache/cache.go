@@ -0,0 +1,16 @@
+// Package cache provides the byte oriented key value backends used to cache
+// upstream responses.
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=cache.go -destination=cache_mock.go -package=cache
+type Backend interface {
+	// Get returns the value stored under key, the boolean reports a hit.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+const defaultMaxEntries = 1000
+
+// Memory is an in-process backend with size bounded LRU eviction.
+type Memory struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List
+	items      map[string]*list.Element
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiredAt time.Time
+}
+
+// NewMemory returns a memory backend holding at most maxEntries keys, the
+// least recently used key is evicted first.
+func NewMemory(maxEntries int) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = defaultMaxEntries
+	}
+	return &Memory{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		now:        time.Now,
+	}
+}
+
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.items[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiredAt) {
+		m.remove(elem)
+		return nil, false, nil
+	}
+	m.ll.MoveToFront(elem)
+	return entry.value, true, nil
+}
+
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiredAt := m.now().Add(ttl)
+	if elem, ok := m.items[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiredAt = expiredAt
+		m.ll.MoveToFront(elem)
+		return nil
+	}
+
+	m.items[key] = m.ll.PushFront(&memoryEntry{key: key, value: value, expiredAt: expiredAt})
+	for m.ll.Len() > m.maxEntries {
+		m.remove(m.ll.Back())
+	}
+	return nil
+}
+
+// Len returns the number of stored keys, expired keys included until they
+// are evicted or read.
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.ll.Len()
+}
+
+func (m *Memory) remove(elem *list.Element) {
+	m.ll.Remove(elem)
+	delete(m.items, elem.Value.(*memoryEntry).key)
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		run      func(m *Memory)
+		wantKeys map[string]bool
+	}{
+		{
+			name: "evicts least recently used",
+			run: func(m *Memory) {
+				assert.NoError(t, m.Set(ctx, "a", []byte("a"), time.Minute))
+				assert.NoError(t, m.Set(ctx, "b", []byte("b"), time.Minute))
+				_, _, _ = m.Get(ctx, "a")
+				assert.NoError(t, m.Set(ctx, "c", []byte("c"), time.Minute))
+			},
+			wantKeys: map[string]bool{"a": true, "b": false, "c": true},
+		},
+		{
+			name: "overwrite refreshes entry",
+			run: func(m *Memory) {
+				assert.NoError(t, m.Set(ctx, "a", []byte("a"), time.Minute))
+				assert.NoError(t, m.Set(ctx, "b", []byte("b"), time.Minute))
+				assert.NoError(t, m.Set(ctx, "a", []byte("a2"), time.Minute))
+				assert.NoError(t, m.Set(ctx, "c", []byte("c"), time.Minute))
+			},
+			wantKeys: map[string]bool{"a": true, "b": false, "c": true},
+		},
+		{
+			name: "expires after ttl",
+			run: func(m *Memory) {
+				assert.NoError(t, m.Set(ctx, "a", []byte("a"), time.Minute))
+				assert.NoError(t, m.Set(ctx, "b", []byte("b"), time.Hour))
+				m.now = func() time.Time { return now.Add(2 * time.Minute) }
+			},
+			wantKeys: map[string]bool{"a": false, "b": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMemory(2)
+			m.now = func() time.Time { return now }
+			tt.run(m)
+
+			for key, wantHit := range tt.wantKeys {
+				_, hit, err := m.Get(ctx, key)
+				assert.NoError(t, err)
+				assert.Equal(t, wantHit, hit, "key %s", key)
+			}
+			assert.LessOrEqual(t, m.Len(), 2)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultDialTimeout = 2 * time.Second
+	defaultIOTimeout   = time.Second
+	defaultMaxIdle     = 8
+)
+
+var errNilReply = errors.New("redis: nil reply")
+
+// Redis is a backend speaking the redis protocol (RESP). Eviction is left to
+// the server, configure it with a maxmemory policy such as allkeys-lru.
+type Redis struct {
+	addr        string
+	password    string
+	db          int
+	dialTimeout time.Duration
+	idle        chan *redisConn
+}
+
+type redisConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// RedisOption define an option for the redis backend.
+type RedisOption func(*Redis)
+
+// WithRedisAuth authenticates new connections with password.
+func WithRedisAuth(password string) RedisOption {
+	return func(r *Redis) {
+		r.password = password
+	}
+}
+
+// WithRedisDB selects the logical database on new connections.
+func WithRedisDB(db int) RedisOption {
+	return func(r *Redis) {
+		r.db = db
+	}
+}
+
+func NewRedis(addr string, opts ...RedisOption) *Redis {
+	r := &Redis{
+		addr:        addr,
+		dialTimeout: defaultDialTimeout,
+		idle:        make(chan *redisConn, defaultMaxIdle),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err == errNilReply {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, true, nil
+}
+
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := r.do(ctx, "SET", key, string(value), "PX", strconv.FormatInt(ttl.Milliseconds(), 10))
+	return err
+}
+
+// Close closes the idle connections.
+func (r *Redis) Close() error {
+	for {
+		select {
+		case conn := <-r.idle:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (r *Redis) do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, args...)
+	if err != nil && err != errNilReply {
+		var replyErr redisError
+		if !errors.As(err, &replyErr) {
+			// the connection state is unknown after an i/o error
+			conn.Close()
+			return nil, err
+		}
+	}
+	r.release(conn)
+	return reply, err
+}
+
+func (r *Redis) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-r.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: r.dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", r.addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{Conn: netConn, reader: bufio.NewReader(netConn)}
+
+	if r.password != "" {
+		if _, err := conn.do(ctx, "AUTH", r.password); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if r.db != 0 {
+		if _, err := conn.do(ctx, "SELECT", strconv.Itoa(r.db)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+func (r *Redis) release(conn *redisConn) {
+	select {
+	case r.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+func (c *redisConn) do(ctx context.Context, args ...string) (interface{}, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultIOTimeout)
+	}
+	if err := c.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if err := writeCommand(c.Conn, args); err != nil {
+		return nil, err
+	}
+	return readReply(c.reader)
+}
+
+func writeCommand(w io.Writer, args []string) error {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, arg...)
+		buf = append(buf, '\r', '\n')
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func readReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, errNilReply
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, errNilReply
+		}
+		values := make([]interface{}, size)
+		for idx := range values {
+			values[idx], err = readReply(reader)
+			if err != nil && err != errNilReply {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
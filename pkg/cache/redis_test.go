@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRedis is a local stand-in for a redis server that understands the
+// handful of commands the backend sends.
+type fakeRedis struct {
+	listener net.Listener
+	password string
+
+	mu     sync.Mutex
+	values map[string]string
+	ttls   map[string]time.Duration
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	f := &fakeRedis{
+		listener: listener,
+		password: password,
+		values:   make(map[string]string),
+		ttls:     make(map[string]time.Duration),
+	}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authed := f.password == ""
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		args := make([]string, 0)
+		for _, arg := range reply.([]interface{}) {
+			args = append(args, string(arg.([]byte)))
+		}
+
+		command := strings.ToUpper(args[0])
+		if !authed && command != "AUTH" {
+			conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+			continue
+		}
+
+		f.mu.Lock()
+		switch command {
+		case "AUTH":
+			if args[1] != f.password {
+				conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+				break
+			}
+			authed = true
+			conn.Write([]byte("+OK\r\n"))
+		case "SET":
+			f.values[args[1]] = args[2]
+			if len(args) == 5 && strings.ToUpper(args[3]) == "PX" {
+				ms, _ := strconv.Atoi(args[4])
+				f.ttls[args[1]] = time.Duration(ms) * time.Millisecond
+			}
+			conn.Write([]byte("+OK\r\n"))
+		case "GET":
+			value, ok := f.values[args[1]]
+			if !ok {
+				conn.Write([]byte("$-1\r\n"))
+				break
+			}
+			conn.Write([]byte("$" + strconv.Itoa(len(value)) + "\r\n" + value + "\r\n"))
+		default:
+			conn.Write([]byte("-ERR unknown command '" + args[0] + "'\r\n"))
+		}
+		f.mu.Unlock()
+	}
+}
+
+func TestRedis(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		password string
+		opts     []RedisOption
+		wantErr  bool
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:     "success: with auth",
+			password: "secret",
+			opts:     []RedisOption{WithRedisAuth("secret")},
+		},
+		{
+			name:     "failed: wrong password",
+			password: "secret",
+			opts:     []RedisOption{WithRedisAuth("wrong")},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeRedis(t, tt.password)
+			r := NewRedis(server.listener.Addr().String(), tt.opts...)
+			defer r.Close()
+
+			_, hit, err := r.Get(ctx, "key")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, hit)
+
+			value := []byte("{\"tracks\":\r\n[]}")
+			assert.NoError(t, r.Set(ctx, "key", value, 90*time.Second))
+
+			got, hit, err := r.Get(ctx, "key")
+			assert.NoError(t, err)
+			assert.True(t, hit)
+			assert.Equal(t, value, got)
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+			assert.Equal(t, 90*time.Second, server.ttls["key"])
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package encryption seals small secrets, such as third party refresh
+// tokens, before they are stored. It uses AES-256-GCM under a keyring so
+// keys can be rotated: the first key seals, every key opens.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const KeySize = 32
+
+var ErrDecrypt = errors.New("encryption: cannot decrypt value")
+
+type Key struct {
+	// ID is stored with every sealed value to find the key opening it.
+	ID string
+	// Secret is KeySize random bytes.
+	Secret []byte
+}
+
+type Keyring struct {
+	primary string
+	aeads   map[string]cipher.AEAD
+}
+
+// NewKeyring seals with the first key and opens with any of them.
+func NewKeyring(keys ...Key) (*Keyring, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("encryption: at least one key is required")
+	}
+	k := &Keyring{
+		primary: keys[0].ID,
+		aeads:   make(map[string]cipher.AEAD, len(keys)),
+	}
+	for _, key := range keys {
+		if key.ID == "" || strings.Contains(key.ID, ":") {
+			return nil, fmt.Errorf("encryption: invalid key id %q", key.ID)
+		}
+		if _, ok := k.aeads[key.ID]; ok {
+			return nil, fmt.Errorf("encryption: duplicate key id %q", key.ID)
+		}
+		if len(key.Secret) != KeySize {
+			return nil, fmt.Errorf("encryption: key %q must be %d bytes", key.ID, KeySize)
+		}
+		block, err := aes.NewCipher(key.Secret)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		k.aeads[key.ID] = aead
+	}
+	return k, nil
+}
+
+// ParseKey decodes a base64 secret as found in config files.
+func ParseKey(id, secret string) (Key, error) {
+	raw, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return Key{}, fmt.Errorf("encryption: key %q is not base64: %w", id, err)
+	}
+	return Key{ID: id, Secret: raw}, nil
+}
+
+// Encrypt seals plaintext as "<key id>:<base64 nonce and ciphertext>".
+// The same associated data has to be given to Decrypt, bind it to the owner
+// of the value so a sealed value can't be moved to another row.
+func (k *Keyring) Encrypt(plaintext, associatedData []byte) (string, error) {
+	aead := k.aeads[k.primary]
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, associatedData)
+	return k.primary + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (k *Keyring) Decrypt(value string, associatedData []byte) ([]byte, error) {
+	keyID, encoded, ok := strings.Cut(value, ":")
+	if !ok {
+		return nil, ErrDecrypt
+	}
+	aead, ok := k.aeads[keyID]
+	if !ok {
+		return nil, ErrDecrypt
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,87 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testKey(id string, fill byte) Key {
+	return Key{ID: id, Secret: bytes.Repeat([]byte{fill}, KeySize)}
+}
+
+func TestKeyring(t *testing.T) {
+	keyring, err := NewKeyring(testKey("k1", 1))
+	assert.NoError(t, err)
+
+	sealed, err := keyring.Encrypt([]byte("refresh token"), []byte("user:1"))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, "k1:"))
+	assert.NotContains(t, sealed, "refresh token")
+
+	other, err := keyring.Encrypt([]byte("refresh token"), []byte("user:1"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, sealed, other, "nonces must differ")
+
+	plaintext, err := keyring.Decrypt(sealed, []byte("user:1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "refresh token", string(plaintext))
+
+	_, err = keyring.Decrypt(sealed, []byte("user:2"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = keyring.Decrypt("k1:"+strings.Repeat("A", 40), []byte("user:1"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = keyring.Decrypt("k2:"+strings.TrimPrefix(sealed, "k1:"), []byte("user:1"))
+	assert.ErrorIs(t, err, ErrDecrypt)
+	_, err = keyring.Decrypt("garbage", nil)
+	assert.ErrorIs(t, err, ErrDecrypt)
+}
+
+func TestKeyring_rotation(t *testing.T) {
+	old, err := NewKeyring(testKey("k1", 1))
+	assert.NoError(t, err)
+	sealed, err := old.Encrypt([]byte("secret"), nil)
+	assert.NoError(t, err)
+
+	rotated, err := NewKeyring(testKey("k2", 2), testKey("k1", 1))
+	assert.NoError(t, err)
+
+	plaintext, err := rotated.Decrypt(sealed, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	resealed, err := rotated.Encrypt(plaintext, nil)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resealed, "k2:"))
+}
+
+func TestNewKeyring_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []Key
+	}{
+		{name: "no keys"},
+		{name: "empty id", keys: []Key{testKey("", 1)}},
+		{name: "id with colon", keys: []Key{testKey("a:b", 1)}},
+		{name: "duplicate id", keys: []Key{testKey("k1", 1), testKey("k1", 2)}},
+		{name: "short secret", keys: []Key{{ID: "k1", Secret: []byte("short")}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKeyring(tt.keys...)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey("k1", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, KeySize)))
+	assert.NoError(t, err)
+	assert.Equal(t, testKey("k1", 1), key)
+
+	_, err = ParseKey("k1", "not base64!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package fakespotify
+
+import "fmt"
+
+// NewTrack builds a catalogue track with the derived fields (href, uri,
+// external ids, images) filled in, so seeds only need the interesting parts.
+func NewTrack(id, name string, album Album, artists ...Artist) Track {
+	if album.Type == "" {
+		album.Type = "album"
+	}
+	if album.AlbumType == "" {
+		album.AlbumType = "album"
+	}
+	if album.Href == "" {
+		album.Href = fmt.Sprintf("https://api.spotify.com/v1/albums/%s", album.ID)
+	}
+	if album.URI == "" {
+		album.URI = fmt.Sprintf("spotify:album:%s", album.ID)
+	}
+	if album.Images == nil {
+		album.Images = []Image{
+			{Height: 640, Width: 640, URL: fmt.Sprintf("https://i.scdn.co/image/%s-640", album.ID)},
+			{Height: 300, Width: 300, URL: fmt.Sprintf("https://i.scdn.co/image/%s-300", album.ID)},
+			{Height: 64, Width: 64, URL: fmt.Sprintf("https://i.scdn.co/image/%s-64", album.ID)},
+		}
+	}
+	if album.ReleaseDatePrecision == "" && album.ReleaseDate != "" {
+		album.ReleaseDatePrecision = "day"
+	}
+	for idx := range artists {
+		artists[idx] = NewArtist(artists[idx].ID, artists[idx].Name)
+	}
+	if album.Artists == nil {
+		album.Artists = artists
+	}
+
+	return Track{
+		Album:        album,
+		Artists:      artists,
+		DiscNumber:   1,
+		DurationMs:   180000,
+		ExternalIDs:  map[string]string{"isrc": fmt.Sprintf("FAKE%s", id)},
+		ExternalURLs: map[string]string{"spotify": fmt.Sprintf("https://open.spotify.com/track/%s", id)},
+		Href:         fmt.Sprintf("https://api.spotify.com/v1/tracks/%s", id),
+		ID:           id,
+		Name:         name,
+		Popularity:   50,
+		TrackNumber:  1,
+		Type:         "track",
+		URI:          fmt.Sprintf("spotify:track:%s", id),
+		IsPlayable:   true,
+	}
+}
+
+// NewArtist builds an artist object with href and uri derived from id.
+func NewArtist(id, name string) Artist {
+	return Artist{
+		Href: fmt.Sprintf("https://api.spotify.com/v1/artists/%s", id),
+		ID:   id,
+		Name: name,
+		Type: "artist",
+		URI:  fmt.Sprintf("spotify:artist:%s", id),
+	}
+}
+
+// NewUser builds a spotify user with the derived fields filled in.
+func NewUser(id, displayName, email string) User {
+	return User{
+		ID:          id,
+		DisplayName: displayName,
+		Email:       email,
+		Type:        "user",
+		URI:         fmt.Sprintf("spotify:user:%s", id),
+	}
+}
+
+// DefaultUser is the user approving authorization requests unless another
+// one is set.
+func DefaultUser() User {
+	return NewUser("fakeuser", "Fake User", "fakeuser@example.com")
+}
+
+// DefaultCatalog returns a small catalogue that is good enough to click
+// around the service locally.
+func DefaultCatalog() []Track {
+	queen := Artist{ID: "1dfeR4HaWDbWqFHLkxsg1d", Name: "Queen"}
+	bowie := Artist{ID: "0oSGxfWSnnOXhD2fKuz2Gy", Name: "David Bowie"}
+	daftPunk := Artist{ID: "4tZwfgrHOc3mvqYlEYSvVi", Name: "Daft Punk"}
+	radiohead := Artist{ID: "4Z8W4fKeB5YxbusRsdQVPb", Name: "Radiohead"}
+
+	bohemianOST := Album{ID: "6i6folBtxKV28WX3msQ4FE", Name: "Bohemian Rhapsody (The Original Soundtrack)", ReleaseDate: "2018-10-19", TotalTracks: 22}
+	opera := Album{ID: "1GbtB4zTqAsyfZEsm1RZfx", Name: "A Night At The Opera (2011 Remaster)", ReleaseDate: "1975-11-21", TotalTracks: 12}
+	jazz := Album{ID: "2yuTRGIackbcReLUXOYBqU", Name: "Jazz (2011 Remaster)", ReleaseDate: "1978-11-10", TotalTracks: 13}
+	hotSpace := Album{ID: "0lDxmRsEdGcgwF6UTJpNGd", Name: "Hot Space (2011 Remaster)", ReleaseDate: "1982-05-21", TotalTracks: 11}
+	discovery := Album{ID: "2noRn2Aes5aoNVsU6iWThc", Name: "Discovery", ReleaseDate: "2001-03-12", TotalTracks: 14}
+	okComputer := Album{ID: "6dVIqQ8qmQ5GBnJ9shOYGE", Name: "OK Computer", ReleaseDate: "1997-05-21", TotalTracks: 12}
+
+	return []Track{
+		NewTrack("3z8h0TU7ReDPLIbEnYhWZb", "Bohemian Rhapsody", bohemianOST, queen),
+		NewTrack("4u7EnebtmKWzUH433cf5Qv", "Bohemian Rhapsody - Remastered 2011", opera, queen),
+		NewTrack("5T8EDUDqKcs6OSOwEsfqG7", "Don't Stop Me Now - Remastered 2011", jazz, queen),
+		NewTrack("11IzgLRXV7Cgek3tEgGgjw", "Under Pressure - Remastered 2011", hotSpace, queen, bowie),
+		NewTrack("0DiWol3AO6WpXZgp0goxAV", "One More Time", discovery, daftPunk),
+		NewTrack("2VEZx7NWsZ1D0eJ4uv5Fym", "Harder, Better, Faster, Stronger", discovery, daftPunk),
+		NewTrack("6LgJvl0Xdtc73RJ1mmpotq", "Paranoid Android", okComputer, radiohead),
+		NewTrack("2CVV8PtUYYsux8XOzWkCP0", "Karma Police", okComputer, radiohead),
+	}
+}
@@ -0,0 +1,175 @@
+package fakespotify
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// libraryEntry is a track in the liked songs of a user.
+type libraryEntry struct {
+	trackID string
+	addedAt time.Time
+}
+
+// WithSavedTracks seeds the liked songs of a user, newest first.
+func WithSavedTracks(userID string, trackIDs ...string) Option {
+	return func(s *Server) {
+		s.saveTracks(userID, time.Now().UTC(), trackIDs)
+	}
+}
+
+// SaveTracks likes tracks for a user as the spotify app would.
+func (s *Server) SaveTracks(userID string, trackIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saveTracks(userID, time.Now().UTC(), trackIDs)
+}
+
+// RemoveTracks unlikes tracks for a user as the spotify app would.
+func (s *Server) RemoveTracks(userID string, trackIDs ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeTracks(userID, trackIDs)
+}
+
+// SavedTracks returns the liked songs of a user, most recently added first.
+func (s *Server) SavedTracks(userID string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	ids := make([]string, 0, len(s.libraries[userID]))
+	for _, entry := range s.libraries[userID] {
+		ids = append(ids, entry.trackID)
+	}
+	return ids
+}
+
+// AuthorizeUser returns a refresh token for the user, skipping the code flow.
+func (s *Server) AuthorizeUser(userID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	refreshToken := randomString()
+	s.refreshTokens[refreshToken] = userID
+	return refreshToken
+}
+
+func (s *Server) saveTracks(userID string, addedAt time.Time, trackIDs []string) {
+	saved := make(map[string]bool, len(trackIDs))
+	entries := make([]libraryEntry, 0, len(trackIDs)+len(s.libraries[userID]))
+	for _, id := range trackIDs {
+		if !saved[id] {
+			saved[id] = true
+			entries = append(entries, libraryEntry{trackID: id, addedAt: addedAt})
+		}
+	}
+	for _, entry := range s.libraries[userID] {
+		if !saved[entry.trackID] {
+			entries = append(entries, entry)
+		}
+	}
+	s.libraries[userID] = entries
+}
+
+func (s *Server) removeTracks(userID string, trackIDs []string) {
+	removed := make(map[string]bool, len(trackIDs))
+	for _, id := range trackIDs {
+		removed[id] = true
+	}
+	entries := make([]libraryEntry, 0, len(s.libraries[userID]))
+	for _, entry := range s.libraries[userID] {
+		if !removed[entry.trackID] {
+			entries = append(entries, entry)
+		}
+	}
+	s.libraries[userID] = entries
+}
+
+// handleSaveTracks likes tracks, already liked ones keep their added time.
+func (s *Server) handleSaveTracks(w http.ResponseWriter, r *http.Request, user User) {
+	ids := splitIDs(r.URL.Query().Get("ids"))
+	if len(ids) == 0 || len(ids) > 50 {
+		writeError(w, http.StatusBadRequest, "Invalid ids")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	saved := make(map[string]bool, len(s.libraries[user.ID]))
+	for _, entry := range s.libraries[user.ID] {
+		saved[entry.trackID] = true
+	}
+	added := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !saved[id] {
+			added = append(added, id)
+		}
+	}
+	s.saveTracks(user.ID, time.Now().UTC(), added)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleRemoveSavedTracks(w http.ResponseWriter, r *http.Request, user User) {
+	ids := splitIDs(r.URL.Query().Get("ids"))
+	if len(ids) == 0 || len(ids) > 50 {
+		writeError(w, http.StatusBadRequest, "Invalid ids")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.removeTracks(user.ID, ids)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleSavedTracks(w http.ResponseWriter, r *http.Request, user User) {
+	query := r.URL.Query()
+	limit, ok := intParam(query, "limit", 20, 1, 50)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+	offset, ok := intParam(query, "offset", 0, 0, 100000)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid offset")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	library := s.libraries[user.ID]
+	total := len(library)
+	start := min(offset, total)
+	end := min(offset+limit, total)
+
+	items := make([]savedTrack, 0, end-start)
+	for _, entry := range library[start:end] {
+		track, ok := s.find(entry.trackID)
+		if !ok {
+			track = Track{ID: entry.trackID, Type: "track", URI: "spotify:track:" + entry.trackID}
+		}
+		items = append(items, savedTrack{
+			AddedAt: entry.addedAt.Format(time.RFC3339),
+			Track:   track,
+		})
+	}
+
+	pageURL := func(offset int) string {
+		return fmt.Sprintf("%s%s?offset=%s&limit=%d", s.URL, r.URL.Path, strconv.Itoa(offset), limit)
+	}
+	var next *string
+	if end < total {
+		nextURL := pageURL(end)
+		next = &nextURL
+	}
+	writeJSON(w, http.StatusOK, savedTracksResponse{
+		Href:   pageURL(offset),
+		Items:  items,
+		Limit:  limit,
+		Next:   next,
+		Offset: offset,
+		Total:  total,
+	})
+}
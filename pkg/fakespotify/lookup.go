@@ -0,0 +1,158 @@
+package fakespotify
+
+import (
+	"net/http"
+)
+
+func (s *Server) handleTrack(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	track, ok := s.find(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Non existing id")
+		return
+	}
+	writeJSON(w, http.StatusOK, track)
+}
+
+func (s *Server) handleArtist(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	artistID := r.PathValue("id")
+	tracks := s.artistTracks(artistID)
+	if len(tracks) == 0 {
+		writeError(w, http.StatusNotFound, "Non existing id")
+		return
+	}
+
+	var artist Artist
+	for _, item := range tracks[0].Artists {
+		if item.ID == artistID {
+			artist = item
+		}
+	}
+	writeJSON(w, http.StatusOK, artistResponse{
+		Followers:  followers{Total: 1000 * len(tracks)},
+		Genres:     []string{},
+		Href:       artist.Href,
+		ID:         artist.ID,
+		Images:     tracks[0].Album.Images,
+		Name:       artist.Name,
+		Popularity: tracks[0].Popularity,
+		Type:       artist.Type,
+		URI:        artist.URI,
+	})
+}
+
+func (s *Server) handleArtistTopTracks(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("market") == "" {
+		writeError(w, http.StatusBadRequest, "Missing market")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tracks := s.artistTracks(r.PathValue("id"))
+	if len(tracks) == 0 {
+		writeError(w, http.StatusNotFound, "Non existing id")
+		return
+	}
+	if len(tracks) > 10 {
+		tracks = tracks[:10]
+	}
+	writeJSON(w, http.StatusOK, recommendationResponse{Tracks: tracks})
+}
+
+func (s *Server) handleAlbum(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tracks := s.albumTracks(r.PathValue("id"))
+	if len(tracks) == 0 {
+		writeError(w, http.StatusNotFound, "Non existing id")
+		return
+	}
+
+	album := s.mustFind(tracks[0].ID).Album
+	writeJSON(w, http.StatusOK, albumResponse{
+		Album:      album,
+		Label:      "Fake Records",
+		Popularity: 50,
+		Tracks:     s.page(r, tracks, 50, 0),
+	})
+}
+
+func (s *Server) handleAlbumTracks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, ok := intParam(query, "limit", 20, 1, 50)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+	offset, ok := intParam(query, "offset", 0, 0, 1000)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid offset")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tracks := s.albumTracks(r.PathValue("id"))
+	if len(tracks) == 0 {
+		writeError(w, http.StatusNotFound, "Non existing id")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.page(r, tracks, limit, offset))
+}
+
+func (s *Server) artistTracks(artistID string) []Track {
+	tracks := make([]Track, 0)
+	for _, track := range s.tracks {
+		if sharesArtist(track, map[string]bool{artistID: true}) {
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+// albumTracks returns the simplified tracks of an album, spotify leaves the
+// album out of those.
+func (s *Server) albumTracks(albumID string) []Track {
+	tracks := make([]Track, 0)
+	for _, track := range s.tracks {
+		if track.Album.ID == albumID {
+			track.Album = Album{}
+			tracks = append(tracks, track)
+		}
+	}
+	return tracks
+}
+
+func (s *Server) mustFind(id string) Track {
+	track, _ := s.find(id)
+	return track
+}
+
+func (s *Server) handleTracks(w http.ResponseWriter, r *http.Request) {
+	ids := splitIDs(r.URL.Query().Get("ids"))
+	if len(ids) == 0 || len(ids) > 50 {
+		writeError(w, http.StatusBadRequest, "Invalid ids")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// unknown ids come back as null, in place
+	tracks := make([]*Track, len(ids))
+	for idx, id := range ids {
+		if track, ok := s.find(id); ok {
+			tracks[idx] = &track
+		}
+	}
+	writeJSON(w, http.StatusOK, severalTracksResponse{Tracks: tracks})
+}
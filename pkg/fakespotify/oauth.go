@@ -0,0 +1,141 @@
+package fakespotify
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// authCode is an authorization code handed out by /authorize.
+type authCode struct {
+	userID        string
+	redirectURI   string
+	codeChallenge string
+	scope         string
+}
+
+// handleAuthorize approves every request right away on behalf of the
+// configured user, as if they had clicked "agree".
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || !redirectURI.IsAbs() {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request", "error_description": "Invalid redirect URI"})
+		return
+	}
+	if s.clientID != "" && query.Get("client_id") != s.clientID {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	values := redirectURI.Query()
+	values.Set("state", query.Get("state"))
+	switch {
+	case query.Get("response_type") != "code":
+		values.Set("error", "unsupported_response_type")
+	case query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "":
+		values.Set("error", "invalid_request")
+	default:
+		code := randomString()
+		s.mu.Lock()
+		s.authCodes[code] = authCode{
+			userID:        s.currentUser,
+			redirectURI:   query.Get("redirect_uri"),
+			codeChallenge: query.Get("code_challenge"),
+			scope:         query.Get("scope"),
+		}
+		s.mu.Unlock()
+		values.Set("code", code)
+	}
+	redirectURI.RawQuery = values.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) exchangeCode(w http.ResponseWriter, r *http.Request) {
+	if s.clientID != "" && r.PostForm.Get("client_id") != s.clientID {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// codes work once, a second exchange fails like on spotify
+	code, ok := s.authCodes[r.PostForm.Get("code")]
+	delete(s.authCodes, r.PostForm.Get("code"))
+	if !ok || code.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "Invalid authorization code"})
+		return
+	}
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "code_verifier was incorrect"})
+		return
+	}
+
+	accessToken, refreshToken := randomString(), randomString()
+	s.userTokens[accessToken] = code.userID
+	s.refreshTokens[refreshToken] = code.userID
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    s.expiresIn,
+		RefreshToken: refreshToken,
+		Scope:        code.scope,
+	})
+}
+
+// refreshUserToken hands out a new access token for a refresh token. The
+// refresh token stays the same, spotify only sometimes rotates it.
+func (s *Server) refreshUserToken(w http.ResponseWriter, r *http.Request) {
+	if s.clientID != "" && r.PostForm.Get("client_id") != s.clientID {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userID, ok := s.refreshTokens[r.PostForm.Get("refresh_token")]
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "Invalid refresh token"})
+		return
+	}
+	accessToken := randomString()
+	s.userTokens[accessToken] = userID
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   s.expiresIn,
+	})
+}
+
+// userAuthorized accepts the access tokens of users, handed out by the code
+// exchange, instead of the client credentials token.
+func (s *Server) userAuthorized(next func(w http.ResponseWriter, r *http.Request, user User)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		accessToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		s.mu.RLock()
+		user, known := s.users[s.userTokens[accessToken]]
+		s.mu.RUnlock()
+		if !ok || !known {
+			writeError(w, http.StatusUnauthorized, "Invalid access token")
+			return
+		}
+		next(w, r, user)
+	}
+}
+
+func (s *Server) handleMe(w http.ResponseWriter, r *http.Request, user User) {
+	writeJSON(w, http.StatusOK, user)
+}
+
+func randomString() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
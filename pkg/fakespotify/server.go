@@ -0,0 +1,383 @@
+// Package fakespotify is an in-process stand-in for the Spotify accounts
+// service and web api. It serves a seedable track catalogue so the service
+// can be run and tested without network access.
+package fakespotify
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultAccessToken = "fake-access-token"
+	defaultExpiresIn   = 3600
+)
+
+type Server struct {
+	*httptest.Server
+
+	mu           sync.RWMutex
+	tracks       []Track
+	clientID     string
+	clientSecret string
+	accessToken  string
+	expiresIn    int
+
+	users       map[string]User
+	currentUser string
+	authCodes   map[string]authCode
+	// userTokens and refreshTokens map tokens to the user they act for.
+	userTokens    map[string]string
+	refreshTokens map[string]string
+	libraries     map[string][]libraryEntry
+}
+
+// Option define an option for the fake server.
+type Option func(*Server)
+
+// WithTracks seeds the catalogue with the given tracks.
+func WithTracks(tracks ...Track) Option {
+	return func(s *Server) {
+		s.tracks = append(s.tracks, tracks...)
+	}
+}
+
+// WithClientCredentials makes the token endpoint reject any other client.
+func WithClientCredentials(clientID, clientSecret string) Option {
+	return func(s *Server) {
+		s.clientID = clientID
+		s.clientSecret = clientSecret
+	}
+}
+
+// WithAccessToken set the access token handed out by the token endpoint.
+func WithAccessToken(accessToken string, expiresIn int) Option {
+	return func(s *Server) {
+		s.accessToken = accessToken
+		s.expiresIn = expiresIn
+	}
+}
+
+// WithUser adds a spotify user and makes it the one approving
+// authorization requests.
+func WithUser(user User) Option {
+	return func(s *Server) {
+		s.users[user.ID] = user
+		s.currentUser = user.ID
+	}
+}
+
+// NewServer starts a fake spotify server. Callers must Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		accessToken: defaultAccessToken,
+		expiresIn:   defaultExpiresIn,
+		users:       make(map[string]User),
+		authCodes:   make(map[string]authCode),
+		userTokens:  make(map[string]string),
+
+		refreshTokens: make(map[string]string),
+		libraries:     make(map[string][]libraryEntry),
+	}
+	WithUser(DefaultUser())(s)
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /authorize", s.handleAuthorize)
+	mux.HandleFunc("POST /api/token", s.handleToken)
+	mux.HandleFunc("GET /v1/me", s.userAuthorized(s.handleMe))
+	mux.HandleFunc("GET /v1/me/tracks", s.userAuthorized(s.handleSavedTracks))
+	mux.HandleFunc("PUT /v1/me/tracks", s.userAuthorized(s.handleSaveTracks))
+	mux.HandleFunc("DELETE /v1/me/tracks", s.userAuthorized(s.handleRemoveSavedTracks))
+	mux.HandleFunc("GET /v1/search", s.authorized(s.handleSearch))
+	mux.HandleFunc("GET /v1/recommendations", s.authorized(s.handleRecommendations))
+	mux.HandleFunc("GET /v1/tracks", s.authorized(s.handleTracks))
+	mux.HandleFunc("GET /v1/tracks/{id}", s.authorized(s.handleTrack))
+	mux.HandleFunc("GET /v1/artists/{id}", s.authorized(s.handleArtist))
+	mux.HandleFunc("GET /v1/artists/{id}/top-tracks", s.authorized(s.handleArtistTopTracks))
+	mux.HandleFunc("GET /v1/albums/{id}", s.authorized(s.handleAlbum))
+	mux.HandleFunc("GET /v1/albums/{id}/tracks", s.authorized(s.handleAlbumTracks))
+
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// APIBaseURL is the value for `SpotifyConfig.APIBaseURL`.
+func (s *Server) APIBaseURL() string {
+	return s.URL + "/v1"
+}
+
+// AccountsBaseURL is the value for `SpotifyConfig.AccountsBaseURL`.
+func (s *Server) AccountsBaseURL() string {
+	return s.URL
+}
+
+// SetUser adds a spotify user and makes it the one approving authorization
+// requests from now on, like logging into another account in the browser.
+func (s *Server) SetUser(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.users[user.ID] = user
+	s.currentUser = user.ID
+}
+
+// Seed appends tracks to the catalogue.
+func (s *Server) Seed(tracks ...Track) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tracks = append(s.tracks, tracks...)
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	switch r.PostForm.Get("grant_type") {
+	case "client_credentials":
+	case "authorization_code":
+		s.exchangeCode(w, r)
+		return
+	case "refresh_token":
+		s.refreshUserToken(w, r)
+		return
+	default:
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if !ok {
+		clientID = r.PostForm.Get("client_id")
+		clientSecret = r.PostForm.Get("client_secret")
+	}
+	if s.clientID != "" && (clientID != s.clientID || clientSecret != s.clientSecret) {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken: s.accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   s.expiresIn,
+	})
+}
+
+func (s *Server) authorized(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.accessToken {
+			writeError(w, http.StatusUnauthorized, "Invalid access token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("q") == "" {
+		writeError(w, http.StatusBadRequest, "No search query")
+		return
+	}
+	if !strings.Contains(query.Get("type"), "track") {
+		writeError(w, http.StatusBadRequest, "Unsupported type")
+		return
+	}
+	limit, ok := intParam(query, "limit", 20, 0, 50)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+	offset, ok := intParam(query, "offset", 0, 0, 1000)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid offset")
+		return
+	}
+
+	terms := strings.Fields(strings.ToLower(query.Get("q")))
+	matches := make([]Track, 0)
+	s.mu.RLock()
+	for _, track := range s.tracks {
+		if matchesAll(searchText(track), terms) {
+			matches = append(matches, track)
+		}
+	}
+	s.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, searchResponse{
+		Tracks: s.page(r, matches, limit, offset),
+	})
+}
+
+func (s *Server) handleRecommendations(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, ok := intParam(query, "limit", 20, 1, 100)
+	if !ok {
+		writeError(w, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	seeds := splitIDs(query.Get("seed_tracks"))
+	artistSeeds := splitIDs(query.Get("seed_artists"))
+	genreSeeds := splitIDs(query.Get("seed_genres"))
+	seedCount := len(seeds) + len(artistSeeds) + len(genreSeeds)
+	if seedCount == 0 {
+		writeError(w, http.StatusBadRequest, "Missing seed")
+		return
+	}
+	if seedCount > 5 {
+		writeError(w, http.StatusBadRequest, "Too many seeds")
+		return
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// the catalogue carries no genres or audio features, so genre seeds and
+	// tunables are accepted but don't change the result.
+	seedIDs := make(map[string]bool, len(seeds))
+	seedArtists := make(map[string]bool)
+	for _, id := range artistSeeds {
+		seedArtists[id] = true
+	}
+	for _, id := range seeds {
+		seedIDs[id] = true
+		if track, ok := s.find(id); ok {
+			for _, artist := range track.Artists {
+				seedArtists[artist.ID] = true
+			}
+		}
+	}
+
+	// tracks sharing an artist with the seeds come first, then the rest of
+	// the catalogue, so results are deterministic for a given seed.
+	related := make([]Track, 0)
+	others := make([]Track, 0)
+	for _, track := range s.tracks {
+		if seedIDs[track.ID] {
+			continue
+		}
+		if sharesArtist(track, seedArtists) {
+			related = append(related, track)
+		} else {
+			others = append(others, track)
+		}
+	}
+	tracks := append(related, others...)
+	if len(tracks) > limit {
+		tracks = tracks[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, recommendationResponse{Tracks: tracks})
+}
+
+func (s *Server) find(id string) (Track, bool) {
+	for _, track := range s.tracks {
+		if track.ID == id {
+			return track, true
+		}
+	}
+	return Track{}, false
+}
+
+func (s *Server) page(r *http.Request, items []Track, limit, offset int) pagingResponse {
+	total := len(items)
+	start := min(offset, total)
+	end := min(offset+limit, total)
+
+	pageURL := func(offset int) string {
+		query := r.URL.Query()
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(limit))
+		return fmt.Sprintf("%s%s?%s", s.URL, r.URL.Path, query.Encode())
+	}
+
+	var next, previous *string
+	if end < total {
+		nextURL := pageURL(end)
+		next = &nextURL
+	}
+	if start > 0 {
+		previousURL := pageURL(max(start-limit, 0))
+		previous = &previousURL
+	}
+
+	return pagingResponse{
+		Href:     pageURL(offset),
+		Items:    items[start:end],
+		Limit:    limit,
+		Next:     next,
+		Offset:   offset,
+		Previous: previous,
+		Total:    total,
+	}
+}
+
+func searchText(track Track) string {
+	parts := []string{track.Name, track.Album.Name}
+	for _, artist := range track.Artists {
+		parts = append(parts, artist.Name)
+	}
+	return strings.ToLower(strings.Join(parts, " "))
+}
+
+func matchesAll(text string, terms []string) bool {
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+func sharesArtist(track Track, artistIDs map[string]bool) bool {
+	for _, artist := range track.Artists {
+		if artistIDs[artist.ID] {
+			return true
+		}
+	}
+	return false
+}
+
+func splitIDs(value string) []string {
+	ids := make([]string, 0)
+	for _, id := range strings.Split(value, ",") {
+		id = strings.TrimSpace(id)
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func intParam(query url.Values, key string, defaultValue, minValue, maxValue int) (int, bool) {
+	raw := query.Get(key)
+	if raw == "" {
+		return defaultValue, true
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < minValue || value > maxValue {
+		return 0, false
+	}
+	return value, true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{
+		Error: errorObject{Status: status, Message: message},
+	})
+}
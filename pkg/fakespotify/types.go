@@ -0,0 +1,133 @@
+package fakespotify
+
+type Track struct {
+	Album        Album             `json:"album"`
+	Artists      []Artist          `json:"artists"`
+	DiscNumber   int               `json:"disc_number"`
+	DurationMs   int               `json:"duration_ms"`
+	Explicit     bool              `json:"explicit"`
+	ExternalIDs  map[string]string `json:"external_ids"`
+	Href         string            `json:"href"`
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	Popularity   int               `json:"popularity"`
+	PreviewURL   *string           `json:"preview_url"`
+	TrackNumber  int               `json:"track_number"`
+	Type         string            `json:"type"`
+	URI          string            `json:"uri"`
+	IsLocal      bool              `json:"is_local"`
+	IsPlayable   bool              `json:"is_playable"`
+	ExternalURLs map[string]string `json:"external_urls"`
+}
+
+type Album struct {
+	AlbumType            string   `json:"album_type"`
+	Artists              []Artist `json:"artists"`
+	Href                 string   `json:"href"`
+	ID                   string   `json:"id"`
+	Images               []Image  `json:"images"`
+	Name                 string   `json:"name"`
+	ReleaseDate          string   `json:"release_date"`
+	ReleaseDatePrecision string   `json:"release_date_precision"`
+	TotalTracks          int      `json:"total_tracks"`
+	Type                 string   `json:"type"`
+	URI                  string   `json:"uri"`
+}
+
+type Artist struct {
+	Href string `json:"href"`
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	URI  string `json:"uri"`
+}
+
+type Image struct {
+	Height int    `json:"height"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+}
+
+type User struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	Email       string `json:"email"`
+	Type        string `json:"type"`
+	URI         string `json:"uri"`
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+type pagingResponse struct {
+	Href     string  `json:"href"`
+	Items    []Track `json:"items"`
+	Limit    int     `json:"limit"`
+	Next     *string `json:"next"`
+	Offset   int     `json:"offset"`
+	Previous *string `json:"previous"`
+	Total    int     `json:"total"`
+}
+
+type searchResponse struct {
+	Tracks pagingResponse `json:"tracks"`
+}
+
+type recommendationResponse struct {
+	Tracks []Track `json:"tracks"`
+}
+
+type errorObject struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+type errorResponse struct {
+	Error errorObject `json:"error"`
+}
+
+type artistResponse struct {
+	Followers  followers `json:"followers"`
+	Genres     []string  `json:"genres"`
+	Href       string    `json:"href"`
+	ID         string    `json:"id"`
+	Images     []Image   `json:"images"`
+	Name       string    `json:"name"`
+	Popularity int       `json:"popularity"`
+	Type       string    `json:"type"`
+	URI        string    `json:"uri"`
+}
+
+type followers struct {
+	Total int `json:"total"`
+}
+
+type albumResponse struct {
+	Album
+	Label      string         `json:"label"`
+	Popularity int            `json:"popularity"`
+	Tracks     pagingResponse `json:"tracks"`
+}
+
+type severalTracksResponse struct {
+	Tracks []*Track `json:"tracks"`
+}
+
+type savedTrack struct {
+	AddedAt string `json:"added_at"`
+	Track   Track  `json:"track"`
+}
+
+type savedTracksResponse struct {
+	Href   string       `json:"href"`
+	Items  []savedTrack `json:"items"`
+	Limit  int          `json:"limit"`
+	Next   *string      `json:"next"`
+	Offset int          `json:"offset"`
+	Total  int          `json:"total"`
+}
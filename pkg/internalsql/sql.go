@@ -1,6 +1,7 @@
 package internalsql
 
 import (
+	"fmt"
 	"log"
 
 	"gorm.io/driver/postgres"
@@ -8,10 +9,62 @@ import (
 )
 
 func Connect(dataSourceName string) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(dataSourceName), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dataSourceName), &gorm.Config{
+		// report unique violations as gorm.ErrDuplicatedKey
+		TranslateError: true,
+	})
 	if err != nil {
 		log.Fatalf("error connecting to database %+v\n", err)
 		return nil, err
 	}
 	return db, nil
 }
+
+// ForeignKey makes Column of Table reference the id of RefTable, rows go
+// with the row they reference when it is deleted.
+type ForeignKey struct {
+	Table    string
+	Column   string
+	RefTable string
+}
+
+func (fk ForeignKey) name() string {
+	return fmt.Sprintf("fk_%s_%s", fk.Table, fk.Column)
+}
+
+// AddCascadingForeignKey adds fk unless it exists. It fails while rows
+// reference nothing, DeleteOrphans deletes them.
+func AddCascadingForeignKey(db *gorm.DB, fk ForeignKey) error {
+	if db.Migrator().HasConstraint(fk.Table, fk.name()) {
+		return nil
+	}
+	err := db.Exec(fmt.Sprintf(
+		`ALTER TABLE %q ADD CONSTRAINT %q FOREIGN KEY (%q) REFERENCES %q (id) ON DELETE CASCADE`,
+		fk.Table, fk.name(), fk.Column, fk.RefTable,
+	)).Error
+	if err != nil {
+		return fmt.Errorf("add foreign key %s: %w", fk.name(), err)
+	}
+	return nil
+}
+
+// DeleteOrphans deletes the rows of fk.Table that reference nothing, so fk
+// can be added, and logs their ids. It is a one-off run on request, tables
+// that don't exist or already have the key are left alone.
+func DeleteOrphans(db *gorm.DB, fk ForeignKey) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(fk.Table) || migrator.HasConstraint(fk.Table, fk.name()) {
+		return nil
+	}
+
+	var ids []uint
+	err := db.Raw(fmt.Sprintf(
+		`DELETE FROM %q WHERE %q IS NOT NULL AND NOT EXISTS (SELECT 1 FROM %q WHERE %q.id = %q.%q) RETURNING id`,
+		fk.Table, fk.Column, fk.RefTable, fk.RefTable, fk.Table, fk.Column,
+	)).Scan(&ids).Error
+	if err != nil {
+		return err
+	}
+	log.Printf("deleted %d rows of %s without a %s: %v", len(ids), fk.Table, fk.Column, ids)
+	return nil
+}
@@ -0,0 +1,71 @@
+package jwt
+
+import (
+	"errors"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// Errors returned by ValidateToken, the messages are safe to show clients.
+var (
+	ErrTokenMalformed        = errors.New("token is malformed")
+	ErrTokenUnverifiable     = errors.New("token can't be verified")
+	ErrTokenAlgorithm        = errors.New("token signing algorithm is not allowed")
+	ErrTokenSignatureInvalid = errors.New("token signature is invalid")
+	ErrTokenExpired          = errors.New("token is expired")
+	ErrTokenNotValidYet      = errors.New("token is not valid yet")
+	ErrTokenInvalidIssuer    = errors.New("token has an invalid issuer")
+	ErrTokenInvalidAudience  = errors.New("token has an invalid audience")
+	ErrTokenInvalidClaims    = errors.New("token has invalid claims")
+)
+
+// tokenError keeps the underlying cause for logs while matching kind with
+// errors.Is.
+type tokenError struct {
+	kind  error
+	cause error
+}
+
+func (e *tokenError) Error() string {
+	if e.cause == nil {
+		return e.kind.Error()
+	}
+	return e.kind.Error() + ": " + e.cause.Error()
+}
+
+func (e *tokenError) Unwrap() error {
+	return e.kind
+}
+
+func newTokenError(kind, cause error) error {
+	return &tokenError{kind: kind, cause: cause}
+}
+
+// parseError maps the errors of the jwt parser to the errors above.
+func parseError(err error) error {
+	var validationErr *jwt.ValidationError
+	if !errors.As(err, &validationErr) {
+		return newTokenError(ErrTokenMalformed, err)
+	}
+
+	// errors of KeySet.verifyKey are already typed
+	var tokenErr *tokenError
+	if errors.As(validationErr.Inner, &tokenErr) {
+		return tokenErr
+	}
+
+	cause := error(validationErr)
+	if validationErr.Inner != nil {
+		cause = validationErr.Inner
+	}
+	switch {
+	case validationErr.Errors&jwt.ValidationErrorMalformed != 0:
+		return newTokenError(ErrTokenMalformed, cause)
+	case validationErr.Errors&jwt.ValidationErrorUnverifiable != 0:
+		return newTokenError(ErrTokenUnverifiable, cause)
+	case validationErr.Errors&jwt.ValidationErrorSignatureInvalid != 0:
+		return newTokenError(ErrTokenSignatureInvalid, cause)
+	default:
+		return newTokenError(ErrTokenInvalidClaims, cause)
+	}
+}
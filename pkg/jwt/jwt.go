@@ -1,42 +1,133 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 )
 
-func CreateToken(id uint, username, secretKey string) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256,
-		jwt.MapClaims{
-			"id":       id,
-			"username": username,
-			"exp":      time.Now().Add(10 * time.Minute).Unix(),
+const accessTokenTTL = 10 * time.Minute
+
+// Claims are the claims of a validated access token.
+type Claims struct {
+	UserID   uint
+	Username string
+	// ID is the jti, unique per token so a single token can be revoked.
+	ID string
+	// TokenVersion is the user's token version when the token was issued.
+	TokenVersion  uint
+	EmailVerified bool
+	ExpiresAt     time.Time
+}
+
+// tokenClaims is the wire format of the claims.
+type tokenClaims struct {
+	jwt.StandardClaims
+	UserID        uint   `json:"id"`
+	Username      string `json:"username"`
+	TokenVersion  uint   `json:"ver"`
+	EmailVerified bool   `json:"email_verified"`
+}
+
+// CreateToken signs a token with secretKey using HS256.
+func CreateToken(claims Claims, secretKey string) (string, error) {
+	return NewHMACKeySet(secretKey).CreateToken(claims)
+}
+
+// ValidateToken validates a token signed with secretKey using HS256.
+func ValidateToken(tokenStr, secretKey string) (*Claims, error) {
+	return NewHMACKeySet(secretKey).ValidateToken(tokenStr)
+}
+
+// CreateToken signs a token with the newest active key, with a fresh jti and expiry.
+func (ks *KeySet) CreateToken(claims Claims) (string, error) {
+	key, err := ks.signingKey()
+	if err != nil {
+		return "", err
+	}
+	jti, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := ks.now()
+	token := jwt.NewWithClaims(key.method(), tokenClaims{
+		StandardClaims: jwt.StandardClaims{
+			Id:        jti,
+			Issuer:    ks.issuer,
+			Audience:  ks.audience,
+			IssuedAt:  now.Unix(),
+			NotBefore: now.Unix(),
+			ExpiresAt: now.Add(accessTokenTTL).Unix(),
 		},
-	)
+		UserID:        claims.UserID,
+		Username:      claims.Username,
+		TokenVersion:  claims.TokenVersion,
+		EmailVerified: claims.EmailVerified,
+	})
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
 
-	key := []byte(secretKey)
-	tokenStr, err := token.SignedString(key)
+	tokenStr, err := token.SignedString(key.signKey)
 	if err != nil {
 		return "", err
 	}
 	return tokenStr, nil
 }
 
-func ValidateToken(tokenStr, secretKey string) (uint, string, error) {
-	key := []byte(secretKey)
-	claims := jwt.MapClaims{}
+// ValidateToken verifies a token against the key named by its kid header.
+func (ks *KeySet) ValidateToken(tokenStr string) (*Claims, error) {
+	claims := tokenClaims{}
 
-	token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-		return key, nil
-	})
+	// verifyKey enforces the algorithm, the claims are checked below
+	parser := jwt.Parser{SkipClaimsValidation: true}
+	_, err := parser.ParseWithClaims(tokenStr, &claims, ks.verifyKey)
 	if err != nil {
-		return 0, "", err
+		return nil, parseError(err)
 	}
 
-	if !token.Valid {
-		return 0, "", errors.New("invalid token")
+	if err := ks.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return &Claims{
+		UserID:        claims.UserID,
+		Username:      claims.Username,
+		ID:            claims.Id,
+		TokenVersion:  claims.TokenVersion,
+		EmailVerified: claims.EmailVerified,
+		ExpiresAt:     time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+func (ks *KeySet) validateClaims(claims tokenClaims) error {
+	now := ks.now().Unix()
+	switch {
+	case claims.ExpiresAt == 0:
+		return newTokenError(ErrTokenInvalidClaims, errors.New("missing exp"))
+	case now >= claims.ExpiresAt:
+		return ErrTokenExpired
+	case claims.NotBefore != 0 && now < claims.NotBefore:
+		return ErrTokenNotValidYet
+	case ks.issuer != "" && claims.Issuer != ks.issuer:
+		return ErrTokenInvalidIssuer
+	case ks.audience != "" && claims.Audience != ks.audience:
+		return ErrTokenInvalidAudience
+	case claims.UserID == 0:
+		return newTokenError(ErrTokenInvalidClaims, errors.New("missing id"))
+	case claims.Id == "":
+		return newTokenError(ErrTokenInvalidClaims, errors.New("missing jti"))
+	}
+	return nil
+}
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
 	}
-	return uint(claims["id"].(float64)), claims["username"].(string), nil
+	return hex.EncodeToString(buf), nil
 }
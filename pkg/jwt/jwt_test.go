@@ -0,0 +1,164 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeySet_ValidateToken(t *testing.T) {
+	now := time.Now()
+	keySet := NewHMACKeySet("secret", WithIssuer("go-spotify"), WithAudience("api"))
+	keySet.now = func() time.Time { return now }
+
+	validClaims := func() tokenClaims {
+		return tokenClaims{
+			StandardClaims: jwt.StandardClaims{
+				Id:        "jti",
+				Issuer:    "go-spotify",
+				Audience:  "api",
+				NotBefore: now.Unix(),
+				ExpiresAt: now.Add(time.Minute).Unix(),
+			},
+			UserID:   1,
+			Username: "username",
+		}
+	}
+	sign := func(method jwt.SigningMethod, claims jwt.Claims, key interface{}) string {
+		tokenStr, err := jwt.NewWithClaims(method, claims).SignedString(key)
+		assert.NoError(t, err)
+		return tokenStr
+	}
+
+	tests := []struct {
+		name     string
+		tokenStr string
+		wantErr  error
+	}{
+		{
+			name:     "valid",
+			tokenStr: sign(jwt.SigningMethodHS256, validClaims(), []byte("secret")),
+		},
+		{
+			name:     "malformed",
+			tokenStr: "not-a-token",
+			wantErr:  ErrTokenMalformed,
+		},
+		{
+			name:     "id of the wrong type",
+			tokenStr: sign(jwt.SigningMethodHS256, jwt.MapClaims{"id": "1", "jti": "jti", "exp": now.Add(time.Minute).Unix()}, []byte("secret")),
+			wantErr:  ErrTokenMalformed,
+		},
+		{
+			name:     "bad signature",
+			tokenStr: sign(jwt.SigningMethodHS256, validClaims(), []byte("other")),
+			wantErr:  ErrTokenSignatureInvalid,
+		},
+		{
+			name:     "algorithm not allowed",
+			tokenStr: sign(jwt.SigningMethodHS384, validClaims(), []byte("secret")),
+			wantErr:  ErrTokenAlgorithm,
+		},
+		{
+			name:     "algorithm none",
+			tokenStr: sign(jwt.SigningMethodNone, validClaims(), jwt.UnsafeAllowNoneSignatureType),
+			wantErr:  ErrTokenAlgorithm,
+		},
+		{
+			name: "unknown key",
+			tokenStr: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+				token.Header["kid"] = "other"
+				tokenStr, err := token.SignedString([]byte("secret"))
+				assert.NoError(t, err)
+				return tokenStr
+			}(),
+			wantErr: ErrTokenUnverifiable,
+		},
+		{
+			name: "expired",
+			tokenStr: func() string {
+				claims := validClaims()
+				claims.ExpiresAt = now.Add(-time.Second).Unix()
+				return sign(jwt.SigningMethodHS256, claims, []byte("secret"))
+			}(),
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name: "not valid yet",
+			tokenStr: func() string {
+				claims := validClaims()
+				claims.NotBefore = now.Add(time.Minute).Unix()
+				return sign(jwt.SigningMethodHS256, claims, []byte("secret"))
+			}(),
+			wantErr: ErrTokenNotValidYet,
+		},
+		{
+			name: "other issuer",
+			tokenStr: func() string {
+				claims := validClaims()
+				claims.Issuer = "other"
+				return sign(jwt.SigningMethodHS256, claims, []byte("secret"))
+			}(),
+			wantErr: ErrTokenInvalidIssuer,
+		},
+		{
+			name: "other audience",
+			tokenStr: func() string {
+				claims := validClaims()
+				claims.Audience = "other"
+				return sign(jwt.SigningMethodHS256, claims, []byte("secret"))
+			}(),
+			wantErr: ErrTokenInvalidAudience,
+		},
+		{
+			name:     "missing claims",
+			tokenStr: sign(jwt.SigningMethodHS256, jwt.MapClaims{"iss": "go-spotify", "aud": "api", "exp": now.Add(time.Minute).Unix()}, []byte("secret")),
+			wantErr:  ErrTokenInvalidClaims,
+		},
+		{
+			name:     "missing exp",
+			tokenStr: sign(jwt.SigningMethodHS256, jwt.MapClaims{"id": 1, "jti": "jti", "iss": "go-spotify", "aud": "api"}, []byte("secret")),
+			wantErr:  ErrTokenInvalidClaims,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := keySet.ValidateToken(tt.tokenStr)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, claims)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &Claims{
+				UserID:    1,
+				Username:  "username",
+				ID:        "jti",
+				ExpiresAt: time.Unix(now.Add(time.Minute).Unix(), 0),
+			}, claims)
+		})
+	}
+}
+
+func TestKeySet_CreateToken(t *testing.T) {
+	keySet := NewHMACKeySet("secret", WithIssuer("go-spotify"), WithAudience("api"))
+
+	tokenStr, err := keySet.CreateToken(Claims{UserID: 1, Username: "username", TokenVersion: 3})
+	assert.NoError(t, err)
+
+	claims := tokenClaims{}
+	_, err = jwt.ParseWithClaims(tokenStr, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte("secret"), nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "go-spotify", claims.Issuer)
+	assert.Equal(t, "api", claims.Audience)
+	assert.NotZero(t, claims.NotBefore)
+	assert.Equal(t, uint(3), claims.TokenVersion)
+
+	_, err = NewHMACKeySet("secret", WithIssuer("other")).ValidateToken(tokenStr)
+	assert.ErrorIs(t, err, ErrTokenInvalidIssuer)
+}
@@ -0,0 +1,244 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// Key signs tokens from NotBefore on and verifies them until NotAfter.
+type Key struct {
+	ID        string
+	Algorithm string
+	NotBefore time.Time
+	NotAfter  time.Time
+
+	signKey   interface{}
+	verifyKey interface{}
+}
+
+// ParseKey builds a key from PEM, without a private key it only verifies.
+func ParseKey(id, algorithm string, privateKeyPEM, publicKeyPEM []byte) (Key, error) {
+	key := Key{ID: id, Algorithm: algorithm}
+	if len(privateKeyPEM) == 0 && len(publicKeyPEM) == 0 {
+		return key, fmt.Errorf("key %q: no private or public key", id)
+	}
+
+	var err error
+	switch algorithm {
+	case AlgorithmRS256:
+		if len(privateKeyPEM) > 0 {
+			var privateKey *rsa.PrivateKey
+			privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(privateKeyPEM)
+			if err == nil {
+				key.signKey, key.verifyKey = privateKey, &privateKey.PublicKey
+			}
+		} else {
+			key.verifyKey, err = jwt.ParseRSAPublicKeyFromPEM(publicKeyPEM)
+		}
+	case AlgorithmEdDSA:
+		if len(privateKeyPEM) > 0 {
+			var privateKey interface{}
+			privateKey, err = jwt.ParseEdPrivateKeyFromPEM(privateKeyPEM)
+			if err == nil {
+				edKey := privateKey.(ed25519.PrivateKey)
+				key.signKey, key.verifyKey = edKey, edKey.Public()
+			}
+		} else {
+			key.verifyKey, err = jwt.ParseEdPublicKeyFromPEM(publicKeyPEM)
+		}
+	default:
+		return key, fmt.Errorf("key %q: unsupported algorithm %q", id, algorithm)
+	}
+	if err != nil {
+		return key, fmt.Errorf("key %q: %w", id, err)
+	}
+	return key, nil
+}
+
+func (k Key) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+func (k Key) retired(now time.Time) bool {
+	return !k.NotAfter.IsZero() && !now.Before(k.NotAfter)
+}
+
+func (k Key) canSign(now time.Time) bool {
+	return k.signKey != nil && !k.retired(now) && !now.Before(k.NotBefore)
+}
+
+// KeySet signs tokens with its newest active key.
+type KeySet struct {
+	keys     []Key
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// Option define an option for a key set.
+type Option func(*KeySet)
+
+// WithIssuer sets and requires the iss claim.
+func WithIssuer(issuer string) Option {
+	return func(ks *KeySet) {
+		ks.issuer = issuer
+	}
+}
+
+// WithAudience sets and requires the aud claim.
+func WithAudience(audience string) Option {
+	return func(ks *KeySet) {
+		ks.audience = audience
+	}
+}
+
+// NewKeySet returns a key set, key ids must be unique and not empty.
+func NewKeySet(keys []Key, opts ...Option) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no keys")
+	}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key set: key without id")
+		}
+		if seen[key.ID] {
+			return nil, fmt.Errorf("key set: duplicate key id %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+	return newKeySet(keys, opts), nil
+}
+
+// NewHMACKeySet returns a key set of one HS256 key without kid.
+func NewHMACKeySet(secretKey string, opts ...Option) *KeySet {
+	return newKeySet([]Key{{
+		Algorithm: AlgorithmHS256,
+		signKey:   []byte(secretKey),
+		verifyKey: []byte(secretKey),
+	}}, opts)
+}
+
+func newKeySet(keys []Key, opts []Option) *KeySet {
+	ks := &KeySet{keys: keys, now: time.Now}
+	for _, opt := range opts {
+		opt(ks)
+	}
+	return ks
+}
+
+// algorithms is the allow-list of signing methods, the ones of its keys.
+func (ks *KeySet) algorithms() []string {
+	algorithms := make([]string, 0, len(ks.keys))
+	for _, key := range ks.keys {
+		if !slices.Contains(algorithms, key.Algorithm) {
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+	return algorithms
+}
+
+func (ks *KeySet) signingKey() (Key, error) {
+	now := ks.now()
+	var signing *Key
+	for idx := range ks.keys {
+		key := &ks.keys[idx]
+		if !key.canSign(now) {
+			continue
+		}
+		if signing == nil || !key.NotBefore.Before(signing.NotBefore) {
+			signing = key
+		}
+	}
+	if signing == nil {
+		return Key{}, errors.New("no active signing key")
+	}
+	return *signing, nil
+}
+
+func (ks *KeySet) verifyKey(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	if !slices.Contains(ks.algorithms(), alg) {
+		return nil, newTokenError(ErrTokenAlgorithm, fmt.Errorf("%q", alg))
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	for _, key := range ks.keys {
+		if key.ID != kid {
+			continue
+		}
+		if key.retired(ks.now()) {
+			return nil, newTokenError(ErrTokenUnverifiable, fmt.Errorf("key %q is retired", kid))
+		}
+		// the key decides the algorithm, never the token
+		if alg != key.Algorithm {
+			return nil, newTokenError(ErrTokenAlgorithm, fmt.Errorf("%q for key %q", alg, kid))
+		}
+		return key.verifyKey, nil
+	}
+	return nil, newTokenError(ErrTokenUnverifiable, fmt.Errorf("unknown key %q", kid))
+}
+
+// JWK is a public key in JSON Web Key format.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	// N and E are the RSA modulus and exponent.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve and X are the OKP curve and public key.
+	Curve string `json:"crv,omitempty"`
+	X     string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that are not retired.
+func (ks *KeySet) JWKS() JWKS {
+	now := ks.now()
+	jwks := JWKS{Keys: make([]JWK, 0, len(ks.keys))}
+	for _, key := range ks.keys {
+		if key.retired(now) {
+			continue
+		}
+		switch publicKey := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				KeyType:   "RSA",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Algorithm,
+				N:         base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, JWK{
+				KeyType:   "OKP",
+				KeyID:     key.ID,
+				Use:       "sig",
+				Algorithm: key.Algorithm,
+				Curve:     "Ed25519",
+				X:         base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+	return jwks
+}
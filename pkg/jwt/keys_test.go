@@ -0,0 +1,195 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRSAKeyPEM(t *testing.T) ([]byte, []byte) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	return encodeKeyPEM(t, privateKey, &privateKey.PublicKey)
+}
+
+func newEdKeyPEM(t *testing.T) ([]byte, []byte) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	return encodeKeyPEM(t, privateKey, publicKey)
+}
+
+func encodeKeyPEM(t *testing.T, privateKey, publicKey interface{}) ([]byte, []byte) {
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+}
+
+func TestKeySet_roundTrip(t *testing.T) {
+	rsaPrivate, _ := newRSAKeyPEM(t)
+	edPrivate, _ := newEdKeyPEM(t)
+
+	tests := []struct {
+		name      string
+		algorithm string
+		pem       []byte
+	}{
+		{name: "RS256", algorithm: AlgorithmRS256, pem: rsaPrivate},
+		{name: "EdDSA", algorithm: AlgorithmEdDSA, pem: edPrivate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKey("kid", tt.algorithm, tt.pem, nil)
+			assert.NoError(t, err)
+			keySet, err := NewKeySet([]Key{key})
+			assert.NoError(t, err)
+
+			tokenStr, err := keySet.CreateToken(Claims{UserID: 1, Username: "username", TokenVersion: 2})
+			assert.NoError(t, err)
+
+			token, _, err := new(jwt.Parser).ParseUnverified(tokenStr, jwt.MapClaims{})
+			assert.NoError(t, err)
+			assert.Equal(t, "kid", token.Header["kid"])
+			assert.Equal(t, tt.algorithm, token.Method.Alg())
+
+			claims, err := keySet.ValidateToken(tokenStr)
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), claims.UserID)
+			assert.Equal(t, "username", claims.Username)
+			assert.Equal(t, uint(2), claims.TokenVersion)
+			assert.NotEmpty(t, claims.ID)
+		})
+	}
+}
+
+func TestKeySet_rotation(t *testing.T) {
+	now := time.Now()
+	oldPrivate, oldPublic := newEdKeyPEM(t)
+	newPrivate, _ := newEdKeyPEM(t)
+
+	oldKey, err := ParseKey("old", AlgorithmEdDSA, oldPrivate, nil)
+	assert.NoError(t, err)
+	oldKey.NotBefore = now.Add(-48 * time.Hour)
+	newKey, err := ParseKey("new", AlgorithmEdDSA, newPrivate, nil)
+	assert.NoError(t, err)
+	newKey.NotBefore = now.Add(time.Hour)
+
+	keySet, err := NewKeySet([]Key{oldKey, newKey})
+	assert.NoError(t, err)
+	keySet.now = func() time.Time { return now }
+
+	// the new key is published but doesn't sign yet
+	oldToken, err := keySet.CreateToken(Claims{UserID: 1, Username: "username"})
+	assert.NoError(t, err)
+	assert.Equal(t, "old", tokenKeyID(t, oldToken))
+	assert.Len(t, keySet.JWKS().Keys, 2)
+
+	keySet.now = func() time.Time { return now.Add(2 * time.Hour) }
+	newToken, err := keySet.CreateToken(Claims{UserID: 1, Username: "username"})
+	assert.NoError(t, err)
+	assert.Equal(t, "new", tokenKeyID(t, newToken))
+
+	// a verifier holding only the old public key still accepts old tokens
+	verifyOnly, err := ParseKey("old", AlgorithmEdDSA, nil, oldPublic)
+	assert.NoError(t, err)
+	verifier, err := NewKeySet([]Key{verifyOnly})
+	assert.NoError(t, err)
+	_, err = verifier.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	_, err = verifier.ValidateToken(newToken)
+	assert.Error(t, err)
+
+	// once retired the old key neither verifies nor is published
+	oldKey.NotAfter = now.Add(time.Hour)
+	keySet, err = NewKeySet([]Key{oldKey, newKey})
+	assert.NoError(t, err)
+	keySet.now = func() time.Time { return now.Add(2 * time.Hour) }
+	_, err = keySet.ValidateToken(oldToken)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"new"}, jwksKeyIDs(keySet.JWKS()))
+}
+
+func TestKeySet_rejectsAlgorithmMismatch(t *testing.T) {
+	_, rsaPublic := newRSAKeyPEM(t)
+	key, err := ParseKey("kid", AlgorithmRS256, nil, rsaPublic)
+	assert.NoError(t, err)
+	keySet, err := NewKeySet([]Key{key})
+	assert.NoError(t, err)
+
+	// an HS256 token keyed with the public key, the classic confusion attack
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"id":  1,
+		"jti": "jti",
+		"exp": time.Now().Add(time.Minute).Unix(),
+	})
+	token.Header["kid"] = "kid"
+	tokenStr, err := token.SignedString(rsaPublic)
+	assert.NoError(t, err)
+
+	_, err = keySet.ValidateToken(tokenStr)
+	assert.Error(t, err)
+
+	_, err = keySet.CreateToken(Claims{UserID: 1, Username: "username"})
+	assert.Error(t, err, "a verify only key set can't sign")
+}
+
+func TestNewKeySet(t *testing.T) {
+	edPrivate, _ := newEdKeyPEM(t)
+	key, err := ParseKey("kid", AlgorithmEdDSA, edPrivate, nil)
+	assert.NoError(t, err)
+
+	_, err = NewKeySet([]Key{})
+	assert.Error(t, err)
+	_, err = NewKeySet([]Key{key, key})
+	assert.Error(t, err)
+	_, err = ParseKey("kid", AlgorithmHS256, edPrivate, nil)
+	assert.Error(t, err)
+	_, err = ParseKey("kid", AlgorithmRS256, edPrivate, nil)
+	assert.Error(t, err)
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	rsaPrivate, _ := newRSAKeyPEM(t)
+	edPrivate, _ := newEdKeyPEM(t)
+	rsaKey, err := ParseKey("rsa", AlgorithmRS256, rsaPrivate, nil)
+	assert.NoError(t, err)
+	edKey, err := ParseKey("ed", AlgorithmEdDSA, edPrivate, nil)
+	assert.NoError(t, err)
+	keySet, err := NewKeySet([]Key{rsaKey, edKey})
+	assert.NoError(t, err)
+
+	jwks := keySet.JWKS()
+	assert.Len(t, jwks.Keys, 2)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t, jwks.Keys[0].N)
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+	assert.Len(t, jwks.Keys[1].X, 43)
+
+	assert.Empty(t, NewHMACKeySet("secret").JWKS().Keys)
+}
+
+func tokenKeyID(t *testing.T, tokenStr string) string {
+	token, _, err := new(jwt.Parser).ParseUnverified(tokenStr, jwt.MapClaims{})
+	assert.NoError(t, err)
+	kid, _ := token.Header["kid"].(string)
+	return kid
+}
+
+func jwksKeyIDs(jwks JWKS) []string {
+	ids := make([]string, 0, len(jwks.Keys))
+	for _, key := range jwks.Keys {
+		ids = append(ids, key.KeyID)
+	}
+	return ids
+}
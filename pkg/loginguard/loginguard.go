@@ -0,0 +1,106 @@
+// Package loginguard slows down repeated failed logins and then locks the
+// account or client address out for a while.
+package loginguard
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Record is the run of failed attempts of one key.
+type Record struct {
+	Failures    int
+	LastFailure time.Time
+}
+
+type Store interface {
+	// Attempt counts an attempt of key at the given time as a failure,
+	// unless the key still has to wait after earlier ones. The check and
+	// the count are one step, so a burst of concurrent attempts can't all
+	// pass the check before any of them is counted. It returns when the
+	// key may attempt again and whether the attempt was counted.
+	Attempt(ctx context.Context, key string, at time.Time, policy Policy) (retryAt time.Time, counted bool, err error)
+	// Refund takes back a counted attempt of key that succeeded.
+	Refund(ctx context.Context, key string, at time.Time, policy Policy) error
+	// Reset forgets the failures of key.
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy says how hard failures of a key are punished.
+type Policy struct {
+	// MaxFailures locks the key out after this many failures in a row.
+	MaxFailures int
+	// Lockout is how long the key stays locked out. Failures are forgotten
+	// after the same time without a new one.
+	Lockout time.Duration
+	// BaseDelay is the wait after the second failure, it doubles with each
+	// further failure up to MaxDelay. The first doesn't wait, attempts are
+	// counted before they are checked and another login from the same
+	// address shouldn't be held up by one still being checked.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+// retryAt is when the key may attempt again after record.
+func (p Policy) retryAt(record Record) time.Time {
+	if record.Failures == 0 {
+		return time.Time{}
+	}
+	if record.Failures >= p.MaxFailures {
+		return record.LastFailure.Add(p.Lockout)
+	}
+	if record.Failures == 1 {
+		return record.LastFailure
+	}
+
+	delay := p.BaseDelay
+	for i := 2; i < record.Failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return record.LastFailure.Add(min(delay, p.MaxDelay, p.Lockout))
+}
+
+// LockedError is returned for attempts made before the key may try again.
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, try again in %s", e.RetryAfter.Round(time.Second))
+}
+
+type Guard struct {
+	store Store
+	now   func() time.Time
+}
+
+func New(store Store) *Guard {
+	return &Guard{store: store, now: time.Now}
+}
+
+// Attempt counts an attempt of key before its credentials are checked, and
+// returns a *LockedError instead when key has to wait. An attempt that
+// turns out to succeed is taken back with Succeed or Reset.
+func (g *Guard) Attempt(ctx context.Context, key string, policy Policy) error {
+	now := g.now()
+	retryAt, counted, err := g.store.Attempt(ctx, key, now, policy)
+	if err != nil {
+		return err
+	}
+	if !counted {
+		return &LockedError{RetryAfter: max(retryAt.Sub(now), 0)}
+	}
+	return nil
+}
+
+// Succeed takes back the attempt of key, for keys that keep their earlier
+// failures after a successful attempt.
+func (g *Guard) Succeed(ctx context.Context, key string, policy Policy) error {
+	return g.store.Refund(ctx, key, g.now(), policy)
+}
+
+// Reset forgets the failures of key, after a successful attempt.
+func (g *Guard) Reset(ctx context.Context, key string) error {
+	return g.store.Reset(ctx, key)
+}
@@ -0,0 +1,147 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicy = Policy{
+	MaxFailures: 5,
+	Lockout:     15 * time.Minute,
+	BaseDelay:   time.Second,
+	MaxDelay:    3 * time.Second,
+}
+
+func TestPolicy_retryAt(t *testing.T) {
+	last := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Time
+	}{
+		{name: "no failures", failures: 0, want: time.Time{}},
+		{name: "first failure doesn't wait", failures: 1, want: last},
+		{name: "second failure waits the base delay", failures: 2, want: last.Add(time.Second)},
+		{name: "delay doubles", failures: 3, want: last.Add(2 * time.Second)},
+		{name: "delay is capped", failures: 4, want: last.Add(3 * time.Second)},
+		{name: "locked out", failures: 5, want: last.Add(15 * time.Minute)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := testPolicy.retryAt(Record{Failures: tt.failures, LastFailure: last})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGuard(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	store := NewMemory()
+	store.now = clock
+	g := New(store)
+	g.now = clock
+
+	// the first attempt doesn't hold up the next, the one after has to wait
+	assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+	assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+	var locked *LockedError
+	assert.ErrorAs(t, g.Attempt(ctx, "key", testPolicy), &locked)
+	assert.Equal(t, time.Second, locked.RetryAfter)
+	assert.NoError(t, g.Attempt(ctx, "other", testPolicy))
+
+	// lock the key out
+	for i := 0; i < 3; i++ {
+		now = now.Add(time.Minute)
+		assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+	}
+	now = now.Add(14 * time.Minute)
+	assert.ErrorAs(t, g.Attempt(ctx, "key", testPolicy), &locked)
+	assert.Equal(t, time.Minute, locked.RetryAfter)
+
+	// the lockout ends and the failures are forgotten
+	now = now.Add(time.Minute)
+	assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+	assert.Equal(t, 1, store.entries["key"].record.Failures)
+
+	// a success takes its attempt back without a wait
+	now = now.Add(time.Minute)
+	assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+	assert.NoError(t, g.Succeed(ctx, "key", testPolicy))
+	assert.Equal(t, 1, store.entries["key"].record.Failures)
+	assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+
+	// or starts over
+	assert.NoError(t, g.Reset(ctx, "key"))
+	assert.NoError(t, g.Attempt(ctx, "key", testPolicy))
+}
+
+func TestGuard_concurrentAttempts(t *testing.T) {
+	ctx := context.Background()
+	g := New(NewMemory())
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if g.Attempt(ctx, "key", testPolicy) == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, allowed)
+}
+
+func TestMemory_Attempt(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	retryAt, counted, err := m.Attempt(ctx, "key", now, testPolicy)
+	assert.NoError(t, err)
+	assert.True(t, counted)
+	assert.Equal(t, now, retryAt)
+
+	retryAt, counted, err = m.Attempt(ctx, "key", now, testPolicy)
+	assert.NoError(t, err)
+	assert.True(t, counted)
+	assert.Equal(t, now.Add(time.Second), retryAt)
+
+	retryAt, counted, err = m.Attempt(ctx, "key", now, testPolicy)
+	assert.NoError(t, err)
+	assert.False(t, counted)
+	assert.Equal(t, now.Add(time.Second), retryAt)
+	assert.Equal(t, 2, m.entries["key"].record.Failures)
+
+	_, _, err = m.Attempt(ctx, "stale", now, Policy{MaxFailures: 1, Lockout: time.Second})
+	assert.NoError(t, err)
+
+	// expired keys are swept once the interval is over
+	now = now.Add(30 * time.Second)
+	_, counted, err = m.Attempt(ctx, "key", now, testPolicy)
+	assert.NoError(t, err)
+	assert.True(t, counted)
+	assert.Contains(t, m.entries, "stale")
+
+	now = now.Add(sweepInterval)
+	_, _, err = m.Attempt(ctx, "other", now, testPolicy)
+	assert.NoError(t, err)
+	assert.NotContains(t, m.entries, "stale")
+	assert.Contains(t, m.entries, "key")
+}
@@ -0,0 +1,98 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the stores drop keys whose failures were
+// forgotten.
+const sweepInterval = time.Minute
+
+type memoryEntry struct {
+	record    Record
+	retryAt   time.Time
+	expiresAt time.Time
+}
+
+// Memory is an in-process store. Failures are lost on restart and not
+// shared between instances, use it for development, tests and single
+// instance deployments.
+type Memory struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	nextSweep time.Time
+	now       func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+func (m *Memory) Attempt(ctx context.Context, key string, at time.Time, policy Policy) (time.Time, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep()
+
+	entry, ok := m.entries[key]
+	if ok && !at.Before(entry.expiresAt) {
+		entry = memoryEntry{}
+	}
+	if entry.retryAt.After(at) {
+		return entry.retryAt, false, nil
+	}
+
+	entry.record.Failures++
+	entry.record.LastFailure = at
+	entry.retryAt = policy.retryAt(entry.record)
+	entry.expiresAt = at.Add(policy.Lockout)
+	m.entries[key] = entry
+	return entry.retryAt, true, nil
+}
+
+func (m *Memory) Refund(ctx context.Context, key string, at time.Time, policy Policy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok || !at.Before(entry.expiresAt) {
+		return nil
+	}
+	entry.record.Failures--
+	if entry.record.Failures <= 0 {
+		delete(m.entries, key)
+		return nil
+	}
+	if entry.record.Failures < policy.MaxFailures && entry.retryAt.After(at) {
+		entry.retryAt = at
+	}
+	m.entries[key] = entry
+	return nil
+}
+
+func (m *Memory) Reset(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// sweep drops the keys whose failures were forgotten, at most once every
+// sweepInterval so a failed attempt doesn't cost a walk over every key.
+// The caller holds mu.
+func (m *Memory) sweep() {
+	now := m.now()
+	if now.Before(m.nextSweep) {
+		return
+	}
+	m.nextSweep = now.Add(sweepInterval)
+	for k, entry := range m.entries {
+		if !now.Before(entry.expiresAt) {
+			delete(m.entries, k)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package loginguard
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LoginFailure struct {
+	Key         string    `gorm:"primaryKey"`
+	Failures    int       `gorm:"not null"`
+	LastFailure time.Time `gorm:"not null"`
+	RetryAt     time.Time `gorm:"not null;default:CURRENT_TIMESTAMP"`
+	ExpiresAt   time.Time `gorm:"index;not null"`
+}
+
+// Postgres is a store shared by every instance using the same database.
+type Postgres struct {
+	db  *gorm.DB
+	now func() time.Time
+
+	mu        sync.Mutex
+	nextSweep time.Time
+}
+
+func NewPostgres(db *gorm.DB) *Postgres {
+	return &Postgres{db: db, now: time.Now}
+}
+
+// Migrate creates the table backing the store.
+func (p *Postgres) Migrate() error {
+	return p.db.AutoMigrate(&LoginFailure{})
+}
+
+// Attempt checks and counts the attempt in one statement. The update only
+// applies once the wait after the earlier failures is over, so of many
+// concurrent attempts only as many count as the policy lets through.
+func (p *Postgres) Attempt(ctx context.Context, key string, at time.Time, policy Policy) (time.Time, bool, error) {
+	db := p.db.WithContext(ctx)
+	if err := p.sweep(db); err != nil {
+		return time.Time{}, false, err
+	}
+
+	failure := LoginFailure{}
+	res := db.Raw(
+		`INSERT INTO login_failures (key, failures, last_failure, retry_at, expires_at)
+		VALUES (@key, 1, @at, @firstRetryAt, @expiresAt)
+		ON CONFLICT (key) DO UPDATE SET
+			failures = `+nextFailures+`,
+			last_failure = EXCLUDED.last_failure,
+			retry_at = EXCLUDED.last_failure + make_interval(secs => CASE
+				WHEN `+nextFailures+` >= @maxFailures THEN @lockout
+				WHEN `+nextFailures+` = 1 THEN 0
+				ELSE LEAST(@baseDelay * power(2, `+nextFailures+` - 2), @maxDelay, @lockout) END),
+			expires_at = EXCLUDED.expires_at
+		WHERE login_failures.retry_at <= EXCLUDED.last_failure
+		RETURNING retry_at`,
+		map[string]interface{}{
+			"key":          key,
+			"at":           at,
+			"firstRetryAt": policy.retryAt(Record{Failures: 1, LastFailure: at}),
+			"expiresAt":    at.Add(policy.Lockout),
+			"maxFailures":  policy.MaxFailures,
+			"baseDelay":    policy.BaseDelay.Seconds(),
+			"maxDelay":     policy.MaxDelay.Seconds(),
+			"lockout":      policy.Lockout.Seconds(),
+		},
+	).Scan(&failure)
+	if res.Error != nil {
+		return time.Time{}, false, res.Error
+	}
+	if res.RowsAffected > 0 {
+		return failure.RetryAt, true, nil
+	}
+
+	// the key is still waiting, read for how long
+	err := db.Where("key = ?", key).Limit(1).Find(&failure).Error
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	return failure.RetryAt, false, nil
+}
+
+// nextFailures is the count after one more failure, starting over once the
+// earlier failures were forgotten.
+const nextFailures = `(CASE WHEN login_failures.expires_at > EXCLUDED.last_failure
+				THEN login_failures.failures + 1 ELSE 1 END)`
+
+func (p *Postgres) Refund(ctx context.Context, key string, at time.Time, policy Policy) error {
+	return p.db.WithContext(ctx).Exec(
+		`UPDATE login_failures SET
+			failures = failures - 1,
+			retry_at = CASE WHEN failures - 1 >= ? THEN retry_at ELSE LEAST(retry_at, ?) END
+		WHERE key = ? AND expires_at > ? AND failures > 0`,
+		policy.MaxFailures, at, key, at,
+	).Error
+}
+
+func (p *Postgres) Reset(ctx context.Context, key string) error {
+	return p.db.WithContext(ctx).Where("key = ?", key).Delete(&LoginFailure{}).Error
+}
+
+// sweep drops the keys whose failures were forgotten, at most once every
+// sweepInterval per instance.
+func (p *Postgres) sweep(db *gorm.DB) error {
+	now := p.now()
+	p.mu.Lock()
+	if now.Before(p.nextSweep) {
+		p.mu.Unlock()
+		return nil
+	}
+	p.nextSweep = now.Add(sweepInterval)
+	p.mu.Unlock()
+
+	return db.Where("expires_at <= ?", now).Delete(&LoginFailure{}).Error
+}
@@ -0,0 +1,113 @@
+package loginguard
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockPostgres(t *testing.T) (*Postgres, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+	return NewPostgres(gormDB), mock
+}
+
+func TestPostgres_Attempt(t *testing.T) {
+	p, mock := newMockPostgres(t)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	policy := Policy{MaxFailures: 5, Lockout: time.Minute, BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+	attemptQuery := `INSERT INTO login_failures .+ ON CONFLICT \(key\) DO UPDATE .+ WHERE login_failures.retry_at <= EXCLUDED.last_failure RETURNING retry_at`
+	attemptArgs := []driver.Value{"ip:127.0.0.1", now, now, now.Add(time.Minute), 5, 60.0, 1.0, 30.0, 60.0}
+
+	tests := []struct {
+		name        string
+		wantRetryAt time.Time
+		wantCounted bool
+		wantErr     bool
+		mockFn      func()
+	}{
+		{
+			name:        "success: counted",
+			wantRetryAt: now.Add(4 * time.Second),
+			wantCounted: true,
+			mockFn: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(`DELETE FROM "login_failures" WHERE expires_at <= \$1`).
+					WithArgs(now).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+				mock.ExpectQuery(attemptQuery).
+					WithArgs(attemptArgs...).
+					WillReturnRows(sqlmock.NewRows([]string{"retry_at"}).AddRow(now.Add(4 * time.Second)))
+			},
+		},
+		{
+			name:        "success: still waiting",
+			wantRetryAt: now.Add(2 * time.Second),
+			mockFn: func() {
+				mock.ExpectQuery(attemptQuery).
+					WithArgs(attemptArgs...).
+					WillReturnRows(sqlmock.NewRows([]string{"retry_at"}))
+				mock.ExpectQuery(`SELECT \* FROM "login_failures" WHERE key = \$1 LIMIT \$2`).
+					WithArgs("ip:127.0.0.1", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"key", "failures", "last_failure", "retry_at", "expires_at"}).
+						AddRow("ip:127.0.0.1", 2, now, now.Add(2*time.Second), now.Add(time.Minute)))
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectQuery(attemptQuery).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			retryAt, counted, err := p.Attempt(context.Background(), "ip:127.0.0.1", now, policy)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantRetryAt, retryAt)
+			assert.Equal(t, tt.wantCounted, counted)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgres_Refund(t *testing.T) {
+	p, mock := newMockPostgres(t)
+	now := time.Now()
+
+	mock.ExpectExec(`UPDATE login_failures SET .+ WHERE key = \$3 AND expires_at > \$4 AND failures > 0`).
+		WithArgs(20, now, "ip:127.0.0.1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, p.Refund(context.Background(), "ip:127.0.0.1", now, Policy{MaxFailures: 20}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_Reset(t *testing.T) {
+	p, mock := newMockPostgres(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM "login_failures" WHERE key = \$1`).
+		WithArgs("email:test@gmail.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, p.Reset(context.Background(), "email:test@gmail.com"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,23 @@
+package mail
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Log writes messages to the log instead of sending them, for local
+// development.
+type Log struct{}
+
+func NewLog() *Log {
+	return &Log{}
+}
+
+func (l *Log) Send(ctx context.Context, message Message) error {
+	if err := message.validate(); err != nil {
+		return err
+	}
+	log.Info().Str("to", message.To).Str("subject", message.Subject).Msg(message.Body)
+	return nil
+}
@@ -0,0 +1,32 @@
+// Package mail sends the transactional emails of the service.
+package mail
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	// Body is plain text.
+	Body string
+}
+
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+var errHeaderInjection = errors.New("mail: header contains a line break")
+
+// validate rejects values that would let a caller inject extra headers.
+func (m Message) validate() error {
+	if strings.ContainsAny(m.To, "\r\n") || strings.ContainsAny(m.Subject, "\r\n") {
+		return errHeaderInjection
+	}
+	if m.To == "" {
+		return errors.New("mail: missing recipient")
+	}
+	return nil
+}
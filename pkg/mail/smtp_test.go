@@ -0,0 +1,137 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTP is a local stand-in for an SMTP relay that records the envelope
+// and data of every message.
+type fakeSMTP struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	auth     string
+	from     string
+	to       []string
+	data     string
+	received chan struct{}
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	f := &fakeSMTP{listener: listener, received: make(chan struct{}, 1)}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeSMTP) port() int {
+	return f.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (f *fakeSMTP) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 fake ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(strings.Fields(line + " ")[0])
+
+		f.mu.Lock()
+		switch command {
+		case "EHLO":
+			reply("250-fake")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			credentials, _ := base64.StdEncoding.DecodeString(strings.Fields(line)[2])
+			f.auth = string(credentials)
+			reply("235 ok")
+		case "MAIL":
+			f.from = strings.TrimSuffix(strings.TrimPrefix(line, "MAIL FROM:<"), ">")
+			reply("250 ok")
+		case "RCPT":
+			f.to = append(f.to, strings.TrimSuffix(strings.TrimPrefix(line, "RCPT TO:<"), ">"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 go ahead")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil || dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			f.data = data.String()
+			reply("250 queued")
+			f.received <- struct{}{}
+		case "QUIT":
+			reply("221 bye")
+			f.mu.Unlock()
+			return
+		default:
+			reply("502 unknown command")
+		}
+		f.mu.Unlock()
+	}
+}
+
+func TestSMTP_Send(t *testing.T) {
+	server := newFakeSMTP(t)
+	mailer := NewSMTP("127.0.0.1", server.port(), "user", "secret", "go-spotify <no-reply@go-spotify.test>")
+
+	err := mailer.Send(context.Background(), Message{
+		To:      "yeremia@gmail.com",
+		Subject: "Verify your email",
+		Body:    "line one\nline two",
+	})
+	assert.NoError(t, err)
+	<-server.received
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, "\x00user\x00secret", server.auth)
+	assert.Equal(t, "no-reply@go-spotify.test", server.from)
+	assert.Equal(t, []string{"yeremia@gmail.com"}, server.to)
+	assert.Contains(t, server.data, "Subject: Verify your email\r\n")
+	assert.Contains(t, server.data, "From: go-spotify <no-reply@go-spotify.test>\r\n")
+	assert.Contains(t, server.data, "To: yeremia@gmail.com\r\n")
+	assert.True(t, strings.HasSuffix(server.data, "\r\n\r\nline one\r\nline two\r\n"), strconv.Quote(server.data))
+}
+
+func TestSMTP_Send_headerInjection(t *testing.T) {
+	mailer := NewSMTP("127.0.0.1", 1, "", "", "no-reply@go-spotify.test")
+
+	err := mailer.Send(context.Background(), Message{
+		To:      "yeremia@gmail.com\r\nBcc: someone@example.com",
+		Subject: "Verify your email",
+	})
+	assert.ErrorIs(t, err, errHeaderInjection)
+}
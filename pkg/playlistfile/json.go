@@ -0,0 +1,71 @@
+package playlistfile
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type (
+	jsonPlaylist struct {
+		Name   string      `json:"name"`
+		Tracks []jsonTrack `json:"tracks"`
+	}
+
+	jsonTrack struct {
+		Name       string   `json:"name"`
+		Artists    []string `json:"artists"`
+		DurationMs int      `json:"durationMs"`
+		URI        string   `json:"uri"`
+	}
+)
+
+func writeJSON(w io.Writer, playlist Playlist) error {
+	document := jsonPlaylist{
+		Name:   playlist.Name,
+		Tracks: make([]jsonTrack, 0, len(playlist.Tracks)),
+	}
+	for _, track := range playlist.Tracks {
+		artists := track.Artists
+		if artists == nil {
+			artists = []string{}
+		}
+		document.Tracks = append(document.Tracks, jsonTrack{
+			Name:       track.Title,
+			Artists:    artists,
+			DurationMs: track.DurationMs,
+			URI:        track.Location,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
+
+func readJSON(r io.Reader) (*Playlist, error) {
+	document := jsonPlaylist{}
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, invalidFile(FormatJSON, err)
+	}
+
+	playlist := &Playlist{
+		Name:   strings.TrimSpace(document.Name),
+		Tracks: make([]Track, 0, len(document.Tracks)),
+	}
+	for idx, entry := range document.Tracks {
+		track := Track{
+			Line:       idx + 1,
+			Title:      strings.TrimSpace(entry.Name),
+			DurationMs: entry.DurationMs,
+			Location:   strings.TrimSpace(entry.URI),
+		}
+		for _, artist := range entry.Artists {
+			if artist = strings.TrimSpace(artist); artist != "" {
+				track.Artists = append(track.Artists, artist)
+			}
+		}
+		playlist.Tracks = append(playlist.Tracks, track)
+	}
+	return playlist, nil
+}
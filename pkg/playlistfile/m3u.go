@@ -0,0 +1,85 @@
+package playlistfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	m3uHeader   = "#EXTM3U"
+	m3uInfo     = "#EXTINF:"
+	m3uPlaylist = "#PLAYLIST:"
+)
+
+func writeM3U(w io.Writer, playlist Playlist) error {
+	buf := bufio.NewWriter(w)
+	fmt.Fprintln(buf, m3uHeader)
+	if playlist.Name != "" {
+		fmt.Fprintf(buf, "%s%s\n", m3uPlaylist, oneLine(playlist.Name))
+	}
+	for _, track := range playlist.Tracks {
+		// -1 is how extended M3U says the length is unknown
+		seconds := -1
+		if track.DurationMs > 0 {
+			seconds = (track.DurationMs + 500) / 1000
+		}
+		display := oneLine(track.Title)
+		if len(track.Artists) > 0 {
+			display = oneLine(strings.Join(track.Artists, ", ")) + " - " + display
+		}
+		fmt.Fprintf(buf, "%s%d,%s\n%s\n", m3uInfo, seconds, display, track.Location)
+	}
+	return buf.Flush()
+}
+
+func readM3U(r io.Reader) (*Playlist, error) {
+	playlist := &Playlist{}
+	scanner := bufio.NewScanner(r)
+
+	var info *Track
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if lineNumber == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, m3uInfo):
+			info = parseM3UInfo(strings.TrimPrefix(line, m3uInfo))
+		case strings.HasPrefix(line, m3uPlaylist):
+			playlist.Name = strings.TrimSpace(strings.TrimPrefix(line, m3uPlaylist))
+		case strings.HasPrefix(line, "#"):
+			// other directives and comments say nothing about the tracks
+		default:
+			track := Track{Line: lineNumber, Location: line}
+			if info != nil {
+				track.Title, track.Artists, track.DurationMs = info.Title, info.Artists, info.DurationMs
+			} else {
+				track.Title, track.Artists = titleFromLocation(line)
+			}
+			playlist.Tracks = append(playlist.Tracks, track)
+			info = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalidFile(FormatM3U, err)
+	}
+	return playlist, nil
+}
+
+// parseM3UInfo reads "<seconds> [attributes],<Artist - Title>".
+func parseM3UInfo(value string) *Track {
+	head, display, _ := strings.Cut(value, ",")
+	track := &Track{}
+	if fields := strings.Fields(head); len(fields) > 0 {
+		if seconds, err := strconv.Atoi(fields[0]); err == nil && seconds > 0 {
+			track.DurationMs = seconds * 1000
+		}
+	}
+	track.Title, track.Artists = splitDisplayTitle(display)
+	return track
+}
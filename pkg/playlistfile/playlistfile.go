@@ -0,0 +1,150 @@
+// Package playlistfile reads and writes playlists in the file formats
+// music players exchange them in: extended M3U, XSPF and a plain JSON
+// document.
+package playlistfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+const (
+	FormatM3U  = "m3u"
+	FormatXSPF = "xspf"
+	FormatJSON = "json"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown playlist format, use m3u, xspf or json")
+	ErrInvalidFile   = errors.New("invalid playlist file")
+)
+
+var contentTypes = map[string]string{
+	FormatM3U:  "audio/x-mpegurl",
+	FormatXSPF: "application/xspf+xml",
+	FormatJSON: "application/json",
+}
+
+type Playlist struct {
+	Name   string
+	Tracks []Track
+}
+
+// Track is an entry of a playlist file. Files made elsewhere often carry
+// only some of the fields, a file path instead of a spotify uri included.
+type Track struct {
+	// Line is where the entry is in the file, the line of its location for
+	// M3U and its position counting from 1 for XSPF and JSON.
+	Line       int
+	Title      string
+	Artists    []string
+	DurationMs int
+	// Location is a spotify uri for files written here.
+	Location string
+}
+
+// ContentType returns the media type of the format.
+func ContentType(format string) (string, error) {
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return "", ErrUnknownFormat
+	}
+	return contentType, nil
+}
+
+// Write writes the playlist in the format as it goes, without building the
+// whole file first.
+func Write(w io.Writer, format string, playlist Playlist) error {
+	switch format {
+	case FormatM3U:
+		return writeM3U(w, playlist)
+	case FormatXSPF:
+		return writeXSPF(w, playlist)
+	case FormatJSON:
+		return writeJSON(w, playlist)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// Read parses a playlist file of the format.
+func Read(r io.Reader, format string) (*Playlist, error) {
+	switch format {
+	case FormatM3U:
+		return readM3U(r)
+	case FormatXSPF:
+		return readXSPF(r)
+	case FormatJSON:
+		return readJSON(r)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+func invalidFile(format string, err error) error {
+	return fmt.Errorf("%w: %s: %w", ErrInvalidFile, format, err)
+}
+
+// TrackURI returns the spotify uri of the track id.
+func TrackURI(trackID string) string {
+	return "spotify:track:" + trackID
+}
+
+var (
+	trackURIPattern = regexp.MustCompile(`^spotify:track:([0-9A-Za-z]{22})$`)
+	trackURLPattern = regexp.MustCompile(`^/(?:intl-[A-Za-z-]+/)?track/([0-9A-Za-z]{22})/?$`)
+)
+
+// SpotifyTrackID returns the track id of a spotify uri or an
+// open.spotify.com link, false for any other location.
+func SpotifyTrackID(location string) (string, bool) {
+	location = strings.TrimSpace(location)
+	if match := trackURIPattern.FindStringSubmatch(location); match != nil {
+		return match[1], true
+	}
+
+	link, err := url.Parse(location)
+	if err != nil || link.Host != "open.spotify.com" {
+		return "", false
+	}
+	if match := trackURLPattern.FindStringSubmatch(link.Path); match != nil {
+		return match[1], true
+	}
+	return "", false
+}
+
+// splitDisplayTitle splits the usual "Artist - Title" of players, a title
+// alone has no artist.
+func splitDisplayTitle(display string) (string, []string) {
+	display = strings.TrimSpace(display)
+	artist, title, found := strings.Cut(display, " - ")
+	if !found || strings.TrimSpace(artist) == "" {
+		return display, nil
+	}
+	return strings.TrimSpace(title), []string{strings.TrimSpace(artist)}
+}
+
+// titleFromLocation names an entry by its file name, for M3U entries that
+// come without #EXTINF.
+func titleFromLocation(location string) (string, []string) {
+	if _, ok := SpotifyTrackID(location); ok {
+		return "", nil
+	}
+	name := strings.ReplaceAll(location, `\`, "/")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	name = path.Base(name)
+	name = strings.TrimSuffix(name, path.Ext(name))
+	return splitDisplayTitle(strings.ReplaceAll(name, "_", " "))
+}
+
+// oneLine keeps names from breaking the line based M3U format.
+func oneLine(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
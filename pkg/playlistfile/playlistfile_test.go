@@ -0,0 +1,166 @@
+package playlistfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var roadTrip = Playlist{
+	Name: "road trip",
+	Tracks: []Track{
+		{Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354320, Location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"},
+		{Title: "Under Pressure", Artists: []string{"Queen", "David Bowie"}, DurationMs: 248440, Location: "spotify:track:2fuCquhmrzHpu5xcA1ci9x"},
+	},
+}
+
+func TestWrite_M3U(t *testing.T) {
+	buf := &bytes.Buffer{}
+	err := Write(buf, FormatM3U, roadTrip)
+	assert.NoError(t, err)
+	assert.Equal(t, "#EXTM3U\n"+
+		"#PLAYLIST:road trip\n"+
+		"#EXTINF:354,Queen - Bohemian Rhapsody\n"+
+		"spotify:track:3z8h0TU7ReDPLIbEnYhWZb\n"+
+		"#EXTINF:248,Queen, David Bowie - Under Pressure\n"+
+		"spotify:track:2fuCquhmrzHpu5xcA1ci9x\n", buf.String())
+}
+
+func TestWriteRead(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []Track
+	}{
+		{
+			format: FormatM3U,
+			// M3U keeps whole seconds and the artists as one name
+			want: []Track{
+				{Line: 4, Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354000, Location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"},
+				{Line: 6, Title: "Under Pressure", Artists: []string{"Queen, David Bowie"}, DurationMs: 248000, Location: "spotify:track:2fuCquhmrzHpu5xcA1ci9x"},
+			},
+		},
+		{
+			format: FormatXSPF,
+			want: []Track{
+				{Line: 1, Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354320, Location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"},
+				{Line: 2, Title: "Under Pressure", Artists: []string{"Queen, David Bowie"}, DurationMs: 248440, Location: "spotify:track:2fuCquhmrzHpu5xcA1ci9x"},
+			},
+		},
+		{
+			format: FormatJSON,
+			want: []Track{
+				{Line: 1, Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, DurationMs: 354320, Location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb"},
+				{Line: 2, Title: "Under Pressure", Artists: []string{"Queen", "David Bowie"}, DurationMs: 248440, Location: "spotify:track:2fuCquhmrzHpu5xcA1ci9x"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := Write(buf, tt.format, roadTrip)
+			assert.NoError(t, err)
+
+			got, err := Read(buf, tt.format)
+			assert.NoError(t, err)
+			assert.Equal(t, &Playlist{Name: "road trip", Tracks: tt.want}, got)
+		})
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		file    string
+		want    *Playlist
+		wantErr error
+	}{
+		{
+			name:   "m3u of a local player",
+			format: FormatM3U,
+			file: "\ufeff#EXTM3U\r\n" +
+				"# saved by a player\r\n" +
+				"\r\n" +
+				"#EXTINF:-1 tvg-id=\"x\",Daft Punk - One More Time\r\n" +
+				"Music/Daft Punk/01 One More Time.mp3\r\n" +
+				"C:\\Music\\Queen - Don't Stop Me Now.flac\r\n" +
+				"https://open.spotify.com/intl-de/track/3z8h0TU7ReDPLIbEnYhWZb?si=abc\r\n",
+			want: &Playlist{
+				Tracks: []Track{
+					{Line: 5, Title: "One More Time", Artists: []string{"Daft Punk"}, Location: "Music/Daft Punk/01 One More Time.mp3"},
+					{Line: 6, Title: "Don't Stop Me Now", Artists: []string{"Queen"}, Location: `C:\Music\Queen - Don't Stop Me Now.flac`},
+					{Line: 7, Location: "https://open.spotify.com/intl-de/track/3z8h0TU7ReDPLIbEnYhWZb?si=abc"},
+				},
+			},
+		},
+		{
+			name:   "xspf of another player",
+			format: FormatXSPF,
+			file: `<?xml version="1.0" encoding="UTF-8"?>
+<playlist version="1" xmlns="http://xspf.org/ns/0/">
+  <title>mix</title>
+  <trackList>
+    <track>
+      <location>file:///music/one.mp3</location>
+      <location>https://open.spotify.com/track/2fuCquhmrzHpu5xcA1ci9x</location>
+      <title>Under Pressure</title>
+    </track>
+    <track>
+      <location>file:///music/Queen%20-%20Bohemian%20Rhapsody.mp3</location>
+    </track>
+  </trackList>
+</playlist>`,
+			want: &Playlist{
+				Name: "mix",
+				Tracks: []Track{
+					{Line: 1, Title: "Under Pressure", Location: "https://open.spotify.com/track/2fuCquhmrzHpu5xcA1ci9x"},
+					{Line: 2, Title: "Bohemian Rhapsody", Artists: []string{"Queen"}, Location: "file:///music/Queen%20-%20Bohemian%20Rhapsody.mp3"},
+				},
+			},
+		},
+		{
+			name:    "broken json",
+			format:  FormatJSON,
+			file:    `{"name":"road trip","tracks":[`,
+			wantErr: ErrInvalidFile,
+		},
+		{
+			name:    "unknown format",
+			format:  "pls",
+			file:    "[playlist]",
+			wantErr: ErrUnknownFormat,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Read(strings.NewReader(tt.file), tt.format)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSpotifyTrackID(t *testing.T) {
+	tests := []struct {
+		location string
+		want     string
+		wantOK   bool
+	}{
+		{location: "spotify:track:3z8h0TU7ReDPLIbEnYhWZb", want: "3z8h0TU7ReDPLIbEnYhWZb", wantOK: true},
+		{location: "https://open.spotify.com/track/3z8h0TU7ReDPLIbEnYhWZb?si=abc", want: "3z8h0TU7ReDPLIbEnYhWZb", wantOK: true},
+		{location: "https://open.spotify.com/intl-pt-BR/track/3z8h0TU7ReDPLIbEnYhWZb", want: "3z8h0TU7ReDPLIbEnYhWZb", wantOK: true},
+		{location: "spotify:album:3z8h0TU7ReDPLIbEnYhWZb", wantOK: false},
+		{location: "spotify:track:short", wantOK: false},
+		{location: "https://example.com/track/3z8h0TU7ReDPLIbEnYhWZb", wantOK: false},
+		{location: "Music/one.mp3", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			got, ok := SpotifyTrackID(tt.location)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
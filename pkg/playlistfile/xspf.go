@@ -0,0 +1,98 @@
+package playlistfile
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+const xspfNamespace = "http://xspf.org/ns/0/"
+
+type (
+	xspfPlaylist struct {
+		XMLName   xml.Name    `xml:"playlist"`
+		Namespace string      `xml:"xmlns,attr,omitempty"`
+		Version   string      `xml:"version,attr"`
+		Title     string      `xml:"title,omitempty"`
+		Tracks    []xspfTrack `xml:"trackList>track"`
+	}
+
+	xspfTrack struct {
+		Locations []string `xml:"location"`
+		Title     string   `xml:"title,omitempty"`
+		Creator   string   `xml:"creator,omitempty"`
+		// Duration is in milliseconds
+		Duration int `xml:"duration,omitempty"`
+	}
+)
+
+func writeXSPF(w io.Writer, playlist Playlist) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	document := xspfPlaylist{
+		Namespace: xspfNamespace,
+		Version:   "1",
+		Title:     playlist.Name,
+		Tracks:    make([]xspfTrack, 0, len(playlist.Tracks)),
+	}
+	for _, track := range playlist.Tracks {
+		document.Tracks = append(document.Tracks, xspfTrack{
+			Locations: []string{track.Location},
+			Title:     track.Title,
+			Creator:   strings.Join(track.Artists, ", "),
+			Duration:  track.DurationMs,
+		})
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func readXSPF(r io.Reader) (*Playlist, error) {
+	document := xspfPlaylist{}
+	if err := xml.NewDecoder(r).Decode(&document); err != nil {
+		return nil, invalidFile(FormatXSPF, err)
+	}
+
+	playlist := &Playlist{
+		Name:   strings.TrimSpace(document.Title),
+		Tracks: make([]Track, 0, len(document.Tracks)),
+	}
+	for idx, entry := range document.Tracks {
+		track := Track{
+			Line:       idx + 1,
+			Title:      strings.TrimSpace(entry.Title),
+			DurationMs: entry.Duration,
+			Location:   xspfLocation(entry.Locations),
+		}
+		if creator := strings.TrimSpace(entry.Creator); creator != "" {
+			track.Artists = []string{creator}
+		}
+		if track.Title == "" {
+			track.Title, track.Artists = titleFromLocation(track.Location)
+		}
+		playlist.Tracks = append(playlist.Tracks, track)
+	}
+	return playlist, nil
+}
+
+// xspfLocation picks a spotify location out of the alternatives a track
+// may list, the first one otherwise.
+func xspfLocation(locations []string) string {
+	for _, location := range locations {
+		if _, ok := SpotifyTrackID(location); ok {
+			return strings.TrimSpace(location)
+		}
+	}
+	if len(locations) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(locations[0])
+}
@@ -0,0 +1,63 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process store. Revocations are lost on restart and not
+// shared between instances, use it for development and single instance
+// deployments.
+type Memory struct {
+	mu       sync.Mutex
+	revoked  map[string]time.Time
+	versions map[uint]uint
+	now      func() time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		revoked:  make(map[string]time.Time),
+		versions: make(map[uint]uint),
+		now:      time.Now,
+	}
+}
+
+func (m *Memory) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	// piggyback on writes to drop entries of tokens that expired since
+	for key, expiredAt := range m.revoked {
+		if !now.Before(expiredAt) {
+			delete(m.revoked, key)
+		}
+	}
+	if now.Before(expiresAt) {
+		m.revoked[jti] = expiresAt
+	}
+	return nil
+}
+
+func (m *Memory) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt, ok := m.revoked[jti]
+	return ok && m.now().Before(expiresAt), nil
+}
+
+func (m *Memory) TokenVersion(ctx context.Context, userID uint) (uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.versions[userID], nil
+}
+
+func (m *Memory) BumpTokenVersion(ctx context.Context, userID uint) (uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.versions[userID]++
+	return m.versions[userID], nil
+}
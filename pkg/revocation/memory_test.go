@@ -0,0 +1,79 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory_Revoke(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	tests := []struct {
+		name        string
+		run         func(m *Memory)
+		wantRevoked map[string]bool
+	}{
+		{
+			name: "revoked until expiry",
+			run: func(m *Memory) {
+				assert.NoError(t, m.Revoke(ctx, "a", now.Add(time.Minute)))
+			},
+			wantRevoked: map[string]bool{"a": true, "b": false},
+		},
+		{
+			name: "expired token is not tracked",
+			run: func(m *Memory) {
+				assert.NoError(t, m.Revoke(ctx, "a", now.Add(-time.Minute)))
+			},
+			wantRevoked: map[string]bool{"a": false},
+		},
+		{
+			name: "entry dropped after expiry",
+			run: func(m *Memory) {
+				assert.NoError(t, m.Revoke(ctx, "a", now.Add(time.Minute)))
+				m.now = func() time.Time { return now.Add(2 * time.Minute) }
+				assert.NoError(t, m.Revoke(ctx, "b", now.Add(time.Hour)))
+				assert.Len(t, m.revoked, 1)
+			},
+			wantRevoked: map[string]bool{"a": false, "b": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMemory()
+			m.now = func() time.Time { return now }
+			tt.run(m)
+
+			for jti, want := range tt.wantRevoked {
+				revoked, err := m.IsRevoked(ctx, jti)
+				assert.NoError(t, err)
+				assert.Equal(t, want, revoked, "jti %s", jti)
+			}
+		})
+	}
+}
+
+func TestMemory_TokenVersion(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	version, err := m.TokenVersion(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), version)
+
+	version, err = m.BumpTokenVersion(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), version)
+
+	version, err = m.TokenVersion(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), version)
+
+	version, err = m.TokenVersion(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), version)
+}
@@ -0,0 +1,75 @@
+package revocation
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	RevokedToken struct {
+		JTI       string    `gorm:"primaryKey"`
+		ExpiresAt time.Time `gorm:"index;not null"`
+	}
+
+	UserTokenVersion struct {
+		UserID  uint `gorm:"primaryKey;autoIncrement:false"`
+		Version uint `gorm:"not null"`
+	}
+)
+
+// Postgres is a store shared by every instance using the same database.
+type Postgres struct {
+	db  *gorm.DB
+	now func() time.Time
+}
+
+func NewPostgres(db *gorm.DB) *Postgres {
+	return &Postgres{db: db, now: time.Now}
+}
+
+// Migrate creates the tables backing the store.
+func (p *Postgres) Migrate() error {
+	return p.db.AutoMigrate(&RevokedToken{}, &UserTokenVersion{})
+}
+
+func (p *Postgres) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	return p.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+}
+
+func (p *Postgres) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := p.db.WithContext(ctx).Model(&RevokedToken{}).
+		Where("jti = ?", jti).Where("expires_at > ?", p.now()).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (p *Postgres) TokenVersion(ctx context.Context, userID uint) (uint, error) {
+	version := UserTokenVersion{}
+	res := p.db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&version)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return version.Version, nil
+}
+
+func (p *Postgres) BumpTokenVersion(ctx context.Context, userID uint) (uint, error) {
+	var version uint
+	err := p.db.WithContext(ctx).Raw(
+		`INSERT INTO user_token_versions (user_id, version) VALUES (?, 1)
+		ON CONFLICT (user_id) DO UPDATE SET version = user_token_versions.version + 1
+		RETURNING version`, userID,
+	).Scan(&version).Error
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
+}
@@ -0,0 +1,137 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newMockPostgres(t *testing.T) (*Postgres, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: db}))
+	assert.NoError(t, err)
+	return NewPostgres(gormDB), mock
+}
+
+func TestPostgres_Revoke(t *testing.T) {
+	p, mock := newMockPostgres(t)
+	expiresAt := time.Now().Add(time.Minute)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO "revoked_tokens" (.+) VALUES (.+) ON CONFLICT DO NOTHING`).
+		WithArgs("jti", expiresAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, p.Revoke(context.Background(), "jti", expiresAt))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgres_IsRevoked(t *testing.T) {
+	p, mock := newMockPostgres(t)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	tests := []struct {
+		name    string
+		want    bool
+		wantErr bool
+		mockFn  func()
+	}{
+		{
+			name: "revoked",
+			want: true,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "revoked_tokens" .+`).
+					WithArgs("jti", now).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			},
+		},
+		{
+			name: "not revoked",
+			want: false,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "revoked_tokens" .+`).
+					WithArgs("jti", now).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			},
+		},
+		{
+			name:    "failed",
+			wantErr: true,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT count\(\*\) FROM "revoked_tokens" .+`).
+					WithArgs("jti", now).
+					WillReturnError(assert.AnError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			got, err := p.IsRevoked(context.Background(), "jti")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgres_TokenVersion(t *testing.T) {
+	p, mock := newMockPostgres(t)
+
+	tests := []struct {
+		name   string
+		want   uint
+		mockFn func()
+	}{
+		{
+			name: "stored",
+			want: 3,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT \* FROM "user_token_versions" WHERE user_id = .+`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "version"}).AddRow(1, 3))
+			},
+		},
+		{
+			name: "never bumped",
+			want: 0,
+			mockFn: func() {
+				mock.ExpectQuery(`SELECT \* FROM "user_token_versions" WHERE user_id = .+`).
+					WithArgs(1, 1).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "version"}))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFn()
+			got, err := p.TokenVersion(context.Background(), 1)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgres_BumpTokenVersion(t *testing.T) {
+	p, mock := newMockPostgres(t)
+
+	mock.ExpectQuery(`INSERT INTO user_token_versions .+ ON CONFLICT \(user_id\) DO UPDATE .+ RETURNING version`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+
+	got, err := p.BumpTokenVersion(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
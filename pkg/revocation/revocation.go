@@ -0,0 +1,23 @@
+// Package revocation keeps track of access tokens that must no longer be
+// honoured before they expire, either one by one through their jti or all
+// at once through a per-user token version.
+package revocation
+
+import (
+	"context"
+	"time"
+)
+
+type Store interface {
+	// Revoke rejects the token with the given jti until expiresAt, after
+	// which the token is dead anyway and the entry may be dropped.
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	// IsRevoked reports whether the token with the given jti was revoked.
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	// TokenVersion returns the current token version of the user, tokens
+	// carrying an older version are rejected.
+	TokenVersion(ctx context.Context, userID uint) (uint, error)
+	// BumpTokenVersion increments the token version of the user and returns
+	// the new version.
+	BumpTokenVersion(ctx context.Context, userID uint) (uint, error)
+}
@@ -0,0 +1,80 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfcSecret is the SHA1 key of the RFC 6238 test vectors.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestCode(t *testing.T) {
+	// the RFC lists 8 digit codes, these are their last 6 digits
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "time %d", tt.unix)
+	}
+
+	_, err := Code("not base32!", 1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := Step(now)
+
+	step, ok := Validate(rfcSecret, "050471", now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, current, step)
+
+	previous, err := Code(rfcSecret, current-1)
+	assert.NoError(t, err)
+	step, ok = Validate(rfcSecret, previous, now, 1)
+	assert.True(t, ok)
+	assert.Equal(t, current-1, step)
+
+	_, ok = Validate(rfcSecret, previous, now, 0)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "000000", now, 1)
+	assert.False(t, ok)
+	_, ok = Validate(rfcSecret, "50471", now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	other, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.NotEqual(t, secret, other)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("go-spotify", "test@gmail.com", "JBSWY3DPEHPK3PXP")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/go-spotify:test@gmail.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=go-spotify")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}